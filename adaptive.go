@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// AdaptiveCalibrationQueries is the number of queries sent to each server by
+// the --adaptive-timeout calibration pass
+const AdaptiveCalibrationQueries = 5
+
+// AdaptiveTimeoutFloor is the lowest timeout --adaptive-timeout sets, so
+// jitter on a very fast server isn't mistaken for a failure
+const AdaptiveTimeoutFloor = 50 * time.Millisecond
+
+// ServerTimeout represents the effective timeout of a server in the main run
+type ServerTimeout struct {
+	Server  DNSServer     `json:"server"`
+	Median  time.Duration `json:"median_ms,omitempty"` // Median calibration response time, 0 if none succeeded
+	Timeout time.Duration `json:"timeout_ms"`
+}
+
+// calibrateTimeouts queries every server a few times with the fixed timeout
+// and derives its timeout as multiplier times the median response time,
+// bounded by AdaptiveTimeoutFloor and the fixed timeout. Servers without a
+// successful calibration query keep the fixed timeout.
+func calibrateTimeouts(client *dns.Client, servers []DNSServer, domains []DomainCategory, opts TestOptions) []ServerTimeout {
+	fmt.Fprintf(infoOutput, "Calibrating timeouts of %d DNS servers...\n", len(servers))
+
+	timeouts := make([]ServerTimeout, len(servers))
+	jobs := make(chan int, len(servers))
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				var times []time.Duration
+				for q := 0; q < AdaptiveCalibrationQueries; q++ {
+					result := testDNS(client, nil, servers[idx], domains[q%len(domains)].Domain, opts)
+					if result.Success {
+						times = append(times, result.ResponseTime)
+					}
+				}
+
+				timeouts[idx] = ServerTimeout{Server: servers[idx], Timeout: opts.Timeout}
+				if len(times) > 0 {
+					sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+					median := times[len(times)/2]
+					timeout := time.Duration(float64(median) * opts.AdaptiveTimeout)
+					timeouts[idx].Median = median
+					timeouts[idx].Timeout = min(max(timeout, AdaptiveTimeoutFloor), opts.Timeout)
+				}
+			}
+		}()
+	}
+
+	for i := range servers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return timeouts
+}
+
+// queryTimeout returns the timeout of queries to server, which is the
+// calibrated one with --adaptive-timeout
+func (opts TestOptions) queryTimeout(server DNSServer) time.Duration {
+	if timeout, ok := opts.ServerTimeouts[server.label()]; ok {
+		return timeout
+	}
+	return opts.Timeout
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestCalibrateTimeouts(t *testing.T) {
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	fast := DNSServer{IP: ip}
+	down := DNSServer{IP: "127.0.0.253"} // Nothing listens here
+
+	opts := TestOptions{Timeout: 2 * time.Second, Workers: 2, QueryType: dns.TypeA, AdaptiveTimeout: 3}
+	domains := []DomainCategory{{Domain: "one.com"}, {Domain: "two.com"}}
+	timeouts := calibrateTimeouts(newDNSClient(opts), []DNSServer{fast, down}, domains, opts)
+
+	if len(timeouts) != 2 {
+		t.Fatalf("calibrateTimeouts returned %d timeouts, want 2", len(timeouts))
+	}
+	// A loopback server answers far below the floor
+	if timeouts[0].Server != fast || timeouts[0].Median <= 0 || timeouts[0].Timeout != AdaptiveTimeoutFloor {
+		t.Errorf("fast server = %+v, want a median and the %v floor", timeouts[0], AdaptiveTimeoutFloor)
+	}
+	if timeouts[1].Server != down || timeouts[1].Median != 0 || timeouts[1].Timeout != opts.Timeout {
+		t.Errorf("failing server = %+v, want the fixed %v timeout", timeouts[1], opts.Timeout)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	opts := TestOptions{Timeout: 2 * time.Second}
+	if got := opts.queryTimeout(DNSServer{IP: "1.1.1.1"}); got != 2*time.Second {
+		t.Errorf("queryTimeout without calibration = %v, want the fixed 2s", got)
+	}
+
+	opts.ServerTimeouts = map[string]time.Duration{"1.1.1.1": 90 * time.Millisecond}
+	if got := opts.queryTimeout(DNSServer{IP: "1.1.1.1"}); got != 90*time.Millisecond {
+		t.Errorf("queryTimeout of a calibrated server = %v, want 90ms", got)
+	}
+	if got := opts.queryTimeout(DNSServer{IP: "8.8.8.8"}); got != 2*time.Second {
+		t.Errorf("queryTimeout of an uncalibrated server = %v, want the fixed 2s", got)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// localhostAddress caches the address "localhost" resolved to, so that it is
+// looked up once however many list entries use it
+var localhostAddress string
+
+// address returns the host:port to query the server at, using defaultPort
+// unless the server list gave an explicit port
+func (s DNSServer) address(defaultPort string) string {
+	port := defaultPort
+	if s.Port != "" {
+		port = s.Port
+	}
+	return net.JoinHostPort(s.IP, port)
+}
+
+// label identifies the server in reports: its IP, with the port when one was
+// given explicitly, after the hostname when the list named it by one. A
+// server listed by DoH URL is prefixed with "doh:", so it stays apart from
+// the plain resolver at the same address.
+func (s DNSServer) label() string {
+	address := s.IP
+	if s.Port != "" {
+		address = net.JoinHostPort(s.IP, s.Port)
+	}
+	if s.Hostname != "" {
+		address = s.Hostname + "/" + address
+	}
+	if s.Protocol == ProtocolHTTPS {
+		return "doh:" + address
+	}
+	return address
+}
+
+// parseServerAddress parses a server list entry: an IP address, optionally
+// with a port as in 127.0.0.1:5335 or [::1]:5335. "localhost" is accepted in
+// place of the IP and resolved to its loopback address, preferring IPv4.
+func parseServerAddress(entry string) (ip, port string, err error) {
+	host := entry
+	if h, p, splitErr := net.SplitHostPort(entry); splitErr == nil {
+		host, port = h, p
+		if n, convErr := strconv.Atoi(port); convErr != nil || n < 1 || n > 65535 {
+			return "", "", fmt.Errorf("invalid port in '%s'", entry)
+		}
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	if strings.EqualFold(host, "localhost") {
+		if localhostAddress == "" {
+			if localhostAddress, err = resolveLocalhost(); err != nil {
+				return "", "", err
+			}
+		}
+		return localhostAddress, port, nil
+	}
+
+	if net.ParseIP(host) == nil {
+		return "", "", fmt.Errorf("invalid IP address '%s'", entry)
+	}
+	return host, port, nil
+}
+
+// isPortField reports whether value is a port number, as in port=5335
+func isPortField(field string) bool {
+	n, err := strconv.Atoi(field)
+	return err == nil && n >= 1 && n <= 65535 && strconv.Itoa(n) == field
+}
+
+// resolveLocalhost returns the address localhost resolves to, preferring IPv4
+func resolveLocalhost() (string, error) {
+	ips, err := net.LookupIP("localhost")
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve localhost: %v", err)
+	}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip.String(), nil
+		}
+	}
+	if len(ips) == 0 {
+		return "", fmt.Errorf("cannot resolve localhost: no addresses")
+	}
+	return ips[0].String(), nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestParseServerAddress(t *testing.T) {
+	tests := []struct {
+		entry    string
+		wantIP   string
+		wantPort string
+		wantErr  bool
+	}{
+		{"1.1.1.1", "1.1.1.1", "", false},
+		{"127.0.0.1:5335", "127.0.0.1", "5335", false},
+		{"2001:db8::1", "2001:db8::1", "", false},
+		{"[::1]:5335", "::1", "5335", false},
+		{"[::1]", "::1", "", false},
+		{"127.0.0.1:0", "", "", true},
+		{"127.0.0.1:70000", "", "", true},
+		{"127.0.0.1:dns", "", "", true},
+		{"not-an-ip", "", "", true},
+		{"not-an-ip:53", "", "", true},
+	}
+	for _, tt := range tests {
+		ip, port, err := parseServerAddress(tt.entry)
+		if (err != nil) != tt.wantErr || ip != tt.wantIP || port != tt.wantPort {
+			t.Errorf("parseServerAddress(%q) = %q, %q, %v, want %q, %q, error %v", tt.entry, ip, port, err, tt.wantIP, tt.wantPort, tt.wantErr)
+		}
+	}
+}
+
+func TestParseServerAddressLocalhost(t *testing.T) {
+	ip, port, err := parseServerAddress("localhost:5335")
+	if err != nil {
+		t.Skipf("localhost does not resolve here: %v", err)
+	}
+	if parsed := net.ParseIP(ip); parsed == nil || !parsed.IsLoopback() || port != "5335" {
+		t.Errorf("parseServerAddress(localhost:5335) = %q, %q, want a loopback address and port 5335", ip, port)
+	}
+}
+
+func TestServerAddressAndLabel(t *testing.T) {
+	tests := []struct {
+		server  DNSServer
+		address string
+		label   string
+	}{
+		{DNSServer{IP: "1.1.1.1"}, "1.1.1.1:53", "1.1.1.1"},
+		{DNSServer{IP: "127.0.0.1", Port: "5335"}, "127.0.0.1:5335", "127.0.0.1:5335"},
+		{DNSServer{IP: "::1", Port: "5335"}, "[::1]:5335", "[::1]:5335"},
+		{DNSServer{IP: "2001:db8::1"}, "[2001:db8::1]:53", "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		if got := tt.server.address("53"); got != tt.address {
+			t.Errorf("%+v address = %q, want %q", tt.server, got, tt.address)
+		}
+		if got := tt.server.label(); got != tt.label {
+			t.Errorf("%+v label = %q, want %q", tt.server, got, tt.label)
+		}
+	}
+}
+
+func TestExplicitPort(t *testing.T) {
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA}
+	result := testDNS(newDNSClient(opts), nil, DNSServer{IP: "127.0.0.1", Port: port}, "example.com", opts)
+	if !result.Success || result.IP != "192.0.2.53" {
+		t.Errorf("query to port %s = success %v, ip %q (%s), want the mock's answer", port, result.Success, result.IP, result.Error)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AlertExitCode is the exit status when an alert fires without --alert-webhook
+const AlertExitCode = 2
+
+// AlertWebhookTimeout bounds the POST of an alert payload
+const AlertWebhookTimeout = 10 * time.Second
+
+// Alert states
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+// ServerAlert represents a server whose success rate stayed below
+// --alert-below for --alert-cycles consecutive cycles, or recovered from it
+type ServerAlert struct {
+	Server      DNSServer `json:"server"`
+	State       string    `json:"state"`
+	SuccessRate float64   `json:"success_rate"` // In the latest cycle, or smoothed with --ema-alpha
+	Threshold   float64   `json:"threshold"`
+	Cycles      int       `json:"cycles"` // Consecutive cycles below the threshold
+}
+
+// AlertPayload is the JSON body posted to --alert-webhook
+type AlertPayload struct {
+	RunID     string        `json:"run_id"`
+	Timestamp time.Time     `json:"timestamp"`
+	Cycle     int           `json:"cycle"`
+	Alerts    []ServerAlert `json:"alerts"`
+}
+
+// alertTracker counts the consecutive cycles each server spent below the
+// threshold. An alert fires once when the count reaches minCycles and
+// resolves on the first cycle back at or above the threshold.
+type alertTracker struct {
+	threshold float64
+	minCycles int
+	servers   []DNSServer
+	below     map[DNSServer]int
+	rates     map[DNSServer]float64
+	firing    map[DNSServer]bool
+}
+
+func newAlertTracker(threshold float64, minCycles int) *alertTracker {
+	return &alertTracker{
+		threshold: threshold,
+		minCycles: minCycles,
+		below:     make(map[DNSServer]int),
+		rates:     make(map[DNSServer]float64),
+		firing:    make(map[DNSServer]bool),
+	}
+}
+
+// record updates the counts with the results of one cycle and returns the
+// alerts that fired or resolved in it. The rates of smoothed, when set,
+// replace those of the cycle.
+func (t *alertTracker) record(results []TestResult, smoothed map[DNSServer]float64) []ServerAlert {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+			if _, known := t.below[result.Server]; !known {
+				t.servers = append(t.servers, result.Server)
+			}
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	var changed []ServerAlert
+	for _, server := range servers {
+		rate := groupStats(byServer[server]).SuccessRate
+		if smoothed != nil {
+			rate = smoothed[server]
+		}
+		t.rates[server] = rate
+
+		if rate < t.threshold {
+			t.below[server]++
+			if t.below[server] >= t.minCycles && !t.firing[server] {
+				t.firing[server] = true
+				changed = append(changed, t.alert(server, AlertFiring))
+			}
+			continue
+		}
+
+		if t.firing[server] {
+			t.firing[server] = false
+			changed = append(changed, t.alert(server, AlertResolved))
+		}
+		t.below[server] = 0
+	}
+	return changed
+}
+
+// active returns the alerts currently firing, in the order the servers were
+// first seen
+func (t *alertTracker) active() []ServerAlert {
+	var alerts []ServerAlert
+	for _, server := range t.servers {
+		if t.firing[server] {
+			alerts = append(alerts, t.alert(server, AlertFiring))
+		}
+	}
+	return alerts
+}
+
+func (t *alertTracker) alert(server DNSServer, state string) ServerAlert {
+	return ServerAlert{
+		Server:      server,
+		State:       state,
+		SuccessRate: t.rates[server],
+		Threshold:   t.threshold,
+		Cycles:      t.below[server],
+	}
+}
+
+// postAlerts sends the alerts of a cycle to the webhook as an AlertPayload
+func postAlerts(url string, payload AlertPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: AlertWebhookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAlertTracker(t *testing.T) {
+	server := DNSServer{IP: "1.1.1.1"}
+	healthy := DNSServer{IP: "8.8.8.8"}
+	tracker := newAlertTracker(90, 2)
+
+	cycle := func(up bool) []ServerAlert {
+		return tracker.record([]TestResult{
+			{Server: server, Success: up},
+			{Server: healthy, Success: true},
+		}, nil)
+	}
+
+	if changed := cycle(false); changed != nil {
+		t.Errorf("first cycle below the threshold changed %+v, want no alert yet", changed)
+	}
+	changed := cycle(false)
+	if len(changed) != 1 || changed[0].Server != server || changed[0].State != AlertFiring || changed[0].Cycles != 2 {
+		t.Fatalf("second cycle below the threshold changed %+v, want %s firing after 2 cycles", changed, server.IP)
+	}
+	if changed := cycle(false); changed != nil {
+		t.Errorf("a third cycle below the threshold changed %+v, want the alert to fire only once", changed)
+	}
+	if active := tracker.active(); len(active) != 1 || active[0].Cycles != 3 {
+		t.Errorf("active() = %+v, want one alert 3 cycles below", active)
+	}
+
+	changed = cycle(true)
+	if len(changed) != 1 || changed[0].State != AlertResolved || changed[0].SuccessRate != 100 {
+		t.Errorf("recovery changed %+v, want the alert resolved", changed)
+	}
+	if active := tracker.active(); active != nil {
+		t.Errorf("active() after recovery = %+v, want none", active)
+	}
+}
+
+func TestPostAlerts(t *testing.T) {
+	var received AlertPayload
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("webhook got %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer webhook.Close()
+
+	payload := AlertPayload{RunID: "run-1", Cycle: 3, Alerts: []ServerAlert{{Server: DNSServer{IP: "1.1.1.1"}, State: AlertFiring}}}
+	if err := postAlerts(webhook.URL, payload); err != nil {
+		t.Fatalf("postAlerts error = %v", err)
+	}
+	if received.RunID != "run-1" || received.Cycle != 3 || len(received.Alerts) != 1 {
+		t.Errorf("webhook received %+v, want the posted payload", received)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := postAlerts(failing.URL, payload); err == nil {
+		t.Error("postAlerts to a failing webhook succeeded, want an error")
+	}
+}
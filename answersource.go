@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// Answer sources inferred for every response, best effort
+const (
+	AnswerSourceCache         = "cache"         // Served from the resolver's cache
+	AnswerSourceAuthoritative = "authoritative" // Answered by a server authoritative for the zone
+	AnswerSourceForwarded     = "forwarded"     // Fetched from upstream for this query
+	AnswerSourceUnknown       = "unknown"
+)
+
+// answerSources lists the sources in the order they are reported
+var answerSources = []string{AnswerSourceCache, AnswerSourceAuthoritative, AnswerSourceForwarded, AnswerSourceUnknown}
+
+// FreshTTLGranularity is the step zone administrators set TTLs in. A cache
+// counts the TTL down from the moment it stored the record, so an answer
+// whose TTL is off this grid has most likely sat in a cache for a while.
+const FreshTTLGranularity = 60
+
+// inferAnswerSource classifies where a response most likely came from. The
+// AA flag marks an authoritative answer. A recursive answer (RA set) is
+// compared with the authoritative TTL when known: a lower TTL was counted
+// down in a cache, the full TTL was just fetched from upstream. Without it,
+// a TTL on the FreshTTLGranularity grid counts as just fetched. Anything
+// else, e.g. a non-recursive referral, is unknown. This is a heuristic: a
+// cache hit can land on the grid, and some zones use odd TTLs.
+func inferAnswerSource(response *dns.Msg, qtype uint16, authTTL uint32) string {
+	if response.Authoritative {
+		return AnswerSourceAuthoritative
+	}
+	ttl, ok := answerTTL(response.Answer, qtype)
+	if !response.RecursionAvailable || !ok {
+		return AnswerSourceUnknown
+	}
+
+	switch {
+	case authTTL > 0 && ttl < authTTL:
+		return AnswerSourceCache
+	case authTTL > 0:
+		return AnswerSourceForwarded
+	case ttl%FreshTTLGranularity != 0:
+		return AnswerSourceCache
+	default:
+		return AnswerSourceForwarded
+	}
+}
+
+// answerSourceCounts counts the results by inferred answer source
+func answerSourceCounts(results []TestResult) map[string]int {
+	var counts map[string]int
+	for _, result := range results {
+		if result.AnswerSource == "" {
+			continue
+		}
+		if counts == nil {
+			counts = make(map[string]int)
+		}
+		counts[result.AnswerSource]++
+	}
+	return counts
+}
+
+// formatAnswerSources renders the non-zero answer source counts in report order
+func formatAnswerSources(counts map[string]int) string {
+	var parts []string
+	for _, source := range answerSources {
+		if counts[source] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", source, counts[source]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestInferAnswerSource(t *testing.T) {
+	response := func(authoritative, recursive bool, ttl uint32) *dns.Msg {
+		m := new(dns.Msg)
+		m.Authoritative = authoritative
+		m.RecursionAvailable = recursive
+		m.Answer = append(m.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: ttl},
+			A:   net.ParseIP("192.0.2.1"),
+		})
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		response *dns.Msg
+		qtype    uint16
+		authTTL  uint32
+		want     string
+	}{
+		{"AA flag", response(true, false, 300), dns.TypeA, 0, AnswerSourceAuthoritative},
+		{"AA flag with RA", response(true, true, 123), dns.TypeA, 300, AnswerSourceAuthoritative},
+		{"not recursive", response(false, false, 300), dns.TypeA, 0, AnswerSourceUnknown},
+		{"no answer of the type", response(false, true, 300), dns.TypeAAAA, 0, AnswerSourceUnknown},
+		{"below authoritative TTL", response(false, true, 250), dns.TypeA, 300, AnswerSourceCache},
+		{"full authoritative TTL", response(false, true, 300), dns.TypeA, 300, AnswerSourceForwarded},
+		{"off the grid", response(false, true, 299), dns.TypeA, 0, AnswerSourceCache},
+		{"on the grid", response(false, true, 300), dns.TypeA, 0, AnswerSourceForwarded},
+	}
+	for _, tt := range tests {
+		if got := inferAnswerSource(tt.response, tt.qtype, tt.authTTL); got != tt.want {
+			t.Errorf("%s: inferAnswerSource = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import "github.com/miekg/dns"
+
+// Behaviors of servers answering --query-type ANY
+const (
+	AnyFull    = "full"    // Records of several types
+	AnyMinimal = "minimal" // A single HINFO record (RFC 8482) or records of one type
+	AnyRefused = "refused" // REFUSED or NOTIMP
+	AnyEmpty   = "empty"   // No records, with any other RCODE
+)
+
+// anyBehaviors lists the ANY behaviors from most to least complete
+var anyBehaviors = []string{AnyFull, AnyMinimal, AnyEmpty, AnyRefused}
+
+// AnyResponse represents what a server returned for an ANY query
+type AnyResponse struct {
+	Rcode     string         `json:"rcode"`
+	Records   int            `json:"records"`
+	Types     map[string]int `json:"types,omitempty"` // Record type -> count
+	Truncated bool           `json:"truncated,omitempty"`
+	Behavior  string         `json:"behavior"`
+}
+
+// ServerAnyBehavior represents how a server handled the ANY queries of a run
+type ServerAnyBehavior struct {
+	Server    DNSServer      `json:"server"`
+	Behavior  string         `json:"behavior"`  // Most common behavior
+	Behaviors map[string]int `json:"behaviors"` // Behavior -> number of domains
+}
+
+// classifyAny records the answer of an ANY query and classifies it
+func classifyAny(response *dns.Msg) *AnyResponse {
+	answer := &AnyResponse{
+		Rcode:     dns.RcodeToString[response.Rcode],
+		Records:   len(response.Answer),
+		Truncated: response.Truncated,
+	}
+
+	for _, rr := range response.Answer {
+		if answer.Types == nil {
+			answer.Types = make(map[string]int)
+		}
+		answer.Types[dns.TypeToString[rr.Header().Rrtype]]++
+	}
+
+	switch {
+	case response.Rcode == dns.RcodeRefused || response.Rcode == dns.RcodeNotImplemented:
+		answer.Behavior = AnyRefused
+	case answer.Records == 0:
+		answer.Behavior = AnyEmpty
+	case len(answer.Types) == 1:
+		answer.Behavior = AnyMinimal
+	default:
+		answer.Behavior = AnyFull
+	}
+	return answer
+}
+
+// anyBehavior summarizes the ANY responses per server, or returns nil when
+// the run didn't query ANY. Ties between behaviors go to the most complete
+// one.
+func anyBehavior(results []TestResult) []ServerAnyBehavior {
+	var servers []DNSServer
+	counts := make(map[DNSServer]map[string]int)
+	for _, result := range results {
+		if result.Any == nil {
+			continue
+		}
+		if _, seen := counts[result.Server]; !seen {
+			servers = append(servers, result.Server)
+			counts[result.Server] = make(map[string]int)
+		}
+		counts[result.Server][result.Any.Behavior]++
+	}
+
+	var behaviors []ServerAnyBehavior
+	for _, server := range servers {
+		entry := ServerAnyBehavior{Server: server, Behaviors: counts[server]}
+		for _, behavior := range anyBehaviors {
+			if entry.Behavior == "" || counts[server][behavior] > counts[server][entry.Behavior] {
+				entry.Behavior = behavior
+			}
+		}
+		behaviors = append(behaviors, entry)
+	}
+	return behaviors
+}
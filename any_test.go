@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestClassifyAny(t *testing.T) {
+	a := &dns.A{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA}, A: net.ParseIP("192.0.2.1")}
+	mx := &dns.MX{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeMX}, Mx: "mail.example.com."}
+	hinfo := &dns.HINFO{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeHINFO}, Cpu: "RFC8482"}
+
+	tests := []struct {
+		name     string
+		rcode    int
+		answer   []dns.RR
+		behavior string
+		records  int
+	}{
+		{"several types", dns.RcodeSuccess, []dns.RR{a, a, mx}, AnyFull, 3},
+		{"RFC 8482 HINFO", dns.RcodeSuccess, []dns.RR{hinfo}, AnyMinimal, 1},
+		{"one type", dns.RcodeSuccess, []dns.RR{a, a}, AnyMinimal, 2},
+		{"no records", dns.RcodeSuccess, nil, AnyEmpty, 0},
+		{"refused", dns.RcodeRefused, nil, AnyRefused, 0},
+		{"not implemented", dns.RcodeNotImplemented, nil, AnyRefused, 0},
+	}
+	for _, tt := range tests {
+		response := &dns.Msg{MsgHdr: dns.MsgHdr{Rcode: tt.rcode}, Answer: tt.answer}
+		got := classifyAny(response)
+		if got.Behavior != tt.behavior || got.Records != tt.records || got.Rcode != dns.RcodeToString[tt.rcode] {
+			t.Errorf("%s: classifyAny = %+v, want %s with %d records", tt.name, got, tt.behavior, tt.records)
+		}
+	}
+	if got := classifyAny(&dns.Msg{Answer: []dns.RR{a, a, mx}}); got.Types["A"] != 2 || got.Types["MX"] != 1 {
+		t.Errorf("types = %v, want 2 A and 1 MX", got.Types)
+	}
+}
+
+func TestAnyBehavior(t *testing.T) {
+	a := DNSServer{IP: "1.1.1.1"}
+	b := DNSServer{IP: "8.8.8.8"}
+	results := []TestResult{
+		{Server: a, Any: &AnyResponse{Behavior: AnyMinimal}},
+		{Server: a, Any: &AnyResponse{Behavior: AnyMinimal}},
+		{Server: a, Any: &AnyResponse{Behavior: AnyFull}},
+		// A tie goes to the more complete behavior
+		{Server: b, Any: &AnyResponse{Behavior: AnyRefused}},
+		{Server: b, Any: &AnyResponse{Behavior: AnyEmpty}},
+		{Server: b, Error: "timeout"},
+	}
+
+	behaviors := anyBehavior(results)
+	if len(behaviors) != 2 || behaviors[0].Behavior != AnyMinimal || behaviors[1].Behavior != AnyEmpty {
+		t.Fatalf("anyBehavior = %+v, want %s for %s and %s for %s", behaviors, AnyMinimal, a.IP, AnyEmpty, b.IP)
+	}
+	if behaviors[0].Behaviors[AnyMinimal] != 2 || behaviors[0].Behaviors[AnyFull] != 1 {
+		t.Errorf("%s behaviors = %v, want 2 minimal and 1 full", a.IP, behaviors[0].Behaviors)
+	}
+
+	if got := anyBehavior([]TestResult{{Server: a, Success: true}}); got != nil {
+		t.Errorf("anyBehavior without ANY queries = %+v, want nil", got)
+	}
+}
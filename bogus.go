@@ -0,0 +1,73 @@
+package main
+
+import "net/netip"
+
+// reservedPrefixes are the ranges no public name should resolve into, on top
+// of what the netip predicates cover: shared address space, documentation,
+// benchmarking and the reserved class E range
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// BogusServer represents a server answering public domains with addresses
+// that aren't routable on the Internet
+type BogusServer struct {
+	Server  DNSServer `json:"server"`
+	Answers int       `json:"answers"`
+}
+
+// isBogusAnswer reports whether a successful result resolved to an address
+// that isn't publicly routable: unspecified, loopback, private, link-local,
+// multicast, broadcast or reserved. Sinkhole answers for the categories a
+// filtering resolver is expected to block are not bogus.
+func isBogusAnswer(result TestResult) bool {
+	if !result.Success || result.IP == "" {
+		return false
+	}
+	if result.Blocked && blockingCategories[result.Category] {
+		return false
+	}
+	addr, err := netip.ParseAddr(result.IP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	if addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() || addr.IsMulticast() ||
+		addr.IsLinkLocalUnicast() || addr.IsInterfaceLocalMulticast() {
+		return true
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// bogusServers counts the bogus answers of every server, in the order the
+// servers were first seen
+func bogusServers(results []TestResult) []BogusServer {
+	var servers []BogusServer
+	index := make(map[DNSServer]int)
+	for _, result := range results {
+		if !result.BogusAnswer {
+			continue
+		}
+		i, seen := index[result.Server]
+		if !seen {
+			i = len(servers)
+			index[result.Server] = i
+			servers = append(servers, BogusServer{Server: result.Server})
+		}
+		servers[i].Answers++
+	}
+	return servers
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsBogusAnswer(t *testing.T) {
+	tests := []struct {
+		result TestResult
+		want   bool
+	}{
+		{TestResult{Success: true, IP: "93.184.216.34"}, false},
+		{TestResult{Success: true, IP: "2606:2800:220:1::1"}, false},
+		{TestResult{Success: true, IP: "10.1.2.3"}, true},
+		{TestResult{Success: true, IP: "192.168.0.1"}, true},
+		{TestResult{Success: true, IP: "127.0.0.1"}, true},
+		{TestResult{Success: true, IP: "0.0.0.0"}, true},
+		{TestResult{Success: true, IP: "169.254.1.1"}, true},
+		{TestResult{Success: true, IP: "100.64.0.1"}, true},
+		{TestResult{Success: true, IP: "192.0.2.1"}, true},
+		{TestResult{Success: true, IP: "240.0.0.1"}, true},
+		{TestResult{Success: true, IP: "224.0.0.1"}, true},
+		{TestResult{Success: true, IP: "fe80::1"}, true},
+		{TestResult{Success: true, IP: "fd00::1"}, true},
+		{TestResult{Success: true, IP: "2001:db8::1"}, true},
+		{TestResult{Success: true, IP: "::ffff:10.0.0.1"}, true},
+		{TestResult{Success: true, IP: "0.0.0.0", Blocked: true, Category: CategoryAdServer}, false},
+		{TestResult{Success: true, IP: "0.0.0.0", Blocked: true, Category: CategoryGeneral}, true},
+		{TestResult{Error: "timeout", IP: "10.0.0.1"}, false},
+		{TestResult{Success: true}, false},
+	}
+	for _, tt := range tests {
+		if got := isBogusAnswer(tt.result); got != tt.want {
+			t.Errorf("isBogusAnswer(%s in %q) = %v, want %v", tt.result.IP, tt.result.Category, got, tt.want)
+		}
+	}
+}
+
+func TestBogusServers(t *testing.T) {
+	a, b := DNSServer{IP: "192.0.2.1"}, DNSServer{IP: "192.0.2.2"}
+	results := []TestResult{
+		{Server: b, BogusAnswer: true},
+		{Server: a},
+		{Server: a, BogusAnswer: true},
+		{Server: b, BogusAnswer: true},
+	}
+	want := []BogusServer{{Server: b, Answers: 2}, {Server: a, Answers: 1}}
+	if got := bogusServers(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("bogusServers = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CheckpointInterval is how often completed results are persisted to --checkpoint
+const CheckpointInterval = 10 * time.Second
+
+// checkpointState is the on-disk format of a --checkpoint file
+type checkpointState struct {
+	Results []TestResult `json:"results"`
+}
+
+// checkpointKey identifies a server/domain pair across runs
+func checkpointKey(server DNSServer, family, domain string) string {
+	return server.label() + "|" + family + "|" + domain
+}
+
+// loadCheckpoint returns the results completed by a previous run, or nil when
+// the checkpoint file doesn't exist yet
+func loadCheckpoint(path string) ([]TestResult, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state checkpointState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state.Results, nil
+}
+
+// saveCheckpoint atomically replaces the checkpoint file with results, so an
+// interruption mid-write never leaves a truncated checkpoint behind
+func saveCheckpoint(path string, results []TestResult) error {
+	data, err := json.Marshal(checkpointState{Results: results})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
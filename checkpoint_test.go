@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "run.checkpoint")
+
+	results, err := loadCheckpoint(path)
+	if err != nil || results != nil {
+		t.Errorf("loadCheckpoint of a missing file = %v, %v, want nothing", results, err)
+	}
+
+	saved := []TestResult{
+		{Server: DNSServer{IP: "192.0.2.1"}, Domain: "a.example", Success: true, IP: "198.51.100.1"},
+		{Server: DNSServer{IP: "192.0.2.1"}, Domain: "b.example", Error: "timeout"},
+	}
+	if err := saveCheckpoint(path, saved); err != nil {
+		t.Fatalf("saveCheckpoint error = %v", err)
+	}
+	results, err = loadCheckpoint(path)
+	if err != nil || len(results) != 2 || results[0].IP != "198.51.100.1" || results[1].Error != "timeout" {
+		t.Errorf("loadCheckpoint = %+v, %v, want the saved results", results, err)
+	}
+
+	// The temporary file is renamed over the checkpoint, nothing is left over
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("checkpoint directory holds %d entries, want 1", len(entries))
+	}
+
+	if err := os.WriteFile(path, []byte("{truncated"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCheckpoint(path); err == nil {
+		t.Error("loadCheckpoint of a malformed file succeeded, want an error")
+	}
+}
+
+func TestRunDNSTestsResumesFromCheckpoint(t *testing.T) {
+	// Nothing listens on the server, so only the checkpointed pair succeeds
+	server := DNSServer{IP: "127.0.0.253"}
+	path := filepath.Join(t.TempDir(), "run.checkpoint")
+	done := TestResult{Server: server, Domain: "a.example", Category: CategoryGeneral, Success: true, IP: "198.51.100.1"}
+	if err := saveCheckpoint(path, []TestResult{done}); err != nil {
+		t.Fatal(err)
+	}
+
+	domains := []DomainCategory{{Domain: "a.example", Category: CategoryGeneral}, {Domain: "b.example", Category: CategoryGeneral}}
+	results := runDNSTests([]DNSServer{server}, domains, TestOptions{Timeout: time.Second, Workers: 1, Checkpoint: path})
+	if len(results.Results) != 2 || results.Summary.TotalQueries != 1 {
+		t.Fatalf("got %d results from %d queries, want 2 results and only b.example queried",
+			len(results.Results), results.Summary.TotalQueries)
+	}
+	if a := results.Results[0]; a.Domain != "a.example" || !a.Success || a.IP != "198.51.100.1" {
+		t.Errorf("a.example = %+v, want the checkpointed result", a)
+	}
+	if b := results.Results[1]; b.Domain != "b.example" || b.Success {
+		t.Errorf("b.example = %+v, want a fresh failure", b)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import "time"
+
+// ColdWarmMinSamples is the sample count --cold-warm raises --samples to, so
+// every pair has one cold and at least two warm queries
+const ColdWarmMinSamples = 3
+
+// ServerColdWarm represents the cold and warm latency of one server, averaged
+// over its pairs where both were measured
+type ServerColdWarm struct {
+	Server DNSServer     `json:"server"`
+	Pairs  int           `json:"pairs"`
+	Cold   time.Duration `json:"cold_ms"`
+	Warm   time.Duration `json:"warm_ms"`
+	Delta  time.Duration `json:"delta_ms"` // Cold minus warm, the gain from the server's cache
+}
+
+// coldWarmStats groups the cold/warm measurements by server. It returns nil
+// when no result carries both latencies.
+func coldWarmStats(results []TestResult) []ServerColdWarm {
+	var stats []ServerColdWarm
+	index := make(map[DNSServer]int)
+
+	for _, result := range results {
+		if result.WarmResponseTime == 0 {
+			continue
+		}
+
+		i, exists := index[result.Server]
+		if !exists {
+			i = len(stats)
+			index[result.Server] = i
+			stats = append(stats, ServerColdWarm{Server: result.Server})
+		}
+		stats[i].Pairs++
+		stats[i].Cold += result.ColdResponseTime
+		stats[i].Warm += result.WarmResponseTime
+	}
+
+	for i := range stats {
+		stats[i].Cold /= time.Duration(stats[i].Pairs)
+		stats[i].Warm /= time.Duration(stats[i].Pairs)
+		stats[i].Delta = stats[i].Cold - stats[i].Warm
+	}
+
+	return stats
+}
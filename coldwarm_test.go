@@ -0,0 +1,67 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestColdWarmStats(t *testing.T) {
+	a := DNSServer{IP: "1.1.1.1"}
+	b := DNSServer{IP: "8.8.8.8"}
+	ms := time.Millisecond
+	results := []TestResult{
+		{Server: a, ColdResponseTime: 80 * ms, WarmResponseTime: 10 * ms},
+		{Server: a, ColdResponseTime: 40 * ms, WarmResponseTime: 20 * ms},
+		{Server: b, ColdResponseTime: 30 * ms, WarmResponseTime: 20 * ms},
+		{Server: b, ResponseTime: 90 * ms}, // Not measured cold and warm
+	}
+
+	stats := coldWarmStats(results)
+	want := []ServerColdWarm{
+		{Server: a, Pairs: 2, Cold: 60 * ms, Warm: 15 * ms, Delta: 45 * ms},
+		{Server: b, Pairs: 1, Cold: 30 * ms, Warm: 20 * ms, Delta: 10 * ms},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("coldWarmStats = %+v, want %+v", stats, want)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("coldWarmStats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+
+	if stats := coldWarmStats([]TestResult{{Server: a, ResponseTime: ms}}); stats != nil {
+		t.Errorf("coldWarmStats without cold/warm results = %+v, want nil", stats)
+	}
+}
+
+func TestDNSSamplesColdWarm(t *testing.T) {
+	// The first query is slow, as if the server had to recurse
+	var queries int64
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if atomic.AddInt64(&queries, 1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	limiter := newThrottle(nil, TestOptions{})
+	defer limiter.stop()
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Samples: ColdWarmMinSamples, ColdWarm: true}
+	result := testDNSSamples(newDNSClient(opts), nil, DNSServer{IP: ip}, "example.com", opts, limiter, nil)
+	if result.ColdResponseTime < 100*time.Millisecond || result.WarmResponseTime <= 0 || result.WarmResponseTime >= result.ColdResponseTime {
+		t.Errorf("cold %v, warm %v, want a slow cold and a faster warm latency", result.ColdResponseTime, result.WarmResponseTime)
+	}
+	if result.ColdWarmDelta != result.ColdResponseTime-result.WarmResponseTime {
+		t.Errorf("delta = %v, want cold minus warm", result.ColdWarmDelta)
+	}
+
+	opts.ColdWarm = false
+	result = testDNSSamples(newDNSClient(opts), nil, DNSServer{IP: ip}, "example.com", opts, limiter, nil)
+	if result.ColdResponseTime != 0 || result.WarmResponseTime != 0 {
+		t.Errorf("without --cold-warm the result has cold %v, warm %v, want neither", result.ColdResponseTime, result.WarmResponseTime)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"time"
+)
+
+// ANSI escape sequences used by the text output
+const (
+	colorReset  = "\033[0m"
+	colorBold   = "\033[1m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+// SlowResponseThreshold marks successful responses slower than this in yellow
+const SlowResponseThreshold = 200 * time.Millisecond
+
+// colorize wraps s in the given color when enabled, returning it unchanged otherwise
+func colorize(enabled bool, color, s string) string {
+	if !enabled {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// resultStatus returns the status label of a result: OK (yellow when slow),
+// MISS for uncached answers and FAIL otherwise
+func resultStatus(result TestResult, color bool) string {
+	switch {
+	case result.Success && result.ResponseTime > SlowResponseThreshold:
+		return colorize(color, colorYellow, "  OK")
+	case result.Success:
+		return colorize(color, colorGreen, "  OK")
+	case result.Uncached:
+		return colorize(color, colorYellow, "MISS")
+	default:
+		return colorize(color, colorRed, "FAIL")
+	}
+}
+
+// useColor reports whether the text output should be colored: only when it
+// goes to a terminal, color isn't disabled with --no-color and NO_COLOR is unset
+func useColor(outputFile string, noColor bool) bool {
+	if noColor || outputFile != "" {
+		return false
+	}
+	if _, set := os.LookupEnv("NO_COLOR"); set {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
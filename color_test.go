@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestColorize(t *testing.T) {
+	if got := colorize(false, colorRed, "FAIL"); got != "FAIL" {
+		t.Errorf("colorize disabled = %q, want the plain text", got)
+	}
+	if got := colorize(true, colorRed, "FAIL"); got != colorRed+"FAIL"+colorReset {
+		t.Errorf("colorize enabled = %q, want it wrapped in red", got)
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	if useColor("", true) {
+		t.Error("useColor with --no-color = true")
+	}
+	if useColor("results.txt", false) {
+		t.Error("useColor with an output file = true")
+	}
+	t.Setenv("NO_COLOR", "")
+	if useColor("", false) {
+		t.Error("useColor with NO_COLOR set = true")
+	}
+}
+
+func TestResultStatus(t *testing.T) {
+	tests := []struct {
+		result TestResult
+		want   string
+	}{
+		{TestResult{Success: true, ResponseTime: time.Millisecond}, colorGreen + "  OK" + colorReset},
+		{TestResult{Success: true, ResponseTime: SlowResponseThreshold + 1}, colorYellow + "  OK" + colorReset},
+		{TestResult{Uncached: true}, colorYellow + "MISS" + colorReset},
+		{TestResult{Error: "timeout"}, colorRed + "FAIL" + colorReset},
+	}
+	for _, tt := range tests {
+		if got := resultStatus(tt.result, true); got != tt.want {
+			t.Errorf("resultStatus(%+v) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+	if got := resultStatus(TestResult{Error: "timeout"}, false); got != "FAIL" {
+		t.Errorf("resultStatus without color = %q, want FAIL", got)
+	}
+}
+
+func TestTextOutputColors(t *testing.T) {
+	server := DNSServer{IP: "192.0.2.1"}
+	results := TestResults{Results: []TestResult{
+		{Server: server, Domain: "fast.example", Category: CategoryGeneral, Success: true, ResponseTime: 10 * time.Millisecond},
+		{Server: server, Domain: "slow.example", Category: CategoryGeneral, Success: true, ResponseTime: SlowResponseThreshold + time.Millisecond},
+		{Server: server, Domain: "down.example", Category: CategoryGeneral, Error: "timeout"},
+	}}
+	results.Summary = calculateSummary(results.Results)
+
+	var plain, colored strings.Builder
+	writeTextOutput(&plain, results, OutputOptions{})
+	writeTextOutput(&colored, results, OutputOptions{Color: true})
+
+	if strings.Contains(plain.String(), "\033[") {
+		t.Error("text output without color holds escape sequences")
+	}
+	for _, want := range []string{
+		colorGreen + "  OK" + colorReset,
+		colorYellow + "  OK" + colorReset,
+		colorRed + "FAIL" + colorReset,
+		colorBold + "Summary:" + colorReset,
+	} {
+		if !strings.Contains(colored.String(), want) {
+			t.Errorf("colored text output lacks %q", want)
+		}
+	}
+}
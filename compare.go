@@ -0,0 +1,317 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DomainComparison represents the head-to-head outcome for a single domain
+type DomainComparison struct {
+	Domain        string        `json:"domain"`
+	Category      string        `json:"category"`
+	SuccessA      bool          `json:"success_a"`
+	SuccessB      bool          `json:"success_b"`
+	ResponseTimeA time.Duration `json:"response_time_a_ms"`
+	ResponseTimeB time.Duration `json:"response_time_b_ms"`
+	Delta         time.Duration `json:"delta_ms"`
+	IPA           string        `json:"resolved_ip_a,omitempty"`
+	IPB           string        `json:"resolved_ip_b,omitempty"`
+	Winner        string        `json:"winner,omitempty"`
+	IPMismatch    bool          `json:"ip_mismatch"`
+}
+
+// ServerComparison represents a head-to-head comparison of two DNS servers
+type ServerComparison struct {
+	Timestamp            time.Time          `json:"timestamp"`
+	ServerA              DNSServer          `json:"server_a"`
+	ServerB              DNSServer          `json:"server_b"`
+	Domains              []DomainComparison `json:"domains"`
+	WinsA                int                `json:"wins_a"`
+	WinsB                int                `json:"wins_b"`
+	SuccessRateA         float64            `json:"success_rate_a"`
+	SuccessRateB         float64            `json:"success_rate_b"`
+	AverageResponseTimeA time.Duration      `json:"average_response_time_a_ms"`
+	AverageResponseTimeB time.Duration      `json:"average_response_time_b_ms"`
+	Disagreements        int                `json:"disagreements"`
+	Verdict              string             `json:"verdict"`
+}
+
+// parseCompareGranularity parses --compare-granularity: "exact" compares
+// resolved IPs as-is, "/N" compares their IPv4 /N networks (IPv6 addresses use
+// a /2N prefix, so /24 becomes /48)
+func parseCompareGranularity(arg string) (int, error) {
+	if arg == "exact" {
+		return 0, nil
+	}
+
+	bits, err := strconv.Atoi(strings.TrimPrefix(arg, "/"))
+	if err != nil || bits < 1 || bits > 32 {
+		return 0, fmt.Errorf("expected exact or a prefix length between /1 and /32, got '%s'", arg)
+	}
+	return bits, nil
+}
+
+// sameNetwork reports whether two resolved IPs match at the given prefix
+// length, comparing them exactly when prefixBits is 0
+func sameNetwork(a, b string, prefixBits int) bool {
+	if prefixBits == 0 || a == b {
+		return a == b
+	}
+
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return false
+	}
+
+	if ipA.To4() != nil && ipB.To4() != nil {
+		mask := net.CIDRMask(prefixBits, 32)
+		return ipA.To4().Mask(mask).Equal(ipB.To4().Mask(mask))
+	}
+
+	mask := net.CIDRMask(prefixBits*2, 128)
+	return ipA.To16().Mask(mask).Equal(ipB.To16().Mask(mask))
+}
+
+// parseCompareServers resolves the "A,B" argument of --compare-servers into two
+// servers. Each side uses the server list syntax (ip:port, port=, hostname,
+// dual-stack pair or DoH URL) and must name a single server; a server that is
+// also in the loaded list is taken from it, keeping its description.
+func parseCompareServers(arg string, servers []DNSServer) (DNSServer, DNSServer, error) {
+	parts := strings.Split(arg, ",")
+	if len(parts) != 2 {
+		return DNSServer{}, DNSServer{}, fmt.Errorf("expected exactly two servers, got %d", len(parts))
+	}
+
+	var selected [2]DNSServer
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		parsed, err := parseDNSServers(strings.NewReader(part), "--compare-servers", true)
+		if err != nil {
+			return DNSServer{}, DNSServer{}, err
+		}
+		if len(parsed) != 1 {
+			return DNSServer{}, DNSServer{}, fmt.Errorf("'%s' names %d servers, expected one", part, len(parsed))
+		}
+
+		selected[i] = parsed[0]
+		for _, server := range servers {
+			if sameServerAddress(server, parsed[0]) {
+				selected[i] = server
+				break
+			}
+		}
+	}
+
+	if sameServerAddress(selected[0], selected[1]) {
+		return DNSServer{}, DNSServer{}, fmt.Errorf("cannot compare server '%s' with itself", selected[0].label())
+	}
+
+	return selected[0], selected[1], nil
+}
+
+// sameServerAddress reports whether two servers are queried the same way,
+// ignoring the description and DoH headers the list may add
+func sameServerAddress(a, b DNSServer) bool {
+	a.Description, a.DoHHeaders = "", ""
+	b.Description, b.DoHHeaders = "", ""
+	return a == b
+}
+
+// compareServers compares the results of two servers. Resolved IPs count as a
+// disagreement when they differ at prefixBits (see sameNetwork).
+func compareServers(results TestResults, serverA, serverB DNSServer, domains []DomainCategory, prefixBits int) ServerComparison {
+	labelA, labelB := serverA.label(), serverB.label()
+	resultsA := make(map[string]TestResult)
+	resultsB := make(map[string]TestResult)
+	for _, result := range results.Results {
+		switch result.Server {
+		case serverA:
+			resultsA[result.Domain] = result
+		case serverB:
+			resultsB[result.Domain] = result
+		}
+	}
+
+	comparison := ServerComparison{
+		Timestamp: results.Timestamp,
+		ServerA:   serverA,
+		ServerB:   serverB,
+	}
+
+	var successA, successB int
+	var totalTimeA, totalTimeB time.Duration
+
+	for _, domain := range domains {
+		a, okA := resultsA[domain.Domain]
+		b, okB := resultsB[domain.Domain]
+		if !okA || !okB {
+			continue
+		}
+
+		dc := DomainComparison{
+			Domain:        domain.Domain,
+			Category:      domain.Category,
+			SuccessA:      a.Success,
+			SuccessB:      b.Success,
+			ResponseTimeA: a.ResponseTime,
+			ResponseTimeB: b.ResponseTime,
+			Delta:         a.ResponseTime - b.ResponseTime,
+			IPA:           a.IP,
+			IPB:           b.IP,
+		}
+
+		// A server that answered always beats one that did not; otherwise the faster one wins
+		switch {
+		case a.Success && !b.Success:
+			dc.Winner = labelA
+		case b.Success && !a.Success:
+			dc.Winner = labelB
+		case a.Success && b.Success && a.ResponseTime < b.ResponseTime:
+			dc.Winner = labelA
+		case a.Success && b.Success && b.ResponseTime < a.ResponseTime:
+			dc.Winner = labelB
+		}
+
+		switch dc.Winner {
+		case labelA:
+			comparison.WinsA++
+		case labelB:
+			comparison.WinsB++
+		}
+
+		if a.Success && b.Success && !sameNetwork(a.IP, b.IP, prefixBits) {
+			dc.IPMismatch = true
+			comparison.Disagreements++
+		}
+
+		if a.Success {
+			successA++
+			totalTimeA += a.ResponseTime
+		}
+		if b.Success {
+			successB++
+			totalTimeB += b.ResponseTime
+		}
+
+		comparison.Domains = append(comparison.Domains, dc)
+	}
+
+	if total := len(comparison.Domains); total > 0 {
+		comparison.SuccessRateA = float64(successA) / float64(total) * 100
+		comparison.SuccessRateB = float64(successB) / float64(total) * 100
+	}
+	if successA > 0 {
+		comparison.AverageResponseTimeA = totalTimeA / time.Duration(successA)
+	}
+	if successB > 0 {
+		comparison.AverageResponseTimeB = totalTimeB / time.Duration(successB)
+	}
+
+	comparison.Verdict = comparisonVerdict(comparison)
+
+	return comparison
+}
+
+func comparisonVerdict(c ServerComparison) string {
+	var speed, reliability string
+
+	switch {
+	case c.AverageResponseTimeA == 0 && c.AverageResponseTimeB == 0:
+		speed = "Neither server answered any query"
+	case c.AverageResponseTimeB == 0 || (c.AverageResponseTimeA != 0 && c.AverageResponseTimeA < c.AverageResponseTimeB):
+		speed = fmt.Sprintf("%s is faster by %v on average", c.ServerA.label(),
+			(c.AverageResponseTimeB - c.AverageResponseTimeA).Truncate(time.Millisecond))
+	case c.AverageResponseTimeA == 0 || c.AverageResponseTimeB < c.AverageResponseTimeA:
+		speed = fmt.Sprintf("%s is faster by %v on average", c.ServerB.label(),
+			(c.AverageResponseTimeA - c.AverageResponseTimeB).Truncate(time.Millisecond))
+	default:
+		speed = "Both servers are equally fast"
+	}
+
+	switch {
+	case c.SuccessRateA > c.SuccessRateB:
+		reliability = fmt.Sprintf("%s is more reliable (%.2f%% vs %.2f%%)", c.ServerA.label(), c.SuccessRateA, c.SuccessRateB)
+	case c.SuccessRateB > c.SuccessRateA:
+		reliability = fmt.Sprintf("%s is more reliable (%.2f%% vs %.2f%%)", c.ServerB.label(), c.SuccessRateB, c.SuccessRateA)
+	default:
+		reliability = fmt.Sprintf("Both servers are equally reliable (%.2f%%)", c.SuccessRateA)
+	}
+
+	return speed + ". " + reliability
+}
+
+func outputComparison(comparison ServerComparison, opts OutputOptions) error {
+	var output strings.Builder
+
+	switch opts.Format {
+	case "json":
+		jsonData, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			return err
+		}
+		output.Write(jsonData)
+	case "text":
+		writeComparisonOutput(&output, comparison)
+	default:
+		return fmt.Errorf("unsupported format: %s", opts.Format)
+	}
+
+	return writeOutput(output.String(), opts)
+}
+
+func writeComparisonOutput(output *strings.Builder, c ServerComparison) {
+	serverLabel := func(server DNSServer) string {
+		if server.Description != "" {
+			return server.label() + " (" + server.Description + ")"
+		}
+		return server.label()
+	}
+
+	output.WriteString("DNS Server Comparison\n")
+	output.WriteString("=====================\n")
+	output.WriteString(fmt.Sprintf("Timestamp: %s\n\n", c.Timestamp.Format("2006-01-02 15:04:05")))
+	output.WriteString(fmt.Sprintf("  A: %s\n", serverLabel(c.ServerA)))
+	output.WriteString(fmt.Sprintf("  B: %s\n\n", serverLabel(c.ServerB)))
+
+	output.WriteString(fmt.Sprintf("  %-22s %-10s %10s %10s %10s  %s\n", "Domain", "Category", "A", "B", "Delta", "Winner"))
+	for _, dc := range c.Domains {
+		timeA, timeB := "FAIL", "FAIL"
+		if dc.SuccessA {
+			timeA = dc.ResponseTimeA.Truncate(time.Millisecond).String()
+		}
+		if dc.SuccessB {
+			timeB = dc.ResponseTimeB.Truncate(time.Millisecond).String()
+		}
+
+		winner := "-"
+		switch dc.Winner {
+		case c.ServerA.label():
+			winner = "A"
+		case c.ServerB.label():
+			winner = "B"
+		}
+
+		output.WriteString(fmt.Sprintf("  %-22s %-10s %10s %10s %10v  %s\n",
+			dc.Domain, dc.Category, timeA, timeB, dc.Delta.Truncate(time.Millisecond), winner))
+	}
+
+	if c.Disagreements > 0 {
+		output.WriteString("\nResolved IP Disagreements:\n")
+		for _, dc := range c.Domains {
+			if dc.IPMismatch {
+				output.WriteString(fmt.Sprintf("  %-22s A: %-16s B: %s\n", dc.Domain, dc.IPA, dc.IPB))
+			}
+		}
+	}
+
+	output.WriteString("\nVerdict:\n")
+	output.WriteString(fmt.Sprintf("  Success Rate: A %.2f%% / B %.2f%%\n", c.SuccessRateA, c.SuccessRateB))
+	output.WriteString(fmt.Sprintf("  Average Response Time: A %v / B %v\n", c.AverageResponseTimeA, c.AverageResponseTimeB))
+	output.WriteString(fmt.Sprintf("  Domain Wins: A %d / B %d\n", c.WinsA, c.WinsB))
+	output.WriteString(fmt.Sprintf("  IP Disagreements: %d\n", c.Disagreements))
+	output.WriteString(fmt.Sprintf("  %s\n", c.Verdict))
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCompareServers(t *testing.T) {
+	servers := []DNSServer{
+		{IP: "1.1.1.1", Description: "Cloudflare"},
+		{IP: "8.8.8.8", Description: "Google"},
+		{IP: "127.0.0.1", Port: "5335", Description: "Local"},
+		{IP: "9.9.9.9", IPv6: "2620:fe::fe", Description: "Quad9"},
+	}
+	tests := []struct {
+		arg     string
+		wantA   DNSServer
+		wantB   DNSServer
+		wantErr bool
+	}{
+		{"1.1.1.1,8.8.8.8", servers[0], servers[1], false},
+		{" 8.8.8.8 , 9.9.9.9 ", servers[1], DNSServer{IP: "9.9.9.9"}, false},
+		{"1.1.1.1", DNSServer{}, DNSServer{}, true},
+		{"1.1.1.1,8.8.8.8,9.9.9.9", DNSServer{}, DNSServer{}, true},
+		{"1.1.1.1,not_an_ip", DNSServer{}, DNSServer{}, true},
+		{"1.1.1.1,1.1.1.1", DNSServer{}, DNSServer{}, true},
+		// The same IP at another port is another server
+		{"127.0.0.1:5335,127.0.0.1", servers[2], DNSServer{IP: "127.0.0.1"}, false},
+		{"127.0.0.1 port=5335,1.1.1.1", servers[2], servers[0], false},
+		{"127.0.0.1:5335,127.0.0.1 port=5335", DNSServer{}, DNSServer{}, true},
+		{"9.9.9.9 2620:fe::fe,9.9.9.9", servers[3], DNSServer{IP: "9.9.9.9"}, false},
+		{"https://1.1.1.1/dns-query,1.1.1.1", DNSServer{IP: "1.1.1.1", DoHURL: "https://1.1.1.1/dns-query", Protocol: ProtocolHTTPS}, servers[0], false},
+		{"1.1.1.1 ports=53-54,8.8.8.8", DNSServer{}, DNSServer{}, true},
+	}
+	for _, tt := range tests {
+		a, b, err := parseCompareServers(tt.arg, servers)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCompareServers(%q) error = %v, want error %v", tt.arg, err, tt.wantErr)
+			continue
+		}
+		if a != tt.wantA || b != tt.wantB {
+			t.Errorf("parseCompareServers(%q) = %+v, %+v, want %+v, %+v", tt.arg, a, b, tt.wantA, tt.wantB)
+		}
+	}
+}
+
+func TestCompareServersSameIPDifferentPort(t *testing.T) {
+	a := DNSServer{IP: "127.0.0.1", Port: "5335"}
+	b := DNSServer{IP: "127.0.0.1"}
+	results := TestResults{Results: []TestResult{
+		{Server: a, Domain: "one.com", Success: true, ResponseTime: 10 * time.Millisecond, IP: "192.0.2.1"},
+		{Server: b, Domain: "one.com", Error: "timeout"},
+	}}
+	domains := []DomainCategory{{Domain: "one.com", Category: "test"}}
+
+	c := compareServers(results, a, b, domains, 0)
+	if len(c.Domains) != 1 || !c.Domains[0].SuccessA || c.Domains[0].SuccessB {
+		t.Fatalf("compareServers() domains = %+v, want A's success and B's failure kept apart", c.Domains)
+	}
+	if c.Domains[0].Winner != "127.0.0.1:5335" || c.WinsA != 1 {
+		t.Errorf("compareServers() winner = %q, wins A = %d, want 127.0.0.1:5335 and 1", c.Domains[0].Winner, c.WinsA)
+	}
+}
+
+func TestCompareServers(t *testing.T) {
+	a := DNSServer{IP: "1.1.1.1"}
+	b := DNSServer{IP: "8.8.8.8"}
+	ms := time.Millisecond
+	results := TestResults{Results: []TestResult{
+		// A is faster and both agree
+		{Server: a, Domain: "one.com", Success: true, ResponseTime: 10 * ms, IP: "192.0.2.1"},
+		{Server: b, Domain: "one.com", Success: true, ResponseTime: 30 * ms, IP: "192.0.2.1"},
+		// B is faster but resolves elsewhere
+		{Server: a, Domain: "two.com", Success: true, ResponseTime: 50 * ms, IP: "192.0.2.2"},
+		{Server: b, Domain: "two.com", Success: true, ResponseTime: 20 * ms, IP: "198.51.100.2"},
+		// Only A answers
+		{Server: a, Domain: "three.com", Success: true, ResponseTime: 90 * ms, IP: "192.0.2.3"},
+		{Server: b, Domain: "three.com", Error: "timeout"},
+		// Another server's result is ignored
+		{Server: DNSServer{IP: "9.9.9.9"}, Domain: "one.com", Success: true, ResponseTime: ms},
+	}}
+	domains := []DomainCategory{{Domain: "one.com"}, {Domain: "two.com"}, {Domain: "three.com"}, {Domain: "untested.com"}}
+
+	c := compareServers(results, a, b, domains, 0)
+
+	if len(c.Domains) != 3 {
+		t.Fatalf("compared %d domains, want 3", len(c.Domains))
+	}
+	for i, want := range []string{a.IP, b.IP, a.IP} {
+		if c.Domains[i].Winner != want {
+			t.Errorf("%s: winner = %q, want %q", c.Domains[i].Domain, c.Domains[i].Winner, want)
+		}
+	}
+	if c.WinsA != 2 || c.WinsB != 1 || c.Disagreements != 1 || !c.Domains[1].IPMismatch {
+		t.Errorf("wins %d-%d, %d disagreements, want 2-1 and 1", c.WinsA, c.WinsB, c.Disagreements)
+	}
+	if c.Domains[1].Delta != 30*ms {
+		t.Errorf("two.com delta = %v, want 30ms", c.Domains[1].Delta)
+	}
+	if c.SuccessRateA != 100 || int(c.SuccessRateB) != 66 {
+		t.Errorf("success rates = %.2f, %.2f, want 100 and 66.67", c.SuccessRateA, c.SuccessRateB)
+	}
+	if c.AverageResponseTimeA != 50*ms || c.AverageResponseTimeB != 25*ms {
+		t.Errorf("averages = %v, %v, want 50ms and 25ms", c.AverageResponseTimeA, c.AverageResponseTimeB)
+	}
+	if !strings.HasPrefix(c.Verdict, "8.8.8.8 is faster by 25ms") || !strings.Contains(c.Verdict, "1.1.1.1 is more reliable") {
+		t.Errorf("verdict = %q", c.Verdict)
+	}
+}
+
+func TestParseCompareGranularity(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    int
+		wantErr bool
+	}{
+		{"exact", 0, false},
+		{"/24", 24, false},
+		{"16", 16, false},
+		{"/32", 32, false},
+		{"/0", 0, true},
+		{"/33", 0, true},
+		{"/abc", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCompareGranularity(tt.arg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseCompareGranularity(%q) = %d, %v, want %d, error %v", tt.arg, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSameNetwork(t *testing.T) {
+	tests := []struct {
+		a, b string
+		bits int
+		want bool
+	}{
+		{"192.0.2.1", "192.0.2.1", 0, true},
+		{"192.0.2.1", "192.0.2.200", 0, false},
+		{"192.0.2.1", "192.0.2.200", 24, true},
+		{"192.0.2.1", "192.0.3.1", 24, false},
+		{"192.0.2.1", "192.0.3.1", 16, true},
+		{"2001:db8:1:2::1", "2001:db8:1:3::1", 24, true}, // IPv6 /48
+		{"2001:db8:1::1", "2001:db8:2::1", 24, false},
+		{"192.0.2.1", "2001:db8::1", 24, false},
+		{"not-an-ip", "192.0.2.1", 24, false},
+	}
+	for _, tt := range tests {
+		if got := sameNetwork(tt.a, tt.b, tt.bits); got != tt.want {
+			t.Errorf("sameNetwork(%s, %s, %d) = %v, want %v", tt.a, tt.b, tt.bits, got, tt.want)
+		}
+	}
+}
+
+func TestCompareServersGranularity(t *testing.T) {
+	a := DNSServer{IP: "1.1.1.1"}
+	b := DNSServer{IP: "8.8.8.8"}
+	results := TestResults{Results: []TestResult{
+		{Server: a, Domain: "cdn.com", Success: true, IP: "192.0.2.10"},
+		{Server: b, Domain: "cdn.com", Success: true, IP: "192.0.2.20"},
+	}}
+	domains := []DomainCategory{{Domain: "cdn.com"}}
+
+	if c := compareServers(results, a, b, domains, 0); c.Disagreements != 1 {
+		t.Errorf("exact comparison found %d disagreements, want 1", c.Disagreements)
+	}
+	if c := compareServers(results, a, b, domains, 24); c.Disagreements != 0 {
+		t.Errorf("/24 comparison found %d disagreements, want 0", c.Disagreements)
+	}
+}
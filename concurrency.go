@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// ReclaimInterval is how often a query waiting for a --max-concurrency slot
+// tries again to free an idle connection
+const ReclaimInterval = 10 * time.Millisecond
+
+// connLimit bounds the sockets held open at once under --max-concurrency. A
+// nil limit is unlimited.
+type connLimit struct {
+	tokens chan struct{}
+}
+
+func newConnLimit(n int) *connLimit {
+	if n <= 0 {
+		return nil
+	}
+	return &connLimit{tokens: make(chan struct{}, n)}
+}
+
+// acquire takes a slot, waiting until one is free or ctx ends. While none is
+// free, reclaim is called periodically to close idle connections.
+func (l *connLimit) acquire(ctx context.Context, reclaim func()) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.tokens <- struct{}{}:
+		return nil
+	default:
+	}
+
+	ticker := time.NewTicker(ReclaimInterval)
+	defer ticker.Stop()
+	for {
+		if reclaim != nil {
+			reclaim()
+		}
+		select {
+		case l.tokens <- struct{}{}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// reclaimIdle closes the idle pooled connections of opts, making room for a
+// query waiting for a --max-concurrency slot
+func (opts TestOptions) reclaimIdle() {
+	if opts.pipelines != nil {
+		opts.pipelines.closeIdle()
+	}
+	if opts.doh != nil {
+		opts.doh.close()
+	}
+}
+
+// release frees a slot taken with acquire
+func (l *connLimit) release() {
+	if l != nil {
+		<-l.tokens
+	}
+}
+
+// wrap ties conn to a slot taken with acquire, releasing it when conn is closed
+func (l *connLimit) wrap(conn net.Conn) net.Conn {
+	if l == nil {
+		return conn
+	}
+	return &limitedConn{Conn: conn, limit: l}
+}
+
+// limitedConn is a connection holding a connLimit slot until it is closed
+type limitedConn struct {
+	net.Conn
+	limit *connLimit
+	once  sync.Once
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.limit.release)
+	return err
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestConnLimit(t *testing.T) {
+	limit := newConnLimit(1)
+	if err := limit.acquire(context.Background(), nil); err != nil {
+		t.Fatalf("first acquire error = %v", err)
+	}
+
+	// A full limit times out unless reclaim frees a slot
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := limit.acquire(ctx, nil); err == nil {
+		t.Fatal("acquire on a full limit succeeded, want the context error")
+	}
+
+	client, server := net.Pipe()
+	defer server.Close()
+	conn := limit.wrap(client)
+	reclaimed := 0
+	reclaim := func() {
+		reclaimed++
+		conn.Close()
+		conn.Close() // A second close must not release another slot
+	}
+	if err := limit.acquire(context.Background(), reclaim); err != nil || reclaimed == 0 {
+		t.Fatalf("acquire with reclaim = %v after %d reclaims, want the closed connection's slot", err, reclaimed)
+	}
+	if len(limit.tokens) != 1 {
+		t.Errorf("%d slots taken, want 1", len(limit.tokens))
+	}
+
+	var unlimited *connLimit
+	if err := unlimited.acquire(context.Background(), nil); err != nil || newConnLimit(0) != nil {
+		t.Errorf("a zero limit is not unlimited")
+	}
+	unlimited.release()
+}
+
+func TestPipelineMaxConcurrency(t *testing.T) {
+	// Three servers share one socket: each query closes the previous
+	// server's idle connection to dial its own
+	var targets []DNSServer
+	for i := 0; i < 3; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skipf("cannot listen on TCP: %v", err)
+		}
+		started := make(chan struct{})
+		server := &dns.Server{Listener: listener, NotifyStartedFunc: func() { close(started) },
+			Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })}
+		go server.ActivateAndServe()
+		t.Cleanup(func() { server.Shutdown() })
+		<-started
+
+		_, port, _ := net.SplitHostPort(listener.Addr().String())
+		targets = append(targets, DNSServer{IP: "127.0.0.1", Port: port})
+	}
+	pool := newPipelinePool(TestOptions{Protocol: ProtocolTCP, Timeout: 2 * time.Second, MaxConcurrency: 1, sockets: newConnLimit(1)})
+	defer pool.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, target := range targets {
+		msg := new(dns.Msg)
+		msg.SetQuestion("example.com.", dns.TypeA)
+		if _, err := pool.exchange(ctx, msg, target); err != nil {
+			t.Fatalf("exchange with %s error = %v", target.label(), err)
+		}
+		if open := len(pool.limit.tokens); open != 1 {
+			t.Errorf("%d connections open after querying %s, want 1", open, target.label())
+		}
+	}
+}
+
+func TestUnpooledQueryTakesSlot(t *testing.T) {
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	// With the only slot taken, a UDP query waits until its timeout
+	opts := TestOptions{Timeout: 200 * time.Millisecond, QueryType: dns.TypeA, Protocol: ProtocolUDP, sockets: newConnLimit(1)}
+	opts.sockets.acquire(context.Background(), nil)
+	if result := testDNS(newDNSClient(opts), nil, server, "example.com", opts); result.Success {
+		t.Error("UDP query with no free slot succeeded, want it to wait for the shared limit")
+	}
+
+	opts.sockets.release()
+	if result := testDNS(newDNSClient(opts), nil, server, "example.com", opts); !result.Success {
+		t.Errorf("UDP query with a free slot failed: %s", result.Error)
+	}
+	if len(opts.sockets.tokens) != 0 {
+		t.Errorf("%d slots still taken after the query, want 0", len(opts.sockets.tokens))
+	}
+}
+
+func TestProbeAndQuorumQueriesTakeSlot(t *testing.T) {
+	var queries atomic.Int32
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		queries.Add(1)
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	// With the only slot taken, neither sends a query before its timeout
+	opts := TestOptions{Timeout: 200 * time.Millisecond, Workers: 1, sockets: newConnLimit(1)}
+	opts.sockets.acquire(context.Background(), nil)
+	var profile ServerProfile
+	probeRecursion(server, opts, &profile)
+	consensus := resolveQuorum([]DNSServer{server, server, server}, []DomainCategory{{Domain: "example.com"}}, opts)
+	if profile.Recursive != nil || len(consensus) != 0 || queries.Load() != 0 {
+		t.Errorf("with no free slot: probe %+v, consensus %d domains, %d queries sent, want none", profile, len(consensus), queries.Load())
+	}
+
+	opts.sockets.release()
+	profile = ServerProfile{}
+	probeRecursion(server, opts, &profile)
+	consensus = resolveQuorum([]DNSServer{server, server, server}, []DomainCategory{{Domain: "example.com"}}, opts)
+	if profile.Recursive == nil || len(consensus) != 1 {
+		t.Errorf("with a free slot: probe %+v, consensus %d domains, want a verdict and 1 domain", profile, len(consensus))
+	}
+	if len(opts.sockets.tokens) != 0 {
+		t.Errorf("%d slots still taken after the queries, want 0", len(opts.sockets.tokens))
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// Confidence levels of a server's average latency
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// Coefficient of variation (stddev / mean) thresholds of the confidence
+// levels, and the measurements needed for more than low confidence
+const (
+	ConfidenceHighCV          = 0.5
+	ConfidenceMediumCV        = 1.0
+	ConfidenceMinMeasurements = 3
+)
+
+// ServerConfidence represents how consistent a server's response times are,
+// and so how far its average latency can be trusted for ranking
+type ServerConfidence struct {
+	Server       DNSServer     `json:"server"`
+	Measurements int           `json:"measurements"`
+	Mean         time.Duration `json:"mean_ms"`
+	StdDev       time.Duration `json:"stddev_ms"`
+	CV           float64       `json:"cv"` // Coefficient of variation, stddev / mean
+	Confidence   string        `json:"confidence"`
+}
+
+// latencyConfidence rates every server by the coefficient of variation of
+// its successful response times. Servers with too few measurements get low
+// confidence; servers without any are left out.
+func latencyConfidence(results []TestResult) []ServerConfidence {
+	var servers []DNSServer
+	times := make(map[DNSServer][]time.Duration)
+	for _, result := range results {
+		if !result.Success {
+			continue
+		}
+		if _, seen := times[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		times[result.Server] = append(times[result.Server], result.ResponseTime)
+	}
+
+	var confidence []ServerConfidence
+	for _, server := range servers {
+		var sum float64
+		for _, t := range times[server] {
+			sum += float64(t)
+		}
+		mean := sum / float64(len(times[server]))
+
+		var squares float64
+		for _, t := range times[server] {
+			squares += (float64(t) - mean) * (float64(t) - mean)
+		}
+		stddev := math.Sqrt(squares / float64(len(times[server])))
+
+		entry := ServerConfidence{
+			Server:       server,
+			Measurements: len(times[server]),
+			Mean:         time.Duration(mean),
+			StdDev:       time.Duration(stddev),
+		}
+		if mean > 0 {
+			entry.CV = stddev / mean
+		}
+
+		switch {
+		case entry.Measurements < ConfidenceMinMeasurements || entry.CV >= ConfidenceMediumCV:
+			entry.Confidence = ConfidenceLow
+		case entry.CV >= ConfidenceHighCV:
+			entry.Confidence = ConfidenceMedium
+		default:
+			entry.Confidence = ConfidenceHigh
+		}
+		confidence = append(confidence, entry)
+	}
+
+	return confidence
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyConfidence(t *testing.T) {
+	ms := time.Millisecond
+	latencies := map[string][]time.Duration{
+		"192.0.2.1": {10 * ms, 10 * ms, 10 * ms},
+		"192.0.2.2": {10 * ms, 10 * ms, 40 * ms},
+		"192.0.2.3": {ms, ms, 100 * ms},
+		"192.0.2.4": {10 * ms, 10 * ms},
+	}
+	var results []TestResult
+	for _, ip := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"} {
+		for _, latency := range latencies[ip] {
+			results = append(results, TestResult{Server: DNSServer{IP: ip}, Success: true, ResponseTime: latency})
+		}
+		// Failures don't count as measurements
+		results = append(results, TestResult{Server: DNSServer{IP: ip}, ResponseTime: time.Second})
+	}
+	results = append(results, TestResult{Server: DNSServer{IP: "192.0.2.5"}, Error: "timeout"})
+
+	confidence := latencyConfidence(results)
+	want := []struct {
+		ip           string
+		measurements int
+		confidence   string
+	}{
+		{"192.0.2.1", 3, ConfidenceHigh},
+		{"192.0.2.2", 3, ConfidenceMedium},
+		{"192.0.2.3", 3, ConfidenceLow},
+		{"192.0.2.4", 2, ConfidenceLow},
+	}
+	if len(confidence) != len(want) {
+		t.Fatalf("latencyConfidence = %+v, want %d servers", confidence, len(want))
+	}
+	for i, w := range want {
+		got := confidence[i]
+		if got.Server.IP != w.ip || got.Measurements != w.measurements || got.Confidence != w.confidence {
+			t.Errorf("server %d = %+v, want %s with %d measurements and %s confidence", i, got, w.ip, w.measurements, w.confidence)
+		}
+	}
+	if confidence[0].StdDev != 0 || confidence[0].Mean != 10*ms {
+		t.Errorf("steady server mean %v, stddev %v, want 10ms and 0", confidence[0].Mean, confidence[0].StdDev)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"sync/atomic"
+
+	"github.com/miekg/dns"
+)
+
+// queryCounter accumulates the footprint of a run across all workers
+type queryCounter struct {
+	queries atomic.Int64
+	bytes   atomic.Int64
+}
+
+// record counts a sent query and the wire size of its response, if any. A nil
+// counter records nothing.
+func (c *queryCounter) record(response *dns.Msg) {
+	if c == nil {
+		return
+	}
+
+	c.queries.Add(1)
+	if response != nil {
+		c.bytes.Add(int64(response.Len()))
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestQueryCounter(t *testing.T) {
+	var nilCounter *queryCounter
+	nilCounter.record(new(dns.Msg)) // Must not panic
+
+	response := new(dns.Msg)
+	response.SetQuestion("example.com.", dns.TypeA)
+
+	counter := &queryCounter{}
+	counter.record(response)
+	counter.record(nil) // A query without a response still counts
+	if counter.queries.Load() != 2 || counter.bytes.Load() != int64(response.Len()) {
+		t.Errorf("counted %d queries and %d bytes, want 2 and %d", counter.queries.Load(), counter.bytes.Load(), response.Len())
+	}
+}
+
+func TestRunDNSTestsCountsQueries(t *testing.T) {
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	servers := []DNSServer{{IP: ip}}
+	domains := []DomainCategory{{Domain: "a.example"}, {Domain: "b.example"}}
+
+	results := runDNSTests(servers, domains, TestOptions{Timeout: 2 * time.Second, Workers: 2, QueryType: dns.TypeA, Samples: 3})
+	if results.Summary.TotalQueries != 6 {
+		t.Errorf("TotalQueries = %d, want 3 samples for each of 2 pairs", results.Summary.TotalQueries)
+	}
+	if results.Summary.TotalBytesReceived == 0 {
+		t.Error("TotalBytesReceived = 0, want the size of the answers")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Groupings selectable with --group-by
+const (
+	GroupByNone    = ""
+	GroupByCountry = "country"
+)
+
+// UnknownCountry groups the servers whose country can't be told
+const UnknownCountry = "Unknown"
+
+// CountryGroup represents the aggregate outcome of the servers of one country
+type CountryGroup struct {
+	Country             string        `json:"country"`
+	Servers             []string      `json:"servers"`
+	Stats               CategoryStats `json:"stats"`
+	AverageResponseTime time.Duration `json:"average_response_time_ms"`
+}
+
+// serverCountry returns the country of the server of a result: the one
+// --geoip found for its address, else the "XX - " prefix of its description
+// used by the built-in list, else UnknownCountry
+func serverCountry(result TestResult) string {
+	if result.ServerCountry != "" {
+		return result.ServerCountry
+	}
+	description := result.Server.Description
+	if len(description) >= 5 && description[2:5] == " - " && isUpperLetter(description[0]) && isUpperLetter(description[1]) {
+		return description[:2]
+	}
+	return UnknownCountry
+}
+
+func isUpperLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// countryGroups aggregates the results by server country, ordered by
+// country code with UnknownCountry last
+func countryGroups(results []TestResult) []CountryGroup {
+	byCountry := make(map[string][]TestResult)
+	servers := make(map[string][]string)
+	seen := make(map[DNSServer]bool)
+	for _, result := range results {
+		country := serverCountry(result)
+		byCountry[country] = append(byCountry[country], result)
+		if !seen[result.Server] {
+			seen[result.Server] = true
+			servers[country] = append(servers[country], result.Server.label())
+		}
+	}
+
+	var groups []CountryGroup
+	for country, countryResults := range byCountry {
+		groups = append(groups, CountryGroup{
+			Country:             country,
+			Servers:             servers[country],
+			Stats:               groupStats(countryResults),
+			AverageResponseTime: averageResponseTime(countryResults),
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].Country == UnknownCountry) != (groups[j].Country == UnknownCountry) {
+			return groups[j].Country == UnknownCountry
+		}
+		return groups[i].Country < groups[j].Country
+	})
+	return groups
+}
+
+// countryRank returns the position of a country in the groups, so servers
+// can be listed in the same order
+func countryRank(groups []CountryGroup) map[string]int {
+	rank := make(map[string]int, len(groups))
+	for i, group := range groups {
+		rank[group.Country] = i
+	}
+	return rank
+}
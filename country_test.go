@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerCountry(t *testing.T) {
+	tests := []struct {
+		result TestResult
+		want   string
+	}{
+		{TestResult{Server: DNSServer{Description: "DE - Example"}}, "DE"},
+		{TestResult{Server: DNSServer{Description: "DE - Example"}, ServerCountry: "NL"}, "NL"},
+		{TestResult{Server: DNSServer{Description: "de - Example"}}, UnknownCountry},
+		{TestResult{Server: DNSServer{Description: "Cloudflare"}}, UnknownCountry},
+		{TestResult{}, UnknownCountry},
+	}
+	for _, tt := range tests {
+		if got := serverCountry(tt.result); got != tt.want {
+			t.Errorf("serverCountry(%q, %q) = %q, want %q", tt.result.Server.Description, tt.result.ServerCountry, got, tt.want)
+		}
+	}
+}
+
+func TestCountryGroups(t *testing.T) {
+	de1 := DNSServer{IP: "192.0.2.1", Description: "DE - One"}
+	de2 := DNSServer{IP: "192.0.2.2", Description: "DE - Two"}
+	unknown := DNSServer{IP: "192.0.2.3", Description: "Other"}
+	tr := DNSServer{IP: "192.0.2.4", Description: "TR - Four"}
+	results := []TestResult{
+		{Server: unknown, Success: true, ResponseTime: time.Millisecond},
+		{Server: de1, Success: true, ResponseTime: 10 * time.Millisecond},
+		{Server: de2, Error: "timeout"},
+		{Server: de2, Success: true, ResponseTime: 20 * time.Millisecond},
+		{Server: tr, Error: "timeout"},
+	}
+
+	groups := countryGroups(results)
+	var countries []string
+	for _, group := range groups {
+		countries = append(countries, group.Country)
+	}
+	if want := []string{"DE", "TR", UnknownCountry}; !reflect.DeepEqual(countries, want) {
+		t.Fatalf("countries = %v, want %v", countries, want)
+	}
+	de := groups[0]
+	if !reflect.DeepEqual(de.Servers, []string{"192.0.2.1", "192.0.2.2"}) || de.Stats.TotalTests != 3 ||
+		de.Stats.SuccessfulTests != 2 || de.AverageResponseTime != 15*time.Millisecond {
+		t.Errorf("DE group = %+v, want 2 servers, 2 of 3 tests and a 15ms average", de)
+	}
+	if rank := countryRank(groups); rank["DE"] != 0 || rank[UnknownCountry] != 2 {
+		t.Errorf("countryRank = %v", rank)
+	}
+}
+
+func TestTextOutputGroupByCountry(t *testing.T) {
+	results := TestResults{Results: []TestResult{
+		{Server: DNSServer{IP: "192.0.2.1", Description: "TR - First"}, Domain: "example.com", Category: CategoryGeneral, Success: true},
+		{Server: DNSServer{IP: "192.0.2.2", Description: "DE - Second"}, Domain: "example.com", Category: CategoryGeneral, Success: true},
+	}}
+	results.Summary = calculateSummary(results.Results)
+	results.Summary.Countries = countryGroups(results.Results)
+
+	var output strings.Builder
+	writeTextOutput(&output, results, OutputOptions{GroupBy: GroupByCountry})
+	text := output.String()
+	de, tr := strings.Index(text, "Country: DE"), strings.Index(text, "Country: TR")
+	if de < 0 || tr < 0 || de > tr {
+		t.Fatalf("text output lists the countries at %d and %d, want DE before TR", de, tr)
+	}
+	if second := strings.Index(text, "DNS Server: 192.0.2.2"); second < de || second > tr {
+		t.Errorf("192.0.2.2 isn't listed under DE")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+// CriticalExitCode is the exit status of --fail-on-critical
+const CriticalExitCode = 3
+
+// CriticalReport represents the outcome of the domains marked critical in
+// the domain list
+type CriticalReport struct {
+	TotalTests      int               `json:"total_tests"`
+	SuccessfulTests int               `json:"successful_tests"`
+	SuccessRate     float64           `json:"success_rate"`
+	Failing         []CriticalFailure `json:"failing,omitempty"` // Critical domains failing on a majority of servers
+}
+
+// CriticalFailure represents a critical domain that failed on most servers
+type CriticalFailure struct {
+	Domain        string `json:"domain"`
+	FailedServers int    `json:"failed_servers"`
+	Servers       int    `json:"servers"`
+}
+
+// criticalReport computes the success rate of the critical domains and
+// lists those that failed on a majority of the servers. A domain counts as
+// resolved by a server when any of its results there succeeded. Results
+// skipped by an interruption are left out, like in the overall success rate.
+// It returns nil when no critical domain was tested.
+func criticalReport(results []TestResult) *CriticalReport {
+	var report *CriticalReport
+	var domains []string
+	resolved := make(map[string]map[DNSServer]bool)
+	for _, result := range results {
+		if !result.Critical || result.Skipped {
+			continue
+		}
+		if report == nil {
+			report = &CriticalReport{}
+		}
+
+		report.TotalTests++
+		if result.Success {
+			report.SuccessfulTests++
+		}
+		if _, seen := resolved[result.Domain]; !seen {
+			domains = append(domains, result.Domain)
+			resolved[result.Domain] = make(map[DNSServer]bool)
+		}
+		resolved[result.Domain][result.Server] = resolved[result.Domain][result.Server] || result.Success
+	}
+	if report == nil {
+		return nil
+	}
+	report.SuccessRate = float64(report.SuccessfulTests) / float64(report.TotalTests) * 100
+
+	for _, domain := range domains {
+		failed := 0
+		for _, ok := range resolved[domain] {
+			if !ok {
+				failed++
+			}
+		}
+		if failed*2 > len(resolved[domain]) {
+			report.Failing = append(report.Failing, CriticalFailure{
+				Domain:        domain,
+				FailedServers: failed,
+				Servers:       len(resolved[domain]),
+			})
+		}
+	}
+	return report
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestCriticalReport(t *testing.T) {
+	a, b, c := DNSServer{IP: "1.1.1.1"}, DNSServer{IP: "8.8.8.8"}, DNSServer{IP: "9.9.9.9"}
+	results := []TestResult{
+		// Fails on two of three servers
+		{Server: a, Domain: "bank.com", Critical: true, Success: true},
+		{Server: b, Domain: "bank.com", Critical: true},
+		{Server: c, Domain: "bank.com", Critical: true},
+		// Fails on one of three, and resolves on a second sample of that server
+		{Server: a, Domain: "mail.com", Critical: true, Success: true},
+		{Server: b, Domain: "mail.com", Critical: true, Success: true},
+		{Server: c, Domain: "mail.com", Critical: true},
+		{Server: c, Domain: "mail.com", Critical: true, Success: true},
+		// Not critical
+		{Server: a, Domain: "other.com"},
+	}
+
+	report := criticalReport(results)
+	if report == nil {
+		t.Fatal("criticalReport = nil, want a report")
+	}
+	if report.TotalTests != 7 || report.SuccessfulTests != 4 {
+		t.Errorf("criticalReport counted %d of %d tests, want 4 of 7", report.SuccessfulTests, report.TotalTests)
+	}
+	if len(report.Failing) != 1 || report.Failing[0] != (CriticalFailure{Domain: "bank.com", FailedServers: 2, Servers: 3}) {
+		t.Errorf("failing = %+v, want bank.com on 2 of 3 servers", report.Failing)
+	}
+
+	if report := criticalReport(results[7:]); report != nil {
+		t.Errorf("criticalReport without critical domains = %+v, want nil", report)
+	}
+}
+
+func TestCriticalReportSkipsSkipped(t *testing.T) {
+	a, b, c := DNSServer{IP: "1.1.1.1"}, DNSServer{IP: "8.8.8.8"}, DNSServer{IP: "9.9.9.9"}
+	results := []TestResult{
+		{Server: a, Domain: "bank.com", Critical: true, Success: true},
+		{Server: b, Domain: "bank.com", Critical: true, Skipped: true},
+		{Server: c, Domain: "bank.com", Critical: true, Skipped: true},
+		{Server: a, Domain: "other.com", Success: false},
+	}
+	report := criticalReport(results)
+	if report == nil {
+		t.Fatal("criticalReport = nil, want a report")
+	}
+	if report.TotalTests != 1 || report.SuccessfulTests != 1 || report.SuccessRate != 100 || len(report.Failing) != 0 {
+		t.Errorf("criticalReport = %+v, want 1 of 1 tests succeeding and nothing failing", report)
+	}
+
+	if report := criticalReport(results[1:3]); report != nil {
+		t.Errorf("criticalReport of skipped results only = %+v, want nil", report)
+	}
+}
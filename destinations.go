@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// outputFormats lists the formats accepted by --format and --output
+var outputFormats = []string{"json", "text", "loki", "ndjson", "server-csv", "scoreboard", "iplist"}
+
+// outputList collects the values of the repeatable --output flag
+type outputList []string
+
+func (o *outputList) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *outputList) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+// parseOutputDestination splits a --output value of the form PATH[:FORMAT].
+// A suffix that isn't a known format stays part of the path, so paths like
+// C:\results.json work; "-" stands for stdout.
+func parseOutputDestination(spec, defaultFormat string) (string, string) {
+	file, format := spec, defaultFormat
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		for _, known := range outputFormats {
+			if spec[i+1:] == known {
+				file, format = spec[:i], known
+				break
+			}
+		}
+	}
+
+	if file == "-" {
+		file = ""
+	}
+	return file, format
+}
+
+// outputDestinations returns one OutputOptions per --output value, based on
+// base. Without any --output the results go to stdout in base.Format. Only
+// JSON file destinations append when --append is set.
+func outputDestinations(specs []string, base OutputOptions, noColor bool) ([]OutputOptions, error) {
+	if len(specs) == 0 {
+		specs = []string{"-"}
+	}
+
+	var destinations []OutputOptions
+	for _, spec := range specs {
+		opts := base
+		opts.File, opts.Format = parseOutputDestination(spec, base.Format)
+		opts.Color = useColor(opts.File, noColor)
+		opts.Append = base.Append && opts.File != "" && opts.Format == "json"
+
+		known := false
+		for _, format := range outputFormats {
+			known = known || opts.Format == format
+		}
+		if !known {
+			return nil, fmt.Errorf("unsupported format: %s", opts.Format)
+		}
+
+		destinations = append(destinations, opts)
+	}
+	return destinations, nil
+}
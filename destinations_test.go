@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestParseOutputDestination(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantFile   string
+		wantFormat string
+	}{
+		{"results.json", "results.json", "text"},
+		{"results.txt:json", "results.txt", "json"},
+		{"report:loki", "report", "loki"},
+		{"-", "", "text"},
+		{"-:json", "", "json"},
+		{`C:\results.json`, `C:\results.json`, "text"},
+		{`C:\results:json`, `C:\results`, "json"},
+		{"out:csv", "out:csv", "text"},
+	}
+	for _, tt := range tests {
+		file, format := parseOutputDestination(tt.spec, "text")
+		if file != tt.wantFile || format != tt.wantFormat {
+			t.Errorf("parseOutputDestination(%q) = %q, %q, want %q, %q", tt.spec, file, format, tt.wantFile, tt.wantFormat)
+		}
+	}
+}
+
+func TestOutputDestinations(t *testing.T) {
+	base := OutputOptions{Format: "text", Append: true}
+
+	destinations, err := outputDestinations(nil, base, true)
+	if err != nil || len(destinations) != 1 || destinations[0].File != "" || destinations[0].Format != "text" || destinations[0].Append {
+		t.Errorf("outputDestinations(nil) = %+v, %v, want stdout in the base format without appending", destinations, err)
+	}
+
+	destinations, err = outputDestinations([]string{"runs.json:json", "report.txt", "-:loki"}, base, true)
+	if err != nil || len(destinations) != 3 {
+		t.Fatalf("outputDestinations = %+v, %v, want 3 destinations", destinations, err)
+	}
+	want := []struct {
+		file   string
+		format string
+		append bool
+	}{
+		{"runs.json", "json", true},
+		{"report.txt", "text", false},
+		{"", "loki", false},
+	}
+	for i, w := range want {
+		d := destinations[i]
+		if d.File != w.file || d.Format != w.format || d.Append != w.append || d.Color {
+			t.Errorf("destination %d = %+v, want %s in %s, append %v", i, d, w.file, w.format, w.append)
+		}
+	}
+
+	if _, err := outputDestinations(nil, OutputOptions{Format: "yaml"}, true); err == nil {
+		t.Error("outputDestinations with an unknown format succeeded, want an error")
+	}
+}
+
+func TestOutputList(t *testing.T) {
+	var list outputList
+	list.Set("a.json")
+	list.Set("b.txt:text")
+	if len(list) != 2 || list.String() != "a.json,b.txt:text" {
+		t.Errorf("outputList = %v, want both values in order", list)
+	}
+}
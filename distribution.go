@@ -0,0 +1,83 @@
+package main
+
+import "sort"
+
+// DomainIPDistribution represents how the tested servers answered a domain
+type DomainIPDistribution struct {
+	Domain  string    `json:"domain"`
+	Servers int       `json:"servers"`          // Servers that tested the domain
+	Answers []IPShare `json:"answers"`          // Most common address first
+	Failed  []string  `json:"failed,omitempty"` // Servers that resolved no address
+}
+
+// IPShare represents one address returned for a domain and the servers
+// returning it
+type IPShare struct {
+	IP       string   `json:"ip"`
+	Percent  float64  `json:"percent"` // Of the servers that tested the domain
+	Servers  []string `json:"servers"`
+	Sinkhole bool     `json:"sinkhole,omitempty"`
+}
+
+// ipDistribution groups, for every domain, the servers by the address they
+// resolved it to. A server counts once per address even when several of its
+// results (e.g. both families of a dual-stack server) returned it. Domains
+// are sorted by name.
+func ipDistribution(results []TestResult) []DomainIPDistribution {
+	type domainServers struct {
+		servers  []DNSServer
+		resolved map[DNSServer]bool
+		byIP     map[string][]DNSServer
+	}
+
+	var domains []string
+	byDomain := make(map[string]*domainServers)
+	for _, result := range results {
+		entry, ok := byDomain[result.Domain]
+		if !ok {
+			entry = &domainServers{resolved: make(map[DNSServer]bool), byIP: make(map[string][]DNSServer)}
+			byDomain[result.Domain] = entry
+			domains = append(domains, result.Domain)
+		}
+		if !containsServer(entry.servers, result.Server) {
+			entry.servers = append(entry.servers, result.Server)
+		}
+		if result.Success && result.IP != "" && !containsServer(entry.byIP[result.IP], result.Server) {
+			entry.byIP[result.IP] = append(entry.byIP[result.IP], result.Server)
+			entry.resolved[result.Server] = true
+		}
+	}
+	sort.Strings(domains)
+
+	var distribution []DomainIPDistribution
+	for _, domain := range domains {
+		entry := byDomain[domain]
+		dist := DomainIPDistribution{Domain: domain, Servers: len(entry.servers), Answers: []IPShare{}}
+
+		for ip, servers := range entry.byIP {
+			share := IPShare{
+				IP:       ip,
+				Percent:  float64(len(servers)) / float64(len(entry.servers)) * 100,
+				Sinkhole: sinkholeAddresses[ip],
+			}
+			for _, server := range servers {
+				share.Servers = append(share.Servers, server.label())
+			}
+			dist.Answers = append(dist.Answers, share)
+		}
+		sort.Slice(dist.Answers, func(i, j int) bool {
+			if len(dist.Answers[i].Servers) != len(dist.Answers[j].Servers) {
+				return len(dist.Answers[i].Servers) > len(dist.Answers[j].Servers)
+			}
+			return dist.Answers[i].IP < dist.Answers[j].IP
+		})
+
+		for _, server := range entry.servers {
+			if !entry.resolved[server] {
+				dist.Failed = append(dist.Failed, server.label())
+			}
+		}
+		distribution = append(distribution, dist)
+	}
+	return distribution
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIPDistribution(t *testing.T) {
+	a, b, c := DNSServer{IP: "1.1.1.1"}, DNSServer{IP: "8.8.8.8"}, DNSServer{IP: "9.9.9.9"}
+	results := []TestResult{
+		{Server: a, Domain: "example.com", Success: true, IP: "192.0.2.1"},
+		{Server: a, Domain: "example.com", Success: true, IP: "192.0.2.1"}, // Counted once
+		{Server: b, Domain: "example.com", Success: true, IP: "192.0.2.1"},
+		{Server: c, Domain: "example.com", Success: true, IP: "0.0.0.0"},
+		{Server: a, Domain: "down.com", Error: "timeout"},
+	}
+
+	got := ipDistribution(results)
+	want := []DomainIPDistribution{
+		{Domain: "down.com", Servers: 1, Answers: []IPShare{}, Failed: []string{"1.1.1.1"}},
+		{Domain: "example.com", Servers: 3, Answers: []IPShare{
+			{IP: "192.0.2.1", Percent: float64(2) / 3 * 100, Servers: []string{"1.1.1.1", "8.8.8.8"}},
+			{IP: "0.0.0.0", Percent: float64(1) / 3 * 100, Servers: []string{"9.9.9.9"}, Sinkhole: true},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ipDistribution = %+v, want %+v", got, want)
+	}
+}
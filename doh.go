@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// DoHPort is the port DNS-over-HTTPS resolvers listen on (RFC 8484)
+const DoHPort = "443"
+
+// dohMediaType is the content type of DNS messages over HTTPS
+const dohMediaType = "application/dns-message"
+
+// dohMaxResponse bounds the body read from a DoH response
+const dohMaxResponse = 65535
+
+// Server list tokens configuring the DoH endpoint of a server
+const (
+	dohURLToken    = "doh="
+	dohHeaderToken = "header="
+)
+
+// parseDoHToken applies a doh= or header= token of a server list line to
+// server. It reports whether the token was one of them.
+func parseDoHToken(server *DNSServer, token string) (bool, error) {
+	switch {
+	case strings.HasPrefix(token, dohURLToken):
+		endpoint, err := url.Parse(strings.TrimPrefix(token, dohURLToken))
+		if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+			return true, fmt.Errorf("invalid DoH URL '%s', expected https://HOST/PATH", strings.TrimPrefix(token, dohURLToken))
+		}
+		server.DoHURL = endpoint.String()
+		return true, nil
+	case strings.HasPrefix(token, dohHeaderToken):
+		name, value, ok := strings.Cut(strings.TrimPrefix(token, dohHeaderToken), ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return true, fmt.Errorf("invalid header '%s', expected header=NAME:VALUE", strings.TrimPrefix(token, dohHeaderToken))
+		}
+		// Kept as one string so the server stays comparable
+		server.DoHHeaders += strings.TrimSpace(name) + ":" + strings.TrimSpace(value) + "\n"
+		return true, nil
+	}
+	return false, nil
+}
+
+// dohURLPrefix starts a server list entry naming a DoH endpoint by its URL,
+// as in https://cloudflare-dns.com/dns-query
+const dohURLPrefix = "https://"
+
+// parseDoHURL parses a server list entry naming a DoH endpoint by its URL. It
+// returns the URL, with the standard path when it has none, and its host.
+func parseDoHURL(entry string) (endpoint, host string, err error) {
+	u, err := url.Parse(entry)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return "", "", fmt.Errorf("invalid DoH URL '%s', expected https://HOST/PATH", entry)
+	}
+	if u.Path == "" {
+		u.Path = "/dns-query"
+	}
+	return u.String(), strings.TrimSuffix(strings.ToLower(u.Hostname()), "."), nil
+}
+
+// dohEndpoint returns the URL queried on server: its doh= URL, or the
+// standard path on its address
+func dohEndpoint(server DNSServer) string {
+	if server.DoHURL != "" {
+		return server.DoHURL
+	}
+	return "https://" + server.address(DoHPort) + "/dns-query"
+}
+
+// dohPool holds one HTTP client per server, so connections are kept alive
+// between the queries to a server like the TCP and TLS pipelines
+type dohPool struct {
+	opts    TestOptions
+	limit   *connLimit // Open connections allowed under --max-concurrency
+	reclaim func()     // Frees idle connections while waiting for a slot, close unless set
+
+	mu      sync.Mutex
+	clients map[DNSServer]*http.Client
+}
+
+func newDoHPool(opts TestOptions) *dohPool {
+	return &dohPool{opts: opts, limit: opts.sockets, clients: make(map[DNSServer]*http.Client)}
+}
+
+// openDoHPool gives opts a DoH pool shared by its queries when --protocol is
+// https or any of servers is listed by DoH URL. The returned function closes
+// the pool's connections.
+func openDoHPool(opts *TestOptions, servers []DNSServer) func() {
+	if opts.Protocol != ProtocolHTTPS && !hasProtocol(servers, ProtocolHTTPS) {
+		return func() {}
+	}
+	opts.doh = newDoHPool(*opts)
+	return opts.doh.close
+}
+
+// client returns the HTTP client of server. Whatever host the URL names, it
+// dials the server address, which bootstraps the DoH hostname to a known
+// IP; the TLS certificate is still verified against the URL host.
+func (p *dohPool) client(server DNSServer) *http.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[server]; ok {
+		return client
+	}
+
+	port := DoHPort
+	if endpoint, err := url.Parse(dohEndpoint(server)); err == nil && endpoint.Port() != "" {
+		port = endpoint.Port()
+	}
+	addr := server.address(port)
+
+	dialer := &net.Dialer{}
+	if p.opts.SourceIP != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: p.opts.SourceIP}
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				reclaim := p.reclaim
+				if reclaim == nil {
+					reclaim = p.close
+				}
+				if err := p.limit.acquire(ctx, reclaim); err != nil {
+					return nil, err
+				}
+				conn, err := dialer.DialContext(ctx, network, addr)
+				if err != nil {
+					p.limit.release()
+					return nil, err
+				}
+				return p.limit.wrap(conn), nil
+			},
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: p.opts.InsecureTLS},
+			ForceAttemptHTTP2: true,
+		},
+	}
+	p.clients[server] = client
+	return client
+}
+
+// close closes the idle connections of every client, which also makes room
+// for new ones under --max-concurrency
+func (p *dohPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, client := range p.clients {
+		client.CloseIdleConnections()
+	}
+}
+
+// exchange sends msg to server as an RFC 8484 POST request, with the
+// server's headers. A Host header replaces the host the request is sent for.
+func (p *dohPool) exchange(ctx context.Context, msg *dns.Msg, server DNSServer) (*dns.Msg, error) {
+	// DoH uses a zero message ID, which keeps responses cacheable
+	query := msg.Copy()
+	query.Id = 0
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dohEndpoint(server), bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohMediaType)
+	req.Header.Set("Accept", dohMediaType)
+	for _, header := range strings.Split(strings.TrimSuffix(server.DoHHeaders, "\n"), "\n") {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	resp, err := p.client(server).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH request failed: HTTP %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dohMaxResponse))
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(dns.Msg)
+	if err := response.Unpack(body); err != nil {
+		return nil, err
+	}
+	response.Id = msg.Id
+	return response, nil
+}
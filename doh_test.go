@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestParseDoHServers(t *testing.T) {
+	list := "192.0.2.1 doh=https://dns.example/dns-query header=X-Token:secret Example header=Host:doh.example\n" +
+		"192.0.2.2 doh=http://dns.example/dns-query\n" +
+		"192.0.2.3 header=missing-colon\n"
+
+	servers, err := parseDNSServers(strings.NewReader(list), "servers.txt", false)
+	if err != nil {
+		t.Fatalf("parseDNSServers error = %v", err)
+	}
+	want := DNSServer{
+		IP:          "192.0.2.1",
+		Description: "Example",
+		DoHURL:      "https://dns.example/dns-query",
+		DoHHeaders:  "X-Token:secret\nHost:doh.example\n",
+	}
+	if len(servers) != 1 || servers[0] != want {
+		t.Errorf("parseDNSServers = %+v, want only %+v", servers, want)
+	}
+
+	if _, err := parseDNSServers(strings.NewReader(list), "servers.txt", true); err == nil || !strings.Contains(err.Error(), "servers.txt:2:") {
+		t.Errorf("strict parseDNSServers error = %v, want one for line 2", err)
+	}
+}
+
+func TestDoHEndpoint(t *testing.T) {
+	tests := []struct {
+		server DNSServer
+		want   string
+	}{
+		{DNSServer{IP: "192.0.2.1"}, "https://192.0.2.1:443/dns-query"},
+		{DNSServer{IP: "2001:db8::1", Port: "8443"}, "https://[2001:db8::1]:8443/dns-query"},
+		{DNSServer{IP: "192.0.2.1", DoHURL: "https://dns.example/query"}, "https://dns.example/query"},
+	}
+	for _, tt := range tests {
+		if got := dohEndpoint(tt.server); got != tt.want {
+			t.Errorf("dohEndpoint(%+v) = %q, want %q", tt.server, got, tt.want)
+		}
+	}
+}
+
+func TestDoHExchange(t *testing.T) {
+	// The endpoint requires a token header and answers RFC 8484 POST requests
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohMediaType {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("X-Token") != "secret" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		query := new(dns.Msg)
+		if err := query.Unpack(body); err != nil || query.Id != 0 {
+			http.Error(w, "bad query", http.StatusBadRequest)
+			return
+		}
+		packed, _ := answerA(query, "192.0.2.53").Pack()
+		w.Header().Set("Content-Type", dohMediaType)
+		w.Write(packed)
+	}))
+	t.Cleanup(ts.Close)
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+
+	// example.com is in the test certificate; the connection goes to the
+	// listed address instead of what the name resolves to
+	_, port, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	endpoint := "https://example.com:" + port + "/dns-query"
+
+	tests := []struct {
+		name    string
+		headers string
+		wantErr bool
+	}{
+		{"with the token", "X-Token:secret\n", false},
+		{"without the token", "", true},
+	}
+	for _, tt := range tests {
+		server := DNSServer{IP: "127.0.0.1", DoHURL: endpoint, DoHHeaders: tt.headers}
+		pool := newDoHPool(TestOptions{Timeout: 2 * time.Second})
+		pool.client(server).Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+
+		msg := new(dns.Msg)
+		msg.SetQuestion("example.com.", dns.TypeA)
+		msg.Id = 1234
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := pool.exchange(ctx, msg, server)
+		cancel()
+		pool.close()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: exchange error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (response.Id != 1234 || len(response.Answer) != 1) {
+			t.Errorf("%s: response = %v, want one answer with the query's ID", tt.name, response)
+		}
+	}
+}
+
+func TestParseDoHURL(t *testing.T) {
+	tests := []struct {
+		entry        string
+		wantEndpoint string
+		wantHost     string
+		wantErr      bool
+	}{
+		{"https://dns.google", "https://dns.google/dns-query", "dns.google", false},
+		{"https://cloudflare-dns.com/dns-query", "https://cloudflare-dns.com/dns-query", "cloudflare-dns.com", false},
+		{"https://Dns.Example:8443/q", "https://Dns.Example:8443/q", "dns.example", false},
+		{"https://1.1.1.1/dns-query", "https://1.1.1.1/dns-query", "1.1.1.1", false},
+		{"https://[2606:4700::1111]/dns-query", "https://[2606:4700::1111]/dns-query", "2606:4700::1111", false},
+		{"http://dns.google/dns-query", "", "", true},
+		{"https:///dns-query", "", "", true},
+		{"https://dns .google", "", "", true},
+	}
+	for _, tt := range tests {
+		endpoint, host, err := parseDoHURL(tt.entry)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDoHURL(%q) error = %v, want error %v", tt.entry, err, tt.wantErr)
+			continue
+		}
+		if endpoint != tt.wantEndpoint || host != tt.wantHost {
+			t.Errorf("parseDoHURL(%q) = %q, %q, want %q, %q", tt.entry, endpoint, host, tt.wantEndpoint, tt.wantHost)
+		}
+	}
+}
+
+func TestParseDNSServersDoHURL(t *testing.T) {
+	servers, err := parseDNSServers(strings.NewReader("https://192.0.2.53/dns-query Local DoH\n192.0.2.53 Plain\n"), "servers.txt", true)
+	if err != nil {
+		t.Fatalf("parseDNSServers error = %v", err)
+	}
+	want := DNSServer{IP: "192.0.2.53", Description: "Local DoH", DoHURL: "https://192.0.2.53/dns-query", Protocol: ProtocolHTTPS}
+	if len(servers) != 2 || servers[0] != want {
+		t.Fatalf("parseDNSServers = %+v, want %+v first", servers, want)
+	}
+	if servers[0].label() != "doh:192.0.2.53" || servers[1].label() != "192.0.2.53" {
+		t.Errorf("labels = %q, %q, want the DoH entry apart from the plain resolver", servers[0].label(), servers[1].label())
+	}
+	if !hasProtocol(servers, ProtocolHTTPS) || hasProtocol(servers[1:], ProtocolHTTPS) {
+		t.Error("hasProtocol doesn't report the DoH URL entry")
+	}
+}
+
+func TestOpenDoHPool(t *testing.T) {
+	plain := []DNSServer{{IP: "192.0.2.53"}}
+	withURL := append(plain, DNSServer{IP: "192.0.2.54", DoHURL: "https://192.0.2.54/dns-query", Protocol: ProtocolHTTPS})
+
+	tests := []struct {
+		protocol string
+		servers  []DNSServer
+		wantPool bool
+	}{
+		{ProtocolUDP, plain, false},
+		{ProtocolUDP, withURL, true},
+		{ProtocolHTTPS, plain, true},
+	}
+	for _, tt := range tests {
+		opts := TestOptions{Protocol: tt.protocol, Timeout: time.Second}
+		closePool := openDoHPool(&opts, tt.servers)
+		if (opts.doh != nil) != tt.wantPool {
+			t.Errorf("openDoHPool(%s, %d servers) set a pool: %v, want %v", tt.protocol, len(tt.servers), opts.doh != nil, tt.wantPool)
+		}
+		closePool()
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadDomainsStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		want     []DomainCategory
+		wantLine string // Line the strict error is reported on, none when empty
+	}{
+		{
+			name:    "valid",
+			content: "google.com general\ndoubleclick.net ad-server\nexample.org\n",
+			want: []DomainCategory{
+				{Domain: "google.com", Category: CategoryGeneral},
+				{Domain: "doubleclick.net", Category: CategoryAdServer},
+				{Domain: "example.org", Category: CategoryOther},
+			},
+		},
+		{
+			name:     "unknown category",
+			content:  "google.com general\nexample.org news\n",
+			want:     []DomainCategory{{Domain: "google.com", Category: CategoryGeneral}, {Domain: "example.org", Category: CategoryOther}},
+			wantLine: ":2:",
+		},
+		{
+			name:     "extra field",
+			content:  "# domains\nexample.org general extra\n",
+			want:     []DomainCategory{{Domain: "example.org", Category: CategoryGeneral}},
+			wantLine: ":2:",
+		},
+		{
+			name:     "invalid name",
+			content:  "exa..mple.org\n",
+			want:     []DomainCategory{{Domain: "exa..mple.org", Category: CategoryOther}},
+			wantLine: ":1:",
+		},
+		{
+			name:    "critical attribute",
+			content: "bank.com general critical=true\nexample.org critical=TRUE\nnews.com critical=false\n",
+			want: []DomainCategory{
+				{Domain: "bank.com", Category: CategoryGeneral, Critical: true},
+				{Domain: "example.org", Category: CategoryOther, Critical: true},
+				{Domain: "news.com", Category: CategoryOther},
+			},
+		},
+		{
+			name:     "unknown attribute",
+			content:  "bank.com general\nexample.org general weight=2\n",
+			want:     []DomainCategory{{Domain: "bank.com", Category: CategoryGeneral}, {Domain: "example.org", Category: CategoryGeneral}},
+			wantLine: ":2:",
+		},
+	}
+	for _, tt := range tests {
+		path := writeTestFile(t, "domains.txt", tt.content)
+
+		domains, err := loadDomainsFromFile(path, false)
+		if err != nil || len(domains) != len(tt.want) {
+			t.Errorf("%s: loadDomainsFromFile = %+v, %v, want %+v", tt.name, domains, err, tt.want)
+		} else {
+			for i := range domains {
+				if domains[i] != tt.want[i] {
+					t.Errorf("%s: domain %d = %+v, want %+v", tt.name, i, domains[i], tt.want[i])
+				}
+			}
+		}
+
+		_, err = loadDomainsFromFile(path, true)
+		switch {
+		case tt.wantLine == "" && err != nil:
+			t.Errorf("%s: strict loadDomainsFromFile error = %v, want none", tt.name, err)
+		case tt.wantLine != "" && (err == nil || !strings.Contains(err.Error(), tt.wantLine)):
+			t.Errorf("%s: strict loadDomainsFromFile error = %v, want one on line %s", tt.name, err, tt.wantLine)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/miekg/dns"
+	"github.com/quic-go/quic-go"
+)
+
+// DoQPort is the port DNS-over-QUIC resolvers listen on (RFC 9250)
+const DoQPort = "853"
+
+// doqErrorNone is the DOQ_NO_ERROR application error code
+const doqErrorNone = 0
+
+// exchangeQUIC sends msg to server over DNS-over-QUIC. Every query opens its
+// own connection, so the measured time includes the QUIC handshake, as the
+// first query of a real client would.
+func exchangeQUIC(ctx context.Context, msg *dns.Msg, server DNSServer, opts TestOptions) (*dns.Msg, error) {
+	addr, err := net.ResolveUDPAddr("udp", server.address(DoQPort))
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: opts.SourceIP})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	tlsConf := &tls.Config{
+		ServerName:         server.IP,
+		NextProtos:         []string{"doq"},
+		InsecureSkipVerify: opts.InsecureTLS,
+	}
+	session, err := quic.Dial(ctx, conn, addr, tlsConf, &quic.Config{HandshakeIdleTimeout: opts.Timeout})
+	if err != nil {
+		return nil, fmt.Errorf("QUIC handshake failed: %v", err)
+	}
+	defer session.CloseWithError(doqErrorNone, "")
+
+	stream, err := session.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetDeadline(deadline)
+	}
+
+	// DoQ requires a zero message ID and a 2-byte length prefix on the stream
+	query := msg.Copy()
+	query.Id = 0
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stream.Write(binary.BigEndian.AppendUint16(nil, uint16(len(packed)))); err != nil {
+		return nil, err
+	}
+	if _, err := stream.Write(packed); err != nil {
+		return nil, err
+	}
+	stream.Close()
+
+	var length uint16
+	if err := binary.Read(stream, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(stream, buf); err != nil {
+		return nil, err
+	}
+
+	response := new(dns.Msg)
+	if err := response.Unpack(buf); err != nil {
+		return nil, err
+	}
+	response.Id = msg.Id
+	return response, nil
+}
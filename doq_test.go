@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/quic-go/quic-go"
+)
+
+// selfSignedCert returns a certificate for ip that no client trusts
+func selfSignedCert(t *testing.T, ip string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: ip},
+		IPAddresses:  []net.IP{net.ParseIP(ip)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// startDoQListener accepts QUIC connections on port 853 of the first free
+// loopback address from 127.0.0.100 on, and returns that address
+func startDoQListener(t *testing.T) string {
+	t.Helper()
+
+	for i := 100; i < 200; i++ {
+		ip := fmt.Sprintf("127.0.0.%d", i)
+		tlsConf := &tls.Config{Certificates: []tls.Certificate{selfSignedCert(t, ip)}, NextProtos: []string{"doq"}}
+		listener, err := quic.ListenAddr(net.JoinHostPort(ip, DoQPort), tlsConf, nil)
+		if err != nil {
+			continue
+		}
+		t.Cleanup(func() { listener.Close() })
+		go func() {
+			for {
+				if _, err := listener.Accept(context.Background()); err != nil {
+					return
+				}
+			}
+		}()
+		return ip
+	}
+	t.Skip("cannot listen on port 853 of any loopback address")
+	return ""
+}
+
+func TestExchangeQUICUntrustedCertificate(t *testing.T) {
+	ip := startDoQListener(t)
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolQUIC}
+	result := testDNS(newDNSClient(opts), nil, DNSServer{IP: ip}, "example.com", opts)
+	if result.Success || !strings.Contains(result.Error, "QUIC handshake failed") {
+		t.Errorf("result = success %v, error %q, want a handshake failure", result.Success, result.Error)
+	}
+	if result.Protocol != ProtocolQUIC {
+		t.Errorf("result protocol = %q, want %q", result.Protocol, ProtocolQUIC)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// DryRunSamplePairs is the number of server/domain pairs listed by --dry-run
+const DryRunSamplePairs = 10
+
+// printTestPlan describes what a run would do without sending any query: the
+// matrix size, the effective settings and the first pairs to be tested
+func printTestPlan(servers []DNSServer, domains []DomainCategory, testOpts TestOptions, outputs []OutputOptions) {
+	endpoints := serverEndpoints(servers)
+	selected := samplePairs(len(endpoints)*len(domains), testOpts)
+
+	var pairs []string
+	for s, endpoint := range endpoints {
+		for d, domain := range domains {
+			if selected != nil && !selected[s*len(domains)+d] {
+				continue
+			}
+			pair := fmt.Sprintf("%s -> %s (%s)", endpoint.target.IP, domain.Domain, domain.Category)
+			pairs = append(pairs, pair)
+		}
+	}
+
+	samples := testOpts.Samples
+	if samples < 1 {
+		samples = 1
+	}
+	queries := samples * len(testOpts.queryTypes())
+	if testOpts.IPv6 && testOpts.QueryType == dns.TypeA {
+		queries *= 2
+	}
+
+	fmt.Println("DNS Check Test Plan (dry run, no queries sent)")
+	fmt.Println("==============================================")
+	fmt.Printf("  Servers: %d (%d endpoints)\n", len(servers), len(endpoints))
+	fmt.Printf("  Domains: %d\n", len(domains))
+	if selected != nil {
+		fmt.Printf("  Jobs: %d of %d pairs (%.2f%% sample, seed %d)\n", len(pairs), len(endpoints)*len(domains), testOpts.SamplePercent, testOpts.SampleSeed)
+	} else {
+		fmt.Printf("  Jobs: %d pairs\n", len(pairs))
+	}
+	fmt.Printf("  Queries: up to %d (%d per pair)\n", len(pairs)*queries, queries)
+
+	fmt.Println("\nEffective Settings:")
+	var typeNames []string
+	for _, qtype := range testOpts.queryTypes() {
+		typeNames = append(typeNames, dns.TypeToString[qtype])
+	}
+	fmt.Printf("  Query Type: %s, Protocol: %s\n", strings.Join(typeNames, ","), testOpts.Protocol)
+	fmt.Printf("  Timeout: %v, Retries: %d, Workers: %d, Dispatch: %s\n", testOpts.Timeout, testOpts.Retries, testOpts.Workers, testOpts.ParallelOver)
+	fmt.Printf("  QPS: %d, Max Per Server: %d, Max Concurrency: %d, Jitter: %v\n", testOpts.QPS, testOpts.MaxPerServer, testOpts.MaxConcurrency, testOpts.Jitter)
+	if testOpts.Deadline > 0 {
+		fmt.Printf("  Deadline: %v\n", testOpts.Deadline)
+	}
+	for _, opts := range outputs {
+		output := opts.File
+		if output == "" {
+			output = "stdout"
+		}
+		fmt.Printf("  Output: %s (%s)\n", output, opts.Format)
+	}
+
+	var explicit []string
+	flag.Visit(func(f *flag.Flag) {
+		explicit = append(explicit, fmt.Sprintf("--%s=%s", f.Name, f.Value))
+	})
+	if len(explicit) > 0 {
+		fmt.Printf("  Flags: %s\n", strings.Join(explicit, " "))
+	}
+
+	fmt.Printf("\nFirst %d Pairs:\n", min(DryRunSamplePairs, len(pairs)))
+	for _, pair := range pairs[:min(DryRunSamplePairs, len(pairs))] {
+		fmt.Printf("  %s\n", pair)
+	}
+	if len(pairs) > DryRunSamplePairs {
+		fmt.Printf("  ... and %d more\n", len(pairs)-DryRunSamplePairs)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	fn()
+	w.Close()
+	return <-output
+}
+
+func TestPrintTestPlan(t *testing.T) {
+	servers := []DNSServer{{IP: "192.0.2.1"}, {IP: "192.0.2.2", IPv6: "2001:db8::2"}}
+	var domains []DomainCategory
+	for i := 0; i < 4; i++ {
+		domains = append(domains, DomainCategory{Domain: fmt.Sprintf("d%d.example", i), Category: CategoryGeneral})
+	}
+	testOpts := TestOptions{Timeout: 5 * time.Second, Workers: 10, ParallelOver: ParallelOverAll, QueryType: dns.TypeA, Samples: 3}
+
+	plan := captureStdout(t, func() {
+		printTestPlan(servers, domains, testOpts, []OutputOptions{{Format: "json"}})
+	})
+	for _, want := range []string{
+		"Servers: 2 (3 endpoints)",
+		"Jobs: 12 pairs",
+		"Queries: up to 36 (3 per pair)",
+		"Query Type: A",
+		"Output: stdout (json)",
+		"First 10 Pairs:",
+		"192.0.2.1 -> d0.example (General)",
+		"2001:db8::2 -> d1.example (General)",
+		"... and 2 more",
+	} {
+		if !strings.Contains(plan, want) {
+			t.Errorf("test plan lacks %q:\n%s", want, plan)
+		}
+	}
+
+	testOpts.SamplePercent, testOpts.SampleSeed = 50, 1
+	plan = captureStdout(t, func() {
+		printTestPlan(servers, domains, testOpts, []OutputOptions{{Format: "json"}})
+	})
+	if !strings.Contains(plan, "Jobs: 6 of 12 pairs (50.00% sample, seed 1)") {
+		t.Errorf("sampled test plan lacks the sampled job count:\n%s", plan)
+	}
+}
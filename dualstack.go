@@ -0,0 +1,81 @@
+package main
+
+import "net"
+
+// Address families of dual-stack servers
+const (
+	FamilyIPv4 = "ipv4"
+	FamilyIPv6 = "ipv6"
+)
+
+// serverEndpoint is an address queried during a run. Dual-stack servers have
+// one endpoint per family; their results are reported under the logical server.
+type serverEndpoint struct {
+	target DNSServer // Server as queried, with IP set to the endpoint address
+	server DNSServer // Logical server the results belong to
+	family string    // Address family, only set for dual-stack servers
+}
+
+// serverEndpoints expands dual-stack servers into one endpoint per family
+func serverEndpoints(servers []DNSServer) []serverEndpoint {
+	var endpoints []serverEndpoint
+	for _, server := range servers {
+		if server.IPv6 == "" {
+			endpoints = append(endpoints, serverEndpoint{target: server, server: server})
+			continue
+		}
+
+		endpoints = append(endpoints,
+			serverEndpoint{target: familyTarget(server, FamilyIPv4), server: server, family: FamilyIPv4},
+			serverEndpoint{target: familyTarget(server, FamilyIPv6), server: server, family: FamilyIPv6},
+		)
+	}
+	return endpoints
+}
+
+// familyTarget returns the server as queried over one family of a dual-stack
+// server: its address of that family, with the port and description kept
+func familyTarget(server DNSServer, family string) DNSServer {
+	ip := server.IP
+	if family == FamilyIPv6 {
+		ip = server.IPv6
+	}
+	return DNSServer{IP: ip, Port: server.Port, Description: server.Description}
+}
+
+// dualStackPair reports whether a and b are an IPv4 and an IPv6 address, in
+// either order, returning them as (v4, v6)
+func dualStackPair(a, b string) (string, string, bool) {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return "", "", false
+	}
+
+	switch {
+	case ipA.To4() != nil && ipB.To4() == nil:
+		return a, b, true
+	case ipA.To4() == nil && ipB.To4() != nil:
+		return b, a, true
+	}
+	return "", "", false
+}
+
+// familyStats returns the success rates per address family, or nil when no
+// dual-stack server was tested
+func familyStats(results []TestResult) map[string]CategoryStats {
+	byFamily := make(map[string][]TestResult)
+	for _, result := range results {
+		if result.Family != "" {
+			byFamily[result.Family] = append(byFamily[result.Family], result)
+		}
+	}
+	if len(byFamily) == 0 {
+		return nil
+	}
+
+	stats := make(map[string]CategoryStats)
+	for family, familyResults := range byFamily {
+		stats[family] = groupStats(familyResults)
+	}
+	return stats
+}
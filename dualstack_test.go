@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestDualStackPair(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		v4, v6 string
+		ok     bool
+	}{
+		{"1.1.1.1", "2606:4700:4700::1111", "1.1.1.1", "2606:4700:4700::1111", true},
+		{"2606:4700:4700::1111", "1.1.1.1", "1.1.1.1", "2606:4700:4700::1111", true},
+		{"1.1.1.1", "1.0.0.1", "", "", false},
+		{"2606:4700:4700::1111", "2606:4700:4700::1001", "", "", false},
+		{"1.1.1.1", "Cloudflare", "", "", false},
+	}
+	for _, tt := range tests {
+		v4, v6, ok := dualStackPair(tt.a, tt.b)
+		if v4 != tt.v4 || v6 != tt.v6 || ok != tt.ok {
+			t.Errorf("dualStackPair(%s, %s) = %s, %s, %v, want %s, %s, %v", tt.a, tt.b, v4, v6, ok, tt.v4, tt.v6, tt.ok)
+		}
+	}
+}
+
+func TestLoadDualStackServers(t *testing.T) {
+	path := writeTestFile(t, "servers.txt",
+		"1.1.1.1 2606:4700:4700::1111 Cloudflare\n2001:4860:4860::8888 8.8.8.8\n9.9.9.9 149.112.112.112 Quad9\n")
+	servers, err := loadDNSServersFromFile(path, true)
+	if err != nil {
+		t.Fatalf("loadDNSServersFromFile error = %v", err)
+	}
+	want := []DNSServer{
+		{IP: "1.1.1.1", IPv6: "2606:4700:4700::1111", Description: "Cloudflare"},
+		{IP: "8.8.8.8", IPv6: "2001:4860:4860::8888"},
+		{IP: "9.9.9.9", Description: "149.112.112.112 Quad9"}, // Same family, so part of the description
+	}
+	if len(servers) != len(want) {
+		t.Fatalf("loaded %d servers, want %d", len(servers), len(want))
+	}
+	for i := range want {
+		if servers[i] != want[i] {
+			t.Errorf("server %d = %+v, want %+v", i, servers[i], want[i])
+		}
+	}
+}
+
+func TestServerEndpoints(t *testing.T) {
+	single := DNSServer{IP: "9.9.9.9", Description: "Quad9"}
+	dual := DNSServer{IP: "1.1.1.1", IPv6: "2606:4700:4700::1111", Description: "Cloudflare"}
+
+	endpoints := serverEndpoints([]DNSServer{single, dual})
+	want := []serverEndpoint{
+		{target: single, server: single},
+		{target: DNSServer{IP: "1.1.1.1", Description: "Cloudflare"}, server: dual, family: FamilyIPv4},
+		{target: DNSServer{IP: "2606:4700:4700::1111", Description: "Cloudflare"}, server: dual, family: FamilyIPv6},
+	}
+	if len(endpoints) != len(want) {
+		t.Fatalf("got %d endpoints, want %d", len(endpoints), len(want))
+	}
+	for i := range want {
+		if endpoints[i] != want[i] {
+			t.Errorf("endpoint %d = %+v, want %+v", i, endpoints[i], want[i])
+		}
+	}
+}
+
+func TestRunDNSTestsDualStack(t *testing.T) {
+	// The IPv4 address answers, the IPv6 one returns no records
+	v4 := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	startTestServer(t, "[::1]:53", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		w.WriteMsg(m)
+	}))
+	server := DNSServer{IP: v4, IPv6: "::1", Description: "Local"}
+	domains := []DomainCategory{{Domain: "a.example", Category: CategoryGeneral}, {Domain: "b.example", Category: CategoryGeneral}}
+
+	results := runDNSTests([]DNSServer{server}, domains, TestOptions{Timeout: 2 * time.Second, Workers: 2, QueryType: dns.TypeA})
+	if len(results.Results) != 4 {
+		t.Fatalf("got %d results, want one per family and domain", len(results.Results))
+	}
+	for i, result := range results.Results {
+		wantFamily := []string{FamilyIPv4, FamilyIPv6}[i%2]
+		if result.Server != server || result.Family != wantFamily || result.Success != (wantFamily == FamilyIPv4) {
+			t.Errorf("result %d = %s %s success %v, want the logical server over %s", i, result.Server.IP, result.Family, result.Success, wantFamily)
+		}
+	}
+
+	stats := results.Summary.FamilyStats
+	if stats[FamilyIPv4].SuccessRate != 100 || stats[FamilyIPv6].SuccessRate != 0 || stats[FamilyIPv6].TotalTests != 2 {
+		t.Errorf("FamilyStats = %+v, want IPv4 at 100%% and IPv6 at 0%% of 2", stats)
+	}
+	if familyStats([]TestResult{{Success: true}}) != nil {
+		t.Error("familyStats without dual-stack results is not nil")
+	}
+}
+
+func TestFamilyTargetKeepsPort(t *testing.T) {
+	server := DNSServer{IP: "127.0.0.1", IPv6: "::1", Port: "5335", Description: "local"}
+	for family, wantIP := range map[string]string{FamilyIPv4: "127.0.0.1", FamilyIPv6: "::1"} {
+		target := familyTarget(server, family)
+		if target.IP != wantIP || target.Port != "5335" || target.Description != "local" {
+			t.Errorf("familyTarget(%s) = %+v, want IP %s on port 5335", family, target, wantIP)
+		}
+	}
+}
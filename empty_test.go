@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestCalculateSummaryEmpty(t *testing.T) {
+	summary := calculateSummary(nil)
+	if summary.TotalTests != 0 || summary.SuccessfulTests != 0 || summary.SuccessRate != 0 || summary.AverageResponseTime != 0 || summary.Percentiles != nil {
+		t.Errorf("calculateSummary(nil) = %+v, want zero counts and rates", summary)
+	}
+}
+
+func TestPercentileEmpty(t *testing.T) {
+	for _, method := range []string{PercentileLinear, PercentileNearestRank} {
+		if got := percentile(nil, 50, method); got != 0 {
+			t.Errorf("percentile(nil, 50, %s) = %v, want 0", method, got)
+		}
+	}
+	if got := responsePercentiles(nil, PercentileLinear); got != nil {
+		t.Errorf("responsePercentiles(nil) = %+v, want nil", got)
+	}
+}
+
+func TestEmptyLists(t *testing.T) {
+	path := writeTestFile(t, "servers.txt", "# no servers\n\n")
+	servers, err := loadDNSServersFromFile(path, true)
+	if err != nil || len(servers) != 0 {
+		t.Errorf("loadDNSServersFromFile(empty) = %v, %v, want no servers and no error", servers, err)
+	}
+
+	opts := TestOptions{Timeout: time.Second, QueryType: dns.TypeA}
+	domains := []DomainCategory{{Domain: "example.com", Category: "test"}}
+	server := []DNSServer{{IP: "127.0.0.253"}}
+
+	for _, tt := range []struct {
+		name    string
+		servers []DNSServer
+		domains []DomainCategory
+	}{
+		{"no servers", nil, domains},
+		{"no domains", server, nil},
+	} {
+		results := runDNSTests(tt.servers, tt.domains, opts)
+		if len(results.Results) != 0 || results.Summary.TotalTests != 0 || results.Summary.SuccessRate != 0 {
+			t.Errorf("runDNSTests with %s = %d results, summary %+v, want none", tt.name, len(results.Results), results.Summary)
+		}
+	}
+}
+
+func TestShowProgressEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	saved := infoOutput
+	infoOutput = &buf
+	defer func() { infoOutput = saved }()
+
+	var completed int64
+	done := make(chan bool)
+	go showProgress(&completed, 0, time.Now(), done)
+	time.Sleep(3 * ProgressUpdateRate)
+	done <- true
+
+	if buf.Len() != 0 {
+		t.Errorf("progress of a run without jobs = %q, want nothing drawn", buf.String())
+	}
+}
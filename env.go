@@ -0,0 +1,19 @@
+package main
+
+import (
+	"io"
+	"strings"
+)
+
+// Environment variables holding the server and domain lists when --list or
+// --domains is not given
+const (
+	EnvServers = "DNSCHECK_SERVERS"
+	EnvDomains = "DNSCHECK_DOMAINS"
+)
+
+// envListReader turns a newline- or comma-separated environment value into
+// the line format of the list files
+func envListReader(value string) io.Reader {
+	return strings.NewReader(strings.ReplaceAll(value, ",", "\n"))
+}
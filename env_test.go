@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnvListReader(t *testing.T) {
+	servers, err := parseDNSServers(envListReader("1.1.1.1 Cloudflare,8.8.8.8\n9.9.9.9"), EnvServers, true)
+	want := []DNSServer{{IP: "1.1.1.1", Description: "Cloudflare"}, {IP: "8.8.8.8"}, {IP: "9.9.9.9"}}
+	if err != nil || len(servers) != len(want) {
+		t.Fatalf("servers from %s = %+v, %v, want %+v", EnvServers, servers, err, want)
+	}
+	for i := range want {
+		if servers[i] != want[i] {
+			t.Errorf("server %d = %+v, want %+v", i, servers[i], want[i])
+		}
+	}
+
+	domains, err := parseDomains(envListReader("google.com,doubleclick.net Ad-server"), EnvDomains, true)
+	if err != nil || len(domains) != 2 || domains[1].Category != CategoryAdServer {
+		t.Errorf("domains from %s = %+v, %v, want 2 with the second an ad server", EnvDomains, domains, err)
+	}
+
+	_, err = parseDomains(envListReader("google.com,bad..name"), EnvDomains, true)
+	if err == nil || !strings.HasPrefix(err.Error(), EnvDomains+":2:") {
+		t.Errorf("strict error = %v, want one naming %s line 2", err, EnvDomains)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// serverFilter holds the conditions of --filter-servers; a server qualifies
+// when it meets all of them
+type serverFilter struct {
+	expression string
+	minSuccess float64       // Success rate (%), negative when unset
+	minAdBlock float64       // Blocked share (%) of the Ad-server tests, negative when unset
+	maxP95     time.Duration // 0 when unset
+	maxAverage time.Duration // 0 when unset
+	dnssec     bool          // Must validate DNSSEC
+	noHijack   bool          // Must not answer for nonexistent names
+}
+
+// ServerFilterReport represents the servers that passed --filter-servers
+type ServerFilterReport struct {
+	Expression string   `json:"expression"`
+	Tested     int      `json:"tested"`
+	Matched    []string `json:"matched"` // Server IPs
+}
+
+var filterCondition = regexp.MustCompile(`^([a-z0-9-]+)\s*(>=|<=)?\s*(.*)$`)
+
+// parseServerFilter parses a comma-separated list of conditions:
+// success>=PCT, p95<=DUR, avg<=DUR, adblock>=PCT, dnssec and no-hijack
+func parseServerFilter(expression string) (*serverFilter, error) {
+	filter := &serverFilter{expression: expression, minSuccess: -1, minAdBlock: -1}
+
+	for _, condition := range strings.Split(expression, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		match := filterCondition.FindStringSubmatch(strings.ToLower(condition))
+		if match == nil {
+			return nil, fmt.Errorf("invalid filter condition '%s'", condition)
+		}
+		name, operator, value := match[1], match[2], match[3]
+
+		var err error
+		switch {
+		case name == "success" && operator == ">=":
+			filter.minSuccess, err = strconv.ParseFloat(value, 64)
+		case name == "adblock" && operator == ">=":
+			filter.minAdBlock, err = strconv.ParseFloat(value, 64)
+		case name == "p95" && operator == "<=":
+			filter.maxP95, err = time.ParseDuration(value)
+		case name == "avg" && operator == "<=":
+			filter.maxAverage, err = time.ParseDuration(value)
+		case name == "dnssec" && operator == "":
+			filter.dnssec = true
+		case name == "no-hijack" && operator == "":
+			filter.noHijack = true
+		default:
+			return nil, fmt.Errorf("unsupported filter condition '%s'", condition)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in filter condition '%s': %v", condition, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// matches reports whether a server meets every condition. Conditions on
+// probe data fail when the probe didn't produce a result.
+func (f *serverFilter) matches(results []TestResult, profile *ServerProfile, percentileMethod string) bool {
+	stats := groupStats(results)
+	if f.minSuccess >= 0 && stats.SuccessRate < f.minSuccess {
+		return false
+	}
+
+	var times []time.Duration
+	adTests, adBlocked := 0, 0
+	for _, result := range results {
+		if result.Success {
+			times = append(times, result.ResponseTime)
+		}
+		if result.Category == CategoryAdServer {
+			adTests++
+			if result.Blocked {
+				adBlocked++
+			}
+		}
+	}
+
+	if f.maxP95 > 0 || f.maxAverage > 0 {
+		if len(times) == 0 {
+			return false
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		if f.maxP95 > 0 && percentile(times, 95, percentileMethod) > f.maxP95 {
+			return false
+		}
+		if f.maxAverage > 0 && averageResponseTime(results) > f.maxAverage {
+			return false
+		}
+	}
+	if f.minAdBlock >= 0 && (adTests == 0 || float64(adBlocked)/float64(adTests)*100 < f.minAdBlock) {
+		return false
+	}
+
+	if f.dnssec && (profile == nil || profile.DNSSECValidating == nil || !*profile.DNSSECValidating) {
+		return false
+	}
+	if f.noHijack && (profile == nil || profile.WildcardResponder == nil || *profile.WildcardResponder) {
+		return false
+	}
+	return true
+}
+
+// applyServerFilter drops the results and profiles of the servers that don't
+// meet the filter and records the shortlist in the summary. The rest of the
+// summary still describes the whole run.
+func applyServerFilter(results *TestResults, filter *serverFilter, percentileMethod string) {
+	profiles := make(map[DNSServer]*ServerProfile)
+	for i := range results.Servers {
+		profiles[results.Servers[i].Server] = &results.Servers[i]
+	}
+
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results.Results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	report := &ServerFilterReport{Expression: filter.expression, Tested: len(servers), Matched: []string{}}
+	matched := make(map[DNSServer]bool)
+	for _, server := range servers {
+		if filter.matches(byServer[server], profiles[server], percentileMethod) {
+			matched[server] = true
+			report.Matched = append(report.Matched, server.IP)
+		}
+	}
+
+	var kept []TestResult
+	for _, result := range results.Results {
+		if matched[result.Server] {
+			kept = append(kept, result)
+		}
+	}
+	results.Results = kept
+
+	var keptProfiles []ServerProfile
+	for _, profile := range results.Servers {
+		if matched[profile.Server] {
+			keptProfiles = append(keptProfiles, profile)
+		}
+	}
+	results.Servers = keptProfiles
+	results.Summary.Filter = report
+}
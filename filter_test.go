@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseServerFilter(t *testing.T) {
+	filter, err := parseServerFilter("success>=99, P95<=50ms,avg<=20ms,adblock>=90,dnssec,no-hijack")
+	if err != nil {
+		t.Fatalf("parseServerFilter error = %v", err)
+	}
+	if filter.minSuccess != 99 || filter.maxP95 != 50*time.Millisecond || filter.maxAverage != 20*time.Millisecond ||
+		filter.minAdBlock != 90 || !filter.dnssec || !filter.noHijack {
+		t.Errorf("parseServerFilter = %+v, want every condition set", filter)
+	}
+
+	filter, err = parseServerFilter("dnssec")
+	if err != nil || filter.minSuccess >= 0 || filter.minAdBlock >= 0 || filter.maxP95 != 0 {
+		t.Errorf("parseServerFilter(dnssec) = %+v, %v, want the other conditions unset", filter, err)
+	}
+
+	for _, expression := range []string{"success<=99", "p95>=50ms", "p95<=fast", "success>=lots", "latency<=5ms", "dnssec>=1", "!!"} {
+		if _, err := parseServerFilter(expression); err == nil {
+			t.Errorf("parseServerFilter(%q) succeeded, want an error", expression)
+		}
+	}
+}
+
+func TestApplyServerFilter(t *testing.T) {
+	fast := DNSServer{IP: "1.1.1.1"}
+	slow := DNSServer{IP: "8.8.8.8"}
+	unreliable := DNSServer{IP: "9.9.9.9"}
+	ms := time.Millisecond
+	validating, notValidating := true, false
+	results := TestResults{
+		Results: []TestResult{
+			{Server: fast, Success: true, ResponseTime: 10 * ms},
+			{Server: fast, Category: CategoryAdServer, Success: true, Blocked: true, ResponseTime: 10 * ms},
+			{Server: slow, Success: true, ResponseTime: 90 * ms},
+			{Server: slow, Category: CategoryAdServer, Success: true, Blocked: true, ResponseTime: 90 * ms},
+			{Server: unreliable, Success: true, ResponseTime: 10 * ms},
+			{Server: unreliable, Category: CategoryAdServer, Error: "timeout"},
+		},
+		Servers: []ServerProfile{
+			{Server: fast, DNSSECValidating: &validating},
+			{Server: slow, DNSSECValidating: &validating},
+			{Server: unreliable, DNSSECValidating: &notValidating},
+		},
+	}
+
+	filter, err := parseServerFilter("avg<=50ms,dnssec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyServerFilter(&results, filter, PercentileLinear)
+
+	report := results.Summary.Filter
+	if report == nil || report.Tested != 3 || len(report.Matched) != 1 || report.Matched[0] != fast.IP {
+		t.Fatalf("filter report = %+v, want only %s of 3 servers", report, fast.IP)
+	}
+	if len(results.Results) != 2 || len(results.Servers) != 1 {
+		t.Errorf("kept %d results and %d profiles, want 2 and 1", len(results.Results), len(results.Servers))
+	}
+}
+
+func TestServerFilterMatches(t *testing.T) {
+	server := DNSServer{IP: "1.1.1.1"}
+	ms := time.Millisecond
+	results := []TestResult{
+		{Server: server, Success: true, ResponseTime: 10 * ms},
+		{Server: server, Category: CategoryAdServer, Success: true, ResponseTime: 30 * ms},
+	}
+	hijacking := true
+
+	tests := []struct {
+		expression string
+		profile    *ServerProfile
+		want       bool
+	}{
+		{"success>=100", nil, true},
+		{"p95<=29ms", nil, false},
+		{"p95<=30ms", nil, true},
+		{"adblock>=50", nil, false},
+		{"adblock>=0", nil, true},
+		{"dnssec", nil, false}, // Without the probe's result
+		{"no-hijack", &ServerProfile{WildcardResponder: &hijacking}, false},
+	}
+	for _, tt := range tests {
+		filter, err := parseServerFilter(tt.expression)
+		if err != nil {
+			t.Fatalf("parseServerFilter(%q) error = %v", tt.expression, err)
+		}
+		if got := filter.matches(results, tt.profile, PercentileNearestRank); got != tt.want {
+			t.Errorf("%s: matches = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
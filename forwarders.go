@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Server list formats selectable with --list-format
+const (
+	ListFormatAuto    = "auto"
+	ListFormatPlain   = "plain"
+	ListFormatUnbound = "unbound"
+	ListFormatBind    = "bind"
+)
+
+var (
+	// bindComments matches /* */, // and # comments of named.conf
+	bindComments = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*|#[^\n]*`)
+	// bindForwarders matches the body of a forwarders { ... }; block
+	bindForwarders = regexp.MustCompile(`forwarders\s*\{([^}]*)\}`)
+)
+
+// detectListFormat picks the parser for a --list file. Only .conf files are
+// inspected; they are read as Unbound or BIND configs by their directives.
+func detectListFormat(filename string) (string, error) {
+	if !strings.HasSuffix(filename, ".conf") {
+		return ListFormatPlain, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.Contains(string(data), "forward-addr:"):
+		return ListFormatUnbound, nil
+	case bindForwarders.Match(data):
+		return ListFormatBind, nil
+	}
+	return ListFormatPlain, nil
+}
+
+// loadServerList loads the --list file in the given format
+func loadServerList(filename, format string, strict bool) ([]DNSServer, error) {
+	if format == ListFormatAuto {
+		detected, err := detectListFormat(filename)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+
+	switch format {
+	case ListFormatPlain:
+		return loadDNSServersFromFile(filename, strict)
+	case ListFormatUnbound:
+		return loadUnboundForwarders(filename, strict)
+	case ListFormatBind:
+		return loadBindForwarders(filename, strict)
+	}
+	return nil, fmt.Errorf("unsupported list format: %s", format)
+}
+
+// loadUnboundForwarders extracts the forward-addr entries of an Unbound
+// config. Port (@853) and TLS name (#host) suffixes are dropped.
+func loadUnboundForwarders(filename string, strict bool) ([]DNSServer, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []DNSServer
+	zone := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(line, "name:"); ok {
+			zone = strings.Trim(strings.TrimSpace(name), `"`)
+			continue
+		}
+
+		value, ok := strings.CutPrefix(line, "forward-addr:")
+		if !ok || len(strings.Fields(value)) == 0 {
+			continue
+		}
+
+		addr := strings.Fields(value)[0]
+		if at := strings.IndexAny(addr, "@#"); at >= 0 {
+			addr = addr[:at]
+		}
+
+		server, err := forwarderServer(addr, "Unbound forward-zone "+zone, filename, i+1, strict)
+		if err != nil {
+			return nil, err
+		}
+		if server != nil {
+			servers = append(servers, *server)
+		}
+	}
+
+	return servers, nil
+}
+
+// loadBindForwarders extracts the addresses of every forwarders { } block of a
+// BIND config. "port" settings are dropped.
+func loadBindForwarders(filename string, strict bool) ([]DNSServer, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	config := bindComments.ReplaceAllString(string(data), "")
+
+	var servers []DNSServer
+	for _, block := range bindForwarders.FindAllStringSubmatch(config, -1) {
+		for _, entry := range strings.Split(block[1], ";") {
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				continue
+			}
+
+			server, err := forwarderServer(fields[0], "BIND forwarder", filename, 0, strict)
+			if err != nil {
+				return nil, err
+			}
+			if server != nil {
+				servers = append(servers, *server)
+			}
+		}
+	}
+
+	return servers, nil
+}
+
+// forwarderServer validates a forwarder address like loadDNSServersFromFile
+// does, returning nil for an invalid address outside strict mode
+func forwarderServer(addr, description, filename string, lineNum int, strict bool) (*DNSServer, error) {
+	if net.ParseIP(addr) != nil {
+		return &DNSServer{IP: addr, Description: description}, nil
+	}
+
+	location := filename
+	if lineNum > 0 {
+		location = fmt.Sprintf("%s:%d", filename, lineNum)
+	}
+	if strict {
+		return nil, fmt.Errorf("%s: invalid forwarder address '%s'", location, addr)
+	}
+	fmt.Fprintf(infoOutput, "Warning: Invalid forwarder address '%s' in %s, skipping\n", addr, location)
+	return nil, nil
+}
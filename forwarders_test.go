@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testUnboundConfig = `server:
+    verbosity: 1
+# forward-addr: 192.0.2.99
+forward-zone:
+    name: "."
+    forward-addr: 1.1.1.1@853#cloudflare-dns.com
+    forward-addr: 2606:4700:4700::1111
+forward-zone:
+    name: "corp.example"
+    forward-addr: 10.0.0.53
+`
+
+const testBindConfig = `options {
+    directory "/var/cache/bind";
+    /* forwarders { 192.0.2.99; }; */
+    forwarders {
+        8.8.8.8;     // Google
+        8.8.4.4 port 5353;
+        # 192.0.2.98;
+    };
+};
+`
+
+func TestLoadServerListFormats(t *testing.T) {
+	unbound := writeTestFile(t, "unbound.conf", testUnboundConfig)
+	bind := writeTestFile(t, "named.conf", testBindConfig)
+	plain := writeTestFile(t, "servers.txt", "9.9.9.9 Quad9\n")
+
+	tests := []struct {
+		name   string
+		path   string
+		format string
+		want   []DNSServer
+	}{
+		{"unbound", unbound, ListFormatAuto, []DNSServer{
+			{IP: "1.1.1.1", Description: "Unbound forward-zone ."},
+			{IP: "2606:4700:4700::1111", Description: "Unbound forward-zone ."},
+			{IP: "10.0.0.53", Description: "Unbound forward-zone corp.example"},
+		}},
+		{"bind", bind, ListFormatAuto, []DNSServer{
+			{IP: "8.8.8.8", Description: "BIND forwarder"},
+			{IP: "8.8.4.4", Description: "BIND forwarder"},
+		}},
+		{"bind forced", bind, ListFormatBind, []DNSServer{
+			{IP: "8.8.8.8", Description: "BIND forwarder"},
+			{IP: "8.8.4.4", Description: "BIND forwarder"},
+		}},
+		{"plain", plain, ListFormatAuto, []DNSServer{{IP: "9.9.9.9", Description: "Quad9"}}},
+	}
+	for _, tt := range tests {
+		servers, err := loadServerList(tt.path, tt.format, true)
+		if err != nil {
+			t.Errorf("%s: loadServerList error = %v", tt.name, err)
+			continue
+		}
+		if len(servers) != len(tt.want) {
+			t.Errorf("%s: loadServerList = %+v, want %+v", tt.name, servers, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if servers[i] != tt.want[i] {
+				t.Errorf("%s: server %d = %+v, want %+v", tt.name, i, servers[i], tt.want[i])
+			}
+		}
+	}
+
+	if _, err := loadServerList(plain, "yaml", false); err == nil {
+		t.Error("loadServerList with an unknown format succeeded, want an error")
+	}
+}
+
+func TestDetectListFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"servers.txt", "forward-addr: 1.1.1.1\n", ListFormatPlain},
+		{"unbound.conf", testUnboundConfig, ListFormatUnbound},
+		{"named.conf", testBindConfig, ListFormatBind},
+		{"other.conf", "1.1.1.1\n", ListFormatPlain},
+	}
+	for _, tt := range tests {
+		got, err := detectListFormat(writeTestFile(t, tt.name, tt.content))
+		if err != nil || got != tt.want {
+			t.Errorf("detectListFormat(%s) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestLoadForwardersStrict(t *testing.T) {
+	path := writeTestFile(t, "unbound.conf", "forward-zone:\n    name: \".\"\n    forward-addr: dns.example\n    forward-addr: 1.1.1.1\n")
+
+	servers, err := loadUnboundForwarders(path, false)
+	if err != nil || len(servers) != 1 || servers[0].IP != "1.1.1.1" {
+		t.Errorf("loadUnboundForwarders = %+v, %v, want the valid address only", servers, err)
+	}
+	if _, err := loadUnboundForwarders(path, true); err == nil || !strings.Contains(err.Error(), "unbound.conf:3:") {
+		t.Errorf("strict loadUnboundForwarders error = %v, want one on line 3", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// geoRecord holds the fields read from MaxMind-style country and ASN databases
+type geoRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	ASN   uint   `maxminddb:"autonomous_system_number"`
+	ASOrg string `maxminddb:"autonomous_system_organization"`
+}
+
+// GeoIPEnricher annotates resolved IPs with country and ASN data
+type GeoIPEnricher struct {
+	readers []*maxminddb.Reader
+}
+
+// openGeoIP opens one or more comma-separated .mmdb files, e.g. a country
+// database and an ASN database. Lookups merge the fields found in each.
+func openGeoIP(paths string) (*GeoIPEnricher, error) {
+	enricher := &GeoIPEnricher{}
+
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		reader, err := maxminddb.Open(path)
+		if err != nil {
+			enricher.Close()
+			return nil, err
+		}
+		enricher.readers = append(enricher.readers, reader)
+	}
+
+	return enricher, nil
+}
+
+func (g *GeoIPEnricher) Close() {
+	for _, reader := range g.readers {
+		reader.Close()
+	}
+}
+
+func (g *GeoIPEnricher) lookup(ip net.IP) geoRecord {
+	var merged geoRecord
+
+	for _, reader := range g.readers {
+		var record geoRecord
+		if err := reader.Lookup(ip, &record); err != nil {
+			continue
+		}
+
+		if merged.Country.ISOCode == "" {
+			merged.Country.ISOCode = record.Country.ISOCode
+		}
+		if merged.ASN == 0 {
+			merged.ASN = record.ASN
+			merged.ASOrg = record.ASOrg
+		}
+	}
+
+	return merged
+}
+
+// enrich fills in the country and ASN fields of every successful result, and
+// the country of the server queried
+func (g *GeoIPEnricher) enrich(results []TestResult) {
+	for i := range results {
+		if serverIP := net.ParseIP(results[i].Server.IP); serverIP != nil {
+			results[i].ServerCountry = g.lookup(serverIP).Country.ISOCode
+		}
+
+		ip := net.ParseIP(results[i].IP)
+		if ip == nil {
+			continue
+		}
+
+		record := g.lookup(ip)
+		results[i].Country = record.Country.ISOCode
+		results[i].ASN = record.ASN
+		results[i].ASOrg = record.ASOrg
+	}
+}
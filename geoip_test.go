@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mmdbField is a key and value of a MaxMind DB map, kept in order
+type mmdbField struct {
+	key   string
+	value interface{} // string, uint16, uint32 or []mmdbField
+}
+
+// encodeMMDB appends value in the MaxMind DB data section encoding
+func encodeMMDB(buf *bytes.Buffer, value interface{}) {
+	control := func(kind byte, size int) {
+		if size < 29 {
+			buf.WriteByte(kind<<5 | byte(size))
+			return
+		}
+		buf.WriteByte(kind<<5 | 29)
+		buf.WriteByte(byte(size - 29))
+	}
+	switch v := value.(type) {
+	case string:
+		control(2, len(v))
+		buf.WriteString(v)
+	case uint16:
+		control(5, 2)
+		binary.Write(buf, binary.BigEndian, v)
+	case uint32:
+		control(6, 4)
+		binary.Write(buf, binary.BigEndian, v)
+	case []mmdbField:
+		control(7, len(v))
+		for _, field := range v {
+			encodeMMDB(buf, field.key)
+			encodeMMDB(buf, field.value)
+		}
+	}
+}
+
+// writeTestMMDB writes an IPv4 MaxMind DB holding record for 128.0.0.0/1;
+// the lower half of the address space has no data
+func writeTestMMDB(t *testing.T, record []mmdbField) string {
+	t.Helper()
+
+	var db bytes.Buffer
+	// A single node: left is "not found" (the node count), right points at
+	// the first data record (node count + 16)
+	db.Write([]byte{0, 0, 1, 0, 0, 17})
+	db.Write(make([]byte, 16))
+	encodeMMDB(&db, record)
+
+	db.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encodeMMDB(&db, []mmdbField{
+		{"node_count", uint32(1)},
+		{"record_size", uint16(24)},
+		{"ip_version", uint16(4)},
+		{"database_type", "Test"},
+		{"binary_format_major_version", uint16(2)},
+		{"binary_format_minor_version", uint16(0)},
+	})
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := os.WriteFile(path, db.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGeoIPEnrich(t *testing.T) {
+	country := writeTestMMDB(t, []mmdbField{
+		{"country", []mmdbField{{"iso_code", "NL"}}},
+	})
+	asn := writeTestMMDB(t, []mmdbField{
+		{"autonomous_system_number", uint32(64500)},
+		{"autonomous_system_organization", "Example Networks"},
+	})
+
+	enricher, err := openGeoIP(country + ", " + asn)
+	if err != nil {
+		t.Fatalf("openGeoIP error = %v", err)
+	}
+	defer enricher.Close()
+
+	results := []TestResult{
+		{Server: DNSServer{IP: "10.0.0.53"}, Success: true, IP: "192.0.2.1"},
+		{Server: DNSServer{IP: "10.0.0.53"}, Success: true, IP: "10.0.0.1"}, // Not in the databases
+		{Server: DNSServer{IP: "192.0.2.53"}, Error: "timeout"},
+	}
+	enricher.enrich(results)
+
+	if got := formatGeo(results[0]); got != "NL AS64500 Example Networks" {
+		t.Errorf("192.0.2.1 enriched as %q, want the country and ASN of both databases", got)
+	}
+	for _, result := range results[1:] {
+		if result.Country != "" || result.ASN != 0 {
+			t.Errorf("result %+v enriched, want it left alone", result)
+		}
+	}
+	if results[0].ServerCountry != "" || results[2].ServerCountry != "NL" {
+		t.Errorf("server countries = %q, %q, want none for 10.0.0.53 and NL for 192.0.2.53", results[0].ServerCountry, results[2].ServerCountry)
+	}
+}
+
+func TestOpenGeoIPMissingFile(t *testing.T) {
+	if _, err := openGeoIP(filepath.Join(t.TempDir(), "missing.mmdb")); err == nil {
+		t.Error("openGeoIP of a missing file succeeded, want an error")
+	}
+}
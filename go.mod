@@ -2,11 +2,21 @@ module dns-check-go
 
 go 1.21
 
-require github.com/miekg/dns v1.1.55
+require (
+	github.com/miekg/dns v1.1.55
+	github.com/oschwald/maxminddb-golang v1.12.0
+	github.com/quic-go/quic-go v0.41.0
+)
 
 require (
+	github.com/go-task/slim-sprig v0.0.0-20230315185526-52ccab3ef572 // indirect
+	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38 // indirect
+	github.com/onsi/ginkgo/v2 v2.9.5 // indirect
+	go.uber.org/mock v0.3.0 // indirect
+	golang.org/x/crypto v0.17.0 // indirect
+	golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1 // indirect
 	golang.org/x/mod v0.12.0 // indirect
 	golang.org/x/net v0.14.0 // indirect
-	golang.org/x/sys v0.11.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
 	golang.org/x/tools v0.12.0 // indirect
 )
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// HostnameResolveTimeout bounds the lookup of each server hostname at startup
+const HostnameResolveTimeout = 5 * time.Second
+
+// serverHostname matches a hostname usable in place of a server IP: dot
+// separated letters, digits and hyphens, with at least one letter
+var serverHostname = regexp.MustCompile(`^(?i)[a-z0-9-]+(\.[a-z0-9-]+)*\.?$`)
+
+var (
+	// serverBootstrap is the resolver, IP[:PORT], that server hostnames are
+	// looked up with; the system resolver when empty
+	serverBootstrap string
+	// hostnameAddresses caches the addresses of every hostname looked up, so
+	// that lists naming a server more than once resolve it once
+	hostnameAddresses = make(map[string][]string)
+)
+
+// splitServerHostname parses a server list entry naming the server by
+// hostname, optionally with a port, as in dns.quad9.net or dns.quad9.net:5353
+func splitServerHostname(entry string) (host, port string, ok bool) {
+	host = entry
+	if h, p, err := net.SplitHostPort(entry); err == nil {
+		host, port = h, p
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", "", false
+		}
+	}
+	if net.ParseIP(host) != nil || !serverHostname.MatchString(host) || !strings.ContainsAny(strings.ToLower(host), "abcdefghijklmnopqrstuvwxyz") {
+		return "", "", false
+	}
+	return strings.TrimSuffix(strings.ToLower(host), "."), port, true
+}
+
+// resolveServerHostname returns the IPv4 addresses of a server hostname
+// followed by its IPv6 ones, looked up with serverBootstrap or the system
+// resolver
+func resolveServerHostname(host string) ([]string, error) {
+	if addresses, ok := hostnameAddresses[host]; ok {
+		return addresses, nil
+	}
+
+	var addresses []string
+	var err error
+	if serverBootstrap != "" {
+		addresses, err = bootstrapLookup(host, serverBootstrap)
+	} else {
+		addresses, err = systemLookup(host)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve '%s': %v", host, err)
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("cannot resolve '%s': no addresses", host)
+	}
+
+	hostnameAddresses[host] = addresses
+	return addresses, nil
+}
+
+// systemLookup resolves host with the system resolver
+func systemLookup(host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), HostnameResolveTimeout)
+	defer cancel()
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+
+	var v4, v6 []string
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			v4 = append(v4, ip.String())
+		} else {
+			v6 = append(v6, ip.String())
+		}
+	}
+	return append(v4, v6...), nil
+}
+
+// bootstrapLookup resolves host with A and AAAA queries to the bootstrap
+// resolver. A name without AAAA records is fine; a failed query isn't.
+func bootstrapLookup(host, bootstrap string) ([]string, error) {
+	client := &dns.Client{Timeout: HostnameResolveTimeout}
+
+	var addresses []string
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(host), qtype)
+		msg.RecursionDesired = true
+
+		response, _, err := client.Exchange(msg, bootstrap)
+		if err != nil {
+			return nil, err
+		}
+		if response.Rcode != dns.RcodeSuccess {
+			return nil, fmt.Errorf("%s from %s", dns.RcodeToString[response.Rcode], bootstrap)
+		}
+
+		for _, answer := range response.Answer {
+			switch rr := answer.(type) {
+			case *dns.A:
+				addresses = append(addresses, rr.A.String())
+			case *dns.AAAA:
+				addresses = append(addresses, rr.AAAA.String())
+			}
+		}
+	}
+	return addresses, nil
+}
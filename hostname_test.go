@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestSplitServerHostname(t *testing.T) {
+	tests := []struct {
+		entry    string
+		wantHost string
+		wantPort string
+		wantOK   bool
+	}{
+		{"dns.quad9.net", "dns.quad9.net", "", true},
+		{"dns.quad9.net:5353", "dns.quad9.net", "5353", true},
+		{"DNS.Quad9.NET.", "dns.quad9.net", "", true},
+		{"resolver-1.example", "resolver-1.example", "", true},
+		{"dns.quad9.net:0", "", "", false},
+		{"dns.quad9.net:dns", "", "", false},
+		{"1.1.1.1", "", "", false},
+		{"[::1]:53", "", "", false},
+		{"12345", "", "", false},
+		{"bad_host.example", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		host, port, ok := splitServerHostname(tt.entry)
+		if host != tt.wantHost || port != tt.wantPort || ok != tt.wantOK {
+			t.Errorf("splitServerHostname(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.entry, host, port, ok, tt.wantHost, tt.wantPort, tt.wantOK)
+		}
+	}
+}
+
+func TestParseDNSServersHostname(t *testing.T) {
+	// The bootstrap resolver knows one name, with an address of each family
+	bootstrap := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		q := r.Question[0]
+		if q.Name != "dns.example." {
+			m.Rcode = dns.RcodeNameError
+		} else if q.Qtype == dns.TypeA {
+			m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60}, A: net.ParseIP("192.0.2.53")})
+		} else if q.Qtype == dns.TypeAAAA {
+			m.Answer = append(m.Answer, &dns.AAAA{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: 60}, AAAA: net.ParseIP("2001:db8::53")})
+		}
+		w.WriteMsg(m)
+	}))
+	serverBootstrap = bootstrap
+	t.Cleanup(func() {
+		serverBootstrap = ""
+		delete(hostnameAddresses, "dns.example")
+		delete(hostnameAddresses, "missing.example")
+	})
+
+	list := "dns.example:5353 Example\nmissing.example\n"
+	servers, err := parseDNSServers(strings.NewReader(list), "servers.txt", false)
+	if err != nil {
+		t.Fatalf("parseDNSServers error = %v", err)
+	}
+	want := []DNSServer{
+		{IP: "192.0.2.53", Hostname: "dns.example", Port: "5353", Description: "Example"},
+		{IP: "2001:db8::53", Hostname: "dns.example", Port: "5353", Description: "Example"},
+	}
+	if !reflect.DeepEqual(servers, want) {
+		t.Errorf("parseDNSServers = %+v, want %+v", servers, want)
+	}
+	if got := servers[1].label(); got != "dns.example/[2001:db8::53]:5353" {
+		t.Errorf("label() = %q, want the hostname before the address", got)
+	}
+
+	// Without a description, the hostname describes the server
+	servers, err = parseDNSServers(strings.NewReader("dns.example\n"), "servers.txt", false)
+	if err != nil || len(servers) != 2 || servers[0].Description != "dns.example" {
+		t.Errorf("parseDNSServers without a description = %+v, %v, want the hostname as description", servers, err)
+	}
+}
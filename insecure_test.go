@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestInsecureSkipVerify(t *testing.T) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{selfSignedCert(t, "127.0.0.1")}})
+	if err != nil {
+		t.Skipf("cannot listen on TCP: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{Listener: listener, Net: "tcp-tls", NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	target := DNSServer{IP: "127.0.0.1", Port: port}
+
+	tests := []struct {
+		insecure bool
+		wantErr  bool
+	}{
+		{false, true},
+		{true, false},
+	}
+	for _, tt := range tests {
+		pool := newPipelinePool(TestOptions{Protocol: ProtocolTLS, Timeout: 2 * time.Second, InsecureTLS: tt.insecure})
+		msg := new(dns.Msg)
+		msg.SetQuestion("example.com.", dns.TypeA)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := pool.exchange(ctx, msg, target)
+		cancel()
+		pool.close()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("exchange with insecure %v error = %v, want error %v", tt.insecure, err, tt.wantErr)
+		}
+	}
+}
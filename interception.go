@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// EgressProbeDomain answers TXT queries with the address of the resolver
+// that asked its authoritative servers, which differs between operators
+const EgressProbeDomain = "o-o.myaddr.l.google.com"
+
+// InterceptionTimeout caps the wait for every --check-interception query.
+// The queries to the unrouted addresses only return on interception, so the
+// check takes this long on a clean network.
+const InterceptionTimeout = 3 * time.Second
+
+var (
+	// interceptionResolvers are public resolvers of distinct operators, whose
+	// egress addresses seen by EgressProbeDomain never coincide
+	interceptionResolvers = []DNSServer{
+		{IP: "8.8.8.8", Description: "Google"},
+		{IP: "1.1.1.1", Description: "Cloudflare"},
+		{IP: "9.9.9.9", Description: "Quad9"},
+		{IP: "208.67.222.222", Description: "OpenDNS"},
+	}
+	// blackholeAddresses are documentation addresses (RFC 5737) that are
+	// never routed, so only an interceptor can answer queries sent to them
+	blackholeAddresses = []DNSServer{
+		{IP: "192.0.2.1"},
+		{IP: "198.51.100.1"},
+	}
+)
+
+// InterceptionReport represents the outcome of the --check-interception
+// heuristics for the network the run was made from
+type InterceptionReport struct {
+	Intercepted        bool              `json:"intercepted"`
+	Reasons            []string          `json:"reasons,omitempty"`
+	BlackholeAnswers   []string          `json:"blackhole_answers,omitempty"` // Unrouted addresses that answered
+	ResolverEgress     map[string]string `json:"resolver_egress,omitempty"`   // Address EgressProbeDomain saw, by public resolver
+	UnansweredControls int               `json:"unanswered_controls,omitempty"`
+}
+
+// detectInterception looks for a network intercepting port 53. It is flagged
+// when any unrouted blackhole address answers, or when at least two public
+// resolvers answer the egress probe and all report the same egress address,
+// i.e. the queries reached the same resolver whatever address they were
+// sent to.
+func detectInterception(resolvers, blackholes []DNSServer, timeout time.Duration) *InterceptionReport {
+	timeout = min(timeout, InterceptionTimeout)
+	client := &dns.Client{Timeout: timeout}
+
+	query := func(server DNSServer, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(name), qtype)
+		msg.RecursionDesired = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		response, _, err := client.ExchangeContext(ctx, msg, server.address("53"))
+		return response, err
+	}
+
+	report := &InterceptionReport{ResolverEgress: make(map[string]string)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, server := range blackholes {
+		wg.Add(1)
+		go func(server DNSServer) {
+			defer wg.Done()
+			if _, err := query(server, EgressProbeDomain, dns.TypeTXT); err == nil {
+				mu.Lock()
+				report.BlackholeAnswers = append(report.BlackholeAnswers, server.label())
+				mu.Unlock()
+			}
+		}(server)
+	}
+	for _, server := range resolvers {
+		wg.Add(1)
+		go func(server DNSServer) {
+			defer wg.Done()
+			response, err := query(server, EgressProbeDomain, dns.TypeTXT)
+			egress := ""
+			if err == nil {
+				egress = egressAddress(response)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if egress == "" {
+				report.UnansweredControls++
+				return
+			}
+			report.ResolverEgress[server.label()] = egress
+		}(server)
+	}
+	wg.Wait()
+	sort.Strings(report.BlackholeAnswers)
+
+	if len(report.BlackholeAnswers) > 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("unrouted address %s answered", strings.Join(report.BlackholeAnswers, ", ")))
+	}
+	if egress, same := commonEgress(report.ResolverEgress); same {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("%d public resolvers all resolved through %s", len(report.ResolverEgress), egress))
+	}
+	report.Intercepted = len(report.Reasons) > 0
+	return report
+}
+
+// egressAddress returns the address in the TXT answer of EgressProbeDomain
+func egressAddress(response *dns.Msg) string {
+	if response.Rcode != dns.RcodeSuccess {
+		return ""
+	}
+	for _, answer := range response.Answer {
+		if txt, ok := answer.(*dns.TXT); ok && len(txt.Txt) > 0 && net.ParseIP(txt.Txt[0]) != nil {
+			return txt.Txt[0]
+		}
+	}
+	return ""
+}
+
+// commonEgress reports whether at least two resolvers answered and all of
+// them with the same egress address
+func commonEgress(egress map[string]string) (string, bool) {
+	if len(egress) < 2 {
+		return "", false
+	}
+	var first string
+	for _, address := range egress {
+		if first == "" {
+			first = address
+		} else if address != first {
+			return "", false
+		}
+	}
+	return first, true
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestCommonEgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		egress map[string]string
+		want   string
+		wantOK bool
+	}{
+		{"none", nil, "", false},
+		{"one resolver", map[string]string{"1.1.1.1": "203.0.113.1"}, "", false},
+		{"same egress", map[string]string{"1.1.1.1": "203.0.113.1", "8.8.8.8": "203.0.113.1"}, "203.0.113.1", true},
+		{"three same", map[string]string{"1.1.1.1": "203.0.113.1", "8.8.8.8": "203.0.113.1", "9.9.9.9": "203.0.113.1"}, "203.0.113.1", true},
+		{"different egress", map[string]string{"1.1.1.1": "203.0.113.1", "8.8.8.8": "198.51.100.7"}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := commonEgress(tt.egress)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: commonEgress = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import "context"
+
+// SkippedError is the error of a query aborted because the run was
+// interrupted, as opposed to one that timed out on its own
+const SkippedError = "Skipped: run interrupted"
+
+// context returns the run context: canceled when the run is interrupted, so
+// that in-flight queries stop and are recorded as skipped
+func (opts TestOptions) context() context.Context {
+	if opts.runCtx != nil {
+		return opts.runCtx
+	}
+	return context.Background()
+}
+
+// interrupted reports whether the run was interrupted
+func (opts TestOptions) interrupted() bool {
+	return opts.runCtx != nil && opts.runCtx.Err() != nil
+}
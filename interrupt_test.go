@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestSkippedExcludedFromRates(t *testing.T) {
+	results := []TestResult{
+		{Domain: "a.com", Category: CategoryGeneral, Success: true},
+		{Domain: "b.com", Category: CategoryGeneral, Error: "timeout"},
+		{Domain: "c.com", Category: CategoryGeneral, Skipped: true, Error: SkippedError},
+	}
+
+	summary := calculateSummary(results)
+	if summary.SkippedTests != 1 || summary.FailedTests != 1 || summary.SuccessRate != 50 {
+		t.Errorf("summary = %d skipped, %d failed, %.2f%%, want 1, 1 and 50%%", summary.SkippedTests, summary.FailedTests, summary.SuccessRate)
+	}
+	if stats := summary.CategoryStats[CategoryGeneral]; stats.SkippedTests != 1 || stats.SuccessRate != 50 {
+		t.Errorf("category stats = %+v, want 1 skipped and 50%%", stats)
+	}
+	if stats := groupStats(results); stats.SkippedTests != 1 || stats.FailedTests != 1 || stats.SuccessRate != 50 {
+		t.Errorf("groupStats = %+v, want 1 skipped, 1 failed and 50%%", stats)
+	}
+
+	if summary := calculateSummary(results[2:]); summary.SuccessRate != 0 || summary.FailedTests != 0 {
+		t.Errorf("summary of skipped results only = %+v, want no failures and a 0%% rate", summary)
+	}
+}
+
+func TestInterruptedQueryIsSkipped(t *testing.T) {
+	// The server never answers, so the query is still in flight when the
+	// run is interrupted
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	opts := TestOptions{Timeout: time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP, runCtx: runCtx}
+	time.AfterFunc(100*time.Millisecond, cancelRun)
+
+	result := testDNS(newDNSClient(opts), nil, server, "example.com", opts)
+	if !result.Skipped || result.Error != SkippedError || result.Success {
+		t.Errorf("interrupted query = %+v, want it skipped", result)
+	}
+}
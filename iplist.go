@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net"
+	"sort"
+	"strings"
+)
+
+// DefaultMinSuccessRate is the success rate a server needs to be listed by
+// --format iplist: every test answered
+const DefaultMinSuccessRate = 100.0
+
+// formatIPList renders the addresses of the servers whose success rate is at
+// least minRate, one per line in the order of the text output. Explicit
+// ports are kept and dual-stack servers are listed with both addresses.
+func formatIPList(results TestResults, sortBy string, minRate float64) string {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results.Results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+	smoothed := results.Summary.smoothedRates()
+	sort.SliceStable(servers, func(i, j int) bool {
+		return serverBefore(byServer[servers[i]], byServer[servers[j]], sortBy, smoothed)
+	})
+
+	var output strings.Builder
+	for _, server := range servers {
+		stats := groupStats(byServer[server])
+		if stats.TotalTests == stats.SkippedTests || stats.SuccessRate < minRate {
+			continue
+		}
+		for _, ip := range []string{server.IP, server.IPv6} {
+			if ip == "" {
+				continue
+			}
+			if server.Port != "" {
+				ip = net.JoinHostPort(ip, server.Port)
+			}
+			output.WriteString(ip + "\n")
+		}
+	}
+	return output.String()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatIPList(t *testing.T) {
+	ms := time.Millisecond
+	slow := DNSServer{IP: "192.0.2.1"}
+	fast := DNSServer{IP: "192.0.2.2", Port: "5353"}
+	dual := DNSServer{IP: "192.0.2.3", IPv6: "2001:db8::3"}
+	flaky := DNSServer{IP: "192.0.2.4"}
+	skipped := DNSServer{IP: "192.0.2.5"}
+	results := TestResults{Results: []TestResult{
+		{Server: slow, Success: true, ResponseTime: 90 * ms},
+		{Server: slow, Success: true, ResponseTime: 90 * ms},
+		{Server: fast, Success: true, ResponseTime: 10 * ms},
+		{Server: fast, Success: true, ResponseTime: 10 * ms},
+		{Server: dual, Success: true, ResponseTime: 50 * ms},
+		{Server: dual, Success: true, ResponseTime: 50 * ms},
+		{Server: flaky, Success: true, ResponseTime: 20 * ms},
+		{Server: flaky, Error: "timeout"},
+		{Server: skipped, Skipped: true},
+	}}
+
+	tests := []struct {
+		sortBy  string
+		minRate float64
+		want    string
+	}{
+		{SortByIP, DefaultMinSuccessRate, "192.0.2.1\n192.0.2.2:5353\n192.0.2.3\n2001:db8::3\n"},
+		{SortByLatency, DefaultMinSuccessRate, "192.0.2.2:5353\n192.0.2.3\n2001:db8::3\n192.0.2.1\n"},
+		{SortByLatency, 50, "192.0.2.2:5353\n192.0.2.4\n192.0.2.3\n2001:db8::3\n192.0.2.1\n"},
+	}
+	for _, tt := range tests {
+		if got := formatIPList(results, tt.sortBy, tt.minRate); got != tt.want {
+			t.Errorf("formatIPList(%s, %v) = %q, want %q", tt.sortBy, tt.minRate, got, tt.want)
+		}
+	}
+}
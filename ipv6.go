@@ -0,0 +1,71 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/miekg/dns"
+)
+
+// ServerIPv6 represents how many of the names a server resolved came with
+// AAAA records, set with --ipv6
+type ServerIPv6 struct {
+	Server       DNSServer `json:"server"`
+	Resolved     int       `json:"resolved"`      // Names resolved to an A or AAAA record
+	IPv6Answers  int       `json:"ipv6_answers"`  // Of those, names with an AAAA record
+	IPv6Coverage float64   `json:"ipv6_coverage"` // Percentage of the resolved names with an AAAA record
+}
+
+// testBothFamilies queries domain as A and then as AAAA. The result is the
+// A one, with the AAAA address in ResolvedIPv6; a name with only an AAAA
+// record still succeeds, with the response time of the AAAA query.
+func testBothFamilies(client *dns.Client, counter *queryCounter, server DNSServer, domain string, opts TestOptions) TestResult {
+	opts.IPv6 = false
+	result := testDNS(client, counter, server, domain, opts)
+	if result.Skipped {
+		return result
+	}
+
+	opts.QueryType = dns.TypeAAAA
+	aaaa := testDNS(client, counter, server, domain, opts)
+	if !aaaa.Success {
+		return result
+	}
+
+	result.ResolvedIPv6 = aaaa.IP
+	result.ResolvedIPs = append(result.ResolvedIPs, aaaa.ResolvedIPs...)
+	if !result.Success {
+		result.Success = true
+		result.Error = ""
+		result.Blocked = aaaa.Blocked
+		result.ResponseTime = aaaa.ResponseTime
+		result.TTL = aaaa.TTL
+	}
+	return result
+}
+
+// ipv6Coverage counts per server the resolved names that had AAAA records
+func ipv6Coverage(results []TestResult) []ServerIPv6 {
+	index := make(map[DNSServer]int)
+	var coverage []ServerIPv6
+	for _, result := range results {
+		if !result.Success {
+			continue
+		}
+		i, ok := index[result.Server]
+		if !ok {
+			i = len(coverage)
+			index[result.Server] = i
+			coverage = append(coverage, ServerIPv6{Server: result.Server})
+		}
+		coverage[i].Resolved++
+		if result.ResolvedIPv6 != "" {
+			coverage[i].IPv6Answers++
+		}
+	}
+
+	for i := range coverage {
+		coverage[i].IPv6Coverage = float64(coverage[i].IPv6Answers) / float64(coverage[i].Resolved) * 100
+	}
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Server.label() < coverage[j].Server.label() })
+	return coverage
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestTestDNSIPv6(t *testing.T) {
+	// both.example has A and AAAA records, v4.example only A and
+	// v6.example only AAAA
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		q := r.Question[0]
+		hdr := dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: dns.ClassINET, Ttl: 60}
+		switch {
+		case q.Qtype == dns.TypeA && q.Name != "v6.example.":
+			m.Answer = append(m.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("192.0.2.1")})
+		case q.Qtype == dns.TypeAAAA && q.Name != "v4.example.":
+			m.Answer = append(m.Answer, &dns.AAAA{Hdr: hdr, AAAA: net.ParseIP("2001:db8::1")})
+		}
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	tests := []struct {
+		domain   string
+		wantIP   string
+		wantIPv6 string
+	}{
+		{"both.example", "192.0.2.1", "2001:db8::1"},
+		{"v4.example", "192.0.2.1", ""},
+		{"v6.example", "", "2001:db8::1"},
+	}
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP, IPv6: true}
+	var results []TestResult
+	for _, tt := range tests {
+		result := testDNS(newDNSClient(opts), nil, server, tt.domain, opts)
+		if !result.Success || result.IP != tt.wantIP || result.ResolvedIPv6 != tt.wantIPv6 {
+			t.Errorf("%s: success %v, %q and %q, want %q and %q", tt.domain, result.Success, result.IP, result.ResolvedIPv6, tt.wantIP, tt.wantIPv6)
+		}
+		results = append(results, result)
+	}
+
+	coverage := ipv6Coverage(results)
+	if len(coverage) != 1 || coverage[0].Resolved != 3 || coverage[0].IPv6Answers != 2 {
+		t.Errorf("ipv6Coverage = %+v, want 2 of 3 names with AAAA records", coverage)
+	}
+}
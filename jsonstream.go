@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// writeJSON writes results as the indented JSON object json.MarshalIndent
+// produces, but encodes the results array one element at a time so memory
+// doesn't grow with the number of results
+func writeJSON(w io.Writer, results TestResults) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+
+	// field writes one top-level member, indented as a member of the object
+	field := func(name string, value interface{}, last bool) error {
+		buf.Reset()
+		encoder.SetIndent("  ", "  ")
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		separator := ",\n"
+		if last {
+			separator = "\n"
+		}
+		_, err := io.WriteString(w, "  \""+name+"\": "+string(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))+separator)
+		return err
+	}
+
+	if _, err := io.WriteString(w, "{\n"); err != nil {
+		return err
+	}
+	if err := field("run_id", results.RunID, false); err != nil {
+		return err
+	}
+	if err := field("timestamp", results.Timestamp, false); err != nil {
+		return err
+	}
+
+	switch {
+	case results.Results == nil:
+		if _, err := io.WriteString(w, "  \"results\": null,\n"); err != nil {
+			return err
+		}
+	case len(results.Results) == 0:
+		if _, err := io.WriteString(w, "  \"results\": [],\n"); err != nil {
+			return err
+		}
+	default:
+		if _, err := io.WriteString(w, "  \"results\": ["); err != nil {
+			return err
+		}
+		encoder.SetIndent("    ", "  ")
+		for i, result := range results.Results {
+			buf.Reset()
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+			separator := ",\n    "
+			if i == 0 {
+				separator = "\n    "
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			if _, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "\n  ],\n"); err != nil {
+			return err
+		}
+	}
+
+	if len(results.Servers) > 0 {
+		if err := field("servers", results.Servers, false); err != nil {
+			return err
+		}
+	}
+	if err := field("summary", results.Summary, true); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "}")
+	return err
+}
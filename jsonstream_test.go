@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWriteJSON(t *testing.T) {
+	server := DNSServer{IP: "192.0.2.1", Description: "<Example & Co>"}
+	full := TestResults{
+		RunID:     "run-1",
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Results: []TestResult{
+			{Server: server, Domain: "example.com", Category: CategoryGeneral, Success: true, IP: "192.0.2.53", ResponseTime: time.Millisecond},
+			{Server: server, Domain: "down.example", Category: CategoryGeneral, Error: "i/o timeout"},
+		},
+		Servers: []ServerProfile{{Server: server}},
+	}
+	full.Summary = calculateSummary(full.Results)
+
+	tests := []struct {
+		name    string
+		results TestResults
+	}{
+		{"nil results", TestResults{RunID: "run-0"}},
+		{"empty results", TestResults{Results: []TestResult{}}},
+		{"results and servers", full},
+	}
+	for _, tt := range tests {
+		want, err := json.MarshalIndent(tt.results, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got bytes.Buffer
+		if err := writeJSON(&got, tt.results); err != nil {
+			t.Fatalf("%s: writeJSON error = %v", tt.name, err)
+		}
+		if !bytes.Equal(got.Bytes(), want) {
+			t.Errorf("%s: writeJSON wrote\n%s\nwant the json.MarshalIndent output\n%s", tt.name, got.Bytes(), want)
+		}
+	}
+}
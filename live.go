@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// liveRanking redraws the servers ranked by response time as their answers
+// arrive, for --live. On a terminal the list is redrawn in place; otherwise
+// it is only printed once, when the run completes.
+type liveRanking struct {
+	out      io.Writer
+	domain   string
+	total    int
+	redraw   bool
+	mu       sync.Mutex
+	results  []TestResult
+	lines    int // Lines of the last drawing, erased before the next one
+	rendered int // Results in the last drawing, to skip unchanged redraws
+}
+
+func newLiveRanking(out io.Writer, domain string, total int) *liveRanking {
+	return &liveRanking{
+		out:    out,
+		domain: domain,
+		total:  total,
+		redraw: out == io.Writer(os.Stderr) && isTerminal(os.Stderr),
+	}
+}
+
+// isTerminal reports whether f is a character device, such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (l *liveRanking) add(result TestResult) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.results = append(l.results, result)
+}
+
+// run redraws the ranking every ProgressUpdateRate until done. The final
+// drawing is left to the caller, once all results are in.
+func (l *liveRanking) run(done chan bool) {
+	ticker := time.NewTicker(ProgressUpdateRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if l.redraw {
+				l.draw(false)
+			}
+		}
+	}
+}
+
+// draw prints the ranking: answering servers fastest first, then the
+// failing ones
+func (l *liveRanking) draw(final bool) {
+	l.mu.Lock()
+	ranked := append([]TestResult(nil), l.results...)
+	l.mu.Unlock()
+	if !final && len(ranked) == l.rendered && l.lines > 0 {
+		return
+	}
+	l.rendered = len(ranked)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Success != ranked[j].Success {
+			return ranked[i].Success
+		}
+		if ranked[i].Success {
+			return ranked[i].ResponseTime < ranked[j].ResponseTime
+		}
+		return ranked[i].Server.label() < ranked[j].Server.label()
+	})
+
+	var drawing strings.Builder
+	if l.redraw && l.lines > 0 {
+		// Move back to the first line of the previous drawing and erase it
+		drawing.WriteString(fmt.Sprintf("\033[%dA\033[J", l.lines))
+	}
+	drawing.WriteString(fmt.Sprintf("%s: %d/%d servers answered\n", l.domain, len(ranked), l.total))
+	rank := 0
+	for _, result := range ranked {
+		if result.Success {
+			rank++
+			drawing.WriteString(fmt.Sprintf("  %3d. %-39s %10v  %s\n", rank, result.Server.label(), result.ResponseTime.Round(time.Microsecond), result.IP))
+		} else {
+			drawing.WriteString(fmt.Sprintf("    -  %-39s %10s  %s\n", result.Server.label(), "FAIL", result.Error))
+		}
+	}
+	l.lines = len(ranked) + 1
+
+	io.WriteString(l.out, drawing.String())
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLiveRankingDraw(t *testing.T) {
+	var out strings.Builder
+	ranking := newLiveRanking(&out, "example.com", 4)
+	if ranking.redraw {
+		t.Fatal("ranking on a non-terminal writer redraws in place")
+	}
+
+	ranking.add(TestResult{Server: DNSServer{IP: "192.0.2.3"}, Success: true, ResponseTime: 30 * time.Millisecond, IP: "198.51.100.1"})
+	ranking.add(TestResult{Server: DNSServer{IP: "192.0.2.9"}, Error: "timeout"})
+	ranking.add(TestResult{Server: DNSServer{IP: "192.0.2.1"}, Success: true, ResponseTime: 10 * time.Millisecond, IP: "198.51.100.1"})
+	ranking.draw(true)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 4 || lines[0] != "example.com: 3/4 servers answered" {
+		t.Fatalf("ranking = %q, want a header and 3 servers", out.String())
+	}
+	for i, want := range []string{"1. 192.0.2.1", "2. 192.0.2.3", "-  192.0.2.9"} {
+		if !strings.Contains(lines[i+1], want) {
+			t.Errorf("line %d = %q, want it to hold %q", i+1, lines[i+1], want)
+		}
+	}
+	if strings.Contains(out.String(), "\033[") {
+		t.Error("ranking on a non-terminal writer holds escape sequences")
+	}
+}
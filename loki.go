@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// LokiJob is the job label attached to every stream pushed to Loki
+const LokiJob = "dns-check-go"
+
+// lokiPush is the body expected by Loki's /loki/api/v1/push endpoint
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"` // [timestamp in ns, log line]
+}
+
+// formatLoki renders the results as a Loki push request, one log line per
+// result, grouped into streams labeled by server, category and success
+func formatLoki(results TestResults) ([]byte, error) {
+	timestamp := strconv.FormatInt(results.Timestamp.UnixNano(), 10)
+
+	streams := make(map[string]*lokiStream)
+	var keys []string
+
+	for _, result := range results.Results {
+		labels := map[string]string{
+			"job":      LokiJob,
+			"server":   result.Server.IP,
+			"category": result.Category,
+			"success":  strconv.FormatBool(result.Success),
+		}
+		key := labels["server"] + "|" + labels["category"] + "|" + labels["success"]
+
+		stream, exists := streams[key]
+		if !exists {
+			stream = &lokiStream{Stream: labels}
+			streams[key] = stream
+			keys = append(keys, key)
+		}
+
+		line, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		stream.Values = append(stream.Values, [2]string{timestamp, string(line)})
+	}
+
+	sort.Strings(keys)
+
+	push := lokiPush{Streams: []lokiStream{}}
+	for _, key := range keys {
+		push.Streams = append(push.Streams, *streams[key])
+	}
+
+	return json.Marshal(push)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFormatLoki(t *testing.T) {
+	a := DNSServer{IP: "192.0.2.1"}
+	b := DNSServer{IP: "192.0.2.2"}
+	results := TestResults{
+		Timestamp: time.Unix(1700000000, 0),
+		Results: []TestResult{
+			{Server: b, Domain: "one.com", Category: CategoryGeneral, Success: true},
+			{Server: a, Domain: "one.com", Category: CategoryGeneral, Success: true},
+			{Server: a, Domain: "two.com", Category: CategoryGeneral, Success: true},
+			{Server: a, Domain: "three.com", Category: CategoryGeneral, Error: "timeout"},
+		},
+	}
+
+	data, err := formatLoki(results)
+	if err != nil {
+		t.Fatalf("formatLoki error = %v", err)
+	}
+	var push lokiPush
+	if err := json.Unmarshal(data, &push); err != nil {
+		t.Fatalf("formatLoki output isn't JSON: %v", err)
+	}
+
+	want := []struct {
+		server  string
+		success string
+		lines   int
+	}{
+		{"192.0.2.1", "false", 1},
+		{"192.0.2.1", "true", 2},
+		{"192.0.2.2", "true", 1},
+	}
+	if len(push.Streams) != len(want) {
+		t.Fatalf("got %d streams, want %d", len(push.Streams), len(want))
+	}
+	for i, w := range want {
+		stream := push.Streams[i]
+		if stream.Stream["job"] != LokiJob || stream.Stream["server"] != w.server || stream.Stream["success"] != w.success {
+			t.Errorf("stream %d labels = %v, want server %s and success %s", i, stream.Stream, w.server, w.success)
+		}
+		if len(stream.Values) != w.lines {
+			t.Errorf("stream %d holds %d lines, want %d", i, len(stream.Values), w.lines)
+		}
+		for _, value := range stream.Values {
+			if value[0] != "1700000000000000000" {
+				t.Errorf("stream %d timestamp = %s, want the run's in nanoseconds", i, value[0])
+			}
+		}
+	}
+}
+
+func TestFormatLokiEmpty(t *testing.T) {
+	data, err := formatLoki(TestResults{})
+	if err != nil || string(data) != `{"streams":[]}` {
+		t.Errorf("formatLoki of no results = %s, %v, want an empty stream list", data, err)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// infoOutput receives the progress bar and the informational and warning
+// messages. --machine discards them so only the results reach stdout and
+// only errors reach stderr.
+var infoOutput io.Writer = os.Stderr
+
+// machineFormats are the output formats allowed in --machine mode
+var machineFormats = map[string]bool{
+	"json":   true,
+	"ndjson": true,
+}
+
+// checkMachineOutputs reports an error when an output of --machine mode
+// isn't structured
+func checkMachineOutputs(outputs []OutputOptions) error {
+	for _, opts := range outputs {
+		if !machineFormats[opts.Format] {
+			return fmt.Errorf("--machine only supports json and ndjson output, not %s", opts.Format)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCheckMachineOutputs(t *testing.T) {
+	tests := []struct {
+		formats []string
+		wantErr bool
+	}{
+		{[]string{"json"}, false},
+		{[]string{"json", "ndjson"}, false},
+		{[]string{"text"}, true},
+		{[]string{"json", "server-csv"}, true},
+	}
+	for _, tt := range tests {
+		var outputs []OutputOptions
+		for _, format := range tt.formats {
+			outputs = append(outputs, OutputOptions{Format: format})
+		}
+		if err := checkMachineOutputs(outputs); (err != nil) != tt.wantErr {
+			t.Errorf("checkMachineOutputs(%v) error = %v, want error %v", tt.formats, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInfoOutput(t *testing.T) {
+	var buf bytes.Buffer
+	saved := infoOutput
+	infoOutput = &buf
+	defer func() { infoOutput = saved }()
+
+	// Warnings about skipped entries go to infoOutput, which --machine discards
+	servers, err := parseDNSServers(strings.NewReader("1.1.1.1\nnot_an_ip\n"), "servers.txt", false)
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("parseDNSServers = %v, %v, want 1.1.1.1", servers, err)
+	}
+	if !strings.Contains(buf.String(), "Warning: invalid IP address 'not_an_ip'") {
+		t.Errorf("infoOutput = %q, want the skipped entry's warning", buf.String())
+	}
+}
@@ -2,15 +2,25 @@ package main
 
 import (
 	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
 	"net"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/miekg/dns"
@@ -37,31 +47,178 @@ var (
 // DNSServer represents a DNS server
 type DNSServer struct {
 	IP          string `json:"ip"`
+	IPv6        string `json:"ipv6,omitempty"`     // Second address of a dual-stack server
+	Hostname    string `json:"hostname,omitempty"` // Hostname the list named the server by, resolved to IP
+	Port        string `json:"port,omitempty"`     // Explicit port, otherwise the protocol's default
 	Description string `json:"description,omitempty"`
+	DoHURL      string `json:"doh_url,omitempty"`  // DoH endpoint dialed at the server address, from a doh= token or URL entry
+	DoHHeaders  string `json:"-"`                  // Extra DoH request headers, NAME:VALUE lines from header= tokens
+	Protocol    string `json:"protocol,omitempty"` // Transport the entry requires whatever --protocol says: https for a URL entry
 }
 
 // TestResult represents the result of a DNS test
 type TestResult struct {
-	Server       DNSServer     `json:"server"`
-	Domain       string        `json:"domain"`
-	Category     string        `json:"category"`
-	Success      bool          `json:"success"`
-	ResponseTime time.Duration `json:"response_time_ms"`
-	IP           string        `json:"resolved_ip,omitempty"`
-	Error        string        `json:"error,omitempty"`
+	Server           DNSServer         `json:"server"`
+	Domain           string            `json:"domain"`
+	Category         string            `json:"category"`
+	Success          bool              `json:"success"`
+	ResponseTime     time.Duration     `json:"response_time_ms"`
+	QueryType        string            `json:"query_type"`              // Record type queried
+	IP               string            `json:"resolved_ip,omitempty"`   // Address, or the data of the first record for other types
+	ResolvedIPv6     string            `json:"resolved_ipv6,omitempty"` // AAAA address, set with --ipv6
+	ResolvedIPs      []string          `json:"resolved_ips,omitempty"`  // Every address in the answer, AAAA ones too with --ipv6
+	Country          string            `json:"resolved_country,omitempty"`
+	ASN              uint              `json:"resolved_asn,omitempty"`
+	ASOrg            string            `json:"resolved_as_org,omitempty"`
+	ServerCountry    string            `json:"server_country,omitempty"` // Country of the server address, set with --geoip
+	SOA              *SOAInfo          `json:"soa,omitempty"`
+	TXT              string            `json:"txt,omitempty"`
+	Any              *AnyResponse      `json:"any,omitempty"`                // Set with --query-type ANY
+	TTL              *uint32           `json:"ttl,omitempty"`                // TTL of the first answer record, nil when there is none
+	NegativeTTL      uint32            `json:"negative_ttl,omitempty"`       // Negative caching TTL of NXDOMAIN and empty answers
+	Rcode            string            `json:"rcode,omitempty"`              // Set when --success-rcodes is used
+	AnswerCount      int               `json:"answer_count,omitempty"`       // Answer records of the queried type, set with --min-answers
+	Sections         *ResponseSections `json:"sections,omitempty"`           // Authority and additional records, set with --include-sections
+	Family           string            `json:"family,omitempty"`             // Address family queried, for dual-stack servers
+	Critical         bool              `json:"critical,omitempty"`           // The domain is marked critical
+	QuorumMismatch   bool              `json:"quorum_mismatch,omitempty"`    // The answer disagrees with the --quorum consensus
+	ExpectedMismatch bool              `json:"expected_mismatch,omitempty"`  // The answer is not among the --expected-zone records
+	Skipped          bool              `json:"skipped,omitempty"`            // Aborted by an interruption of the run, neither success nor failure
+	AnswerFamily     string            `json:"answer_family,omitempty"`      // Family of the resolved address, set with --prefer dual
+	RecoveredOnRetry bool              `json:"recovered_on_retry,omitempty"` // Failed in the main run, succeeded in the second pass
+	Protocol         string            `json:"protocol"`                     // Transport used for the query
+	TCPFallback      bool              `json:"tcp_fallback,omitempty"`       // The UDP answer was truncated and fetched again over TCP
+	Retries          int               `json:"retries,omitempty"`            // Attempts that failed before the recorded one, up to --retries
+	SourceIP         string            `json:"source_ip,omitempty"`          // Local address the query was bound to
+	Timeout          time.Duration     `json:"timeout_ms,omitempty"`         // Effective timeout, set with --adaptive-timeout
+	Blocked          bool              `json:"blocked,omitempty"`            // NXDOMAIN or a sinkhole address such as 0.0.0.0
+	BogusAnswer      bool              `json:"bogus_answer,omitempty"`       // Resolved to a non-routable address such as a private or reserved one
+	Uncached         bool              `json:"uncached,omitempty"`
+	NameMismatch     bool              `json:"name_mismatch,omitempty"`
+	AnswerSource     string            `json:"answer_source,omitempty"` // Inferred origin of the answer, one of the AnswerSource constants
+	ResponseName     string            `json:"response_name,omitempty"`
+
+	SampleCount      int             `json:"sample_count,omitempty"`
+	SampleSuccesses  int             `json:"sample_successes,omitempty"`
+	SamplesRequested int             `json:"samples_requested,omitempty"` // Set when --deadline cut the sample count
+	Samples          []time.Duration `json:"samples_ms,omitempty"`
+	ColdResponseTime time.Duration   `json:"cold_response_time_ms,omitempty"` // First query of the pair, set with --cold-warm
+	WarmResponseTime time.Duration   `json:"warm_response_time_ms,omitempty"` // Average of the later successful queries
+	ColdWarmDelta    time.Duration   `json:"cold_warm_delta_ms,omitempty"`
+
+	Error string `json:"error,omitempty"`
 }
 
 // TestResults represents all test results
 type TestResults struct {
-	Timestamp time.Time    `json:"timestamp"`
-	Results   []TestResult `json:"results"`
-	Summary   Summary      `json:"summary"`
+	RunID     string          `json:"run_id"`
+	Timestamp time.Time       `json:"timestamp"`
+	Results   []TestResult    `json:"results"`
+	Servers   []ServerProfile `json:"servers,omitempty"`
+	Summary   Summary         `json:"summary"`
+
+	spill *resultSpill // Full results on disk with --spill-dir; Results are then compacted
+}
+
+// eachResult calls fn for every result in order, reading them back from disk
+// when the run spilled them
+func (r TestResults) eachResult(fn func(TestResult) error) error {
+	if r.spill != nil {
+		return r.spill.each(fn)
+	}
+	for _, result := range r.Results {
+		if err := fn(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dispatch strategies for --parallel-over
+const (
+	ParallelOverAll     = "all"     // Every server/domain pair is an independent job
+	ParallelOverServers = "servers" // One worker per server, domains queried in order
+	ParallelOverDomains = "domains" // One worker per domain, servers queried in order
+)
+
+// TestOptions controls how the DNS test matrix is executed
+type TestOptions struct {
+	Timeout          time.Duration            // Per-query timeout
+	Retries          int                      // Retries of a query failing with a timeout or network error
+	Workers          int                      // Number of concurrent workers
+	ParallelOver     string                   // Dispatch strategy, one of the ParallelOver constants
+	QueryType        uint16                   // Record type queried for every domain, the first of QueryTypes
+	QueryTypes       []uint16                 // Record types queried for every domain, one job each
+	QPS              int                      // Global queries per second limit, 0 for unlimited
+	MaxPerServer     int                      // Concurrent queries per server, 0 for unlimited
+	MaxConcurrency   int                      // Cap on workers and open sockets, pooled connections included, 0 for unlimited
+	Jitter           time.Duration            // Upper bound of the random delay before each query
+	SamplePercent    float64                  // Percentage of server/domain pairs to test, 0 for all
+	SampleSeed       int64                    // Seed for the pair sampling
+	Samples          int                      // Number of queries per server/domain pair
+	EmitSamples      bool                     // Record the raw latency of every sample
+	ColdWarm         bool                     // Report the first sample apart from the later, cached ones
+	Deadline         time.Duration            // Time budget for the samples, 0 for none
+	PercentileMethod string                   // Interpolation used for the summary percentiles
+	ComparePrefix    int                      // Prefix length resolved IPs are matched at, 0 for exact (--compare-granularity)
+	Checkpoint       string                   // File persisting completed pairs so an interrupted run can resume
+	LatencySLA       time.Duration            // Per-server latency threshold, 0 for none
+	SecondPass       bool                     // Retry the failed pairs once after the run
+	SLAMetric        string                   // Latency compared against LatencySLA: p95 or avg
+	SuccessRcodes    map[int]bool             // RCODEs counted as success, nil for NOERROR with an answer
+	NoRecurse        bool                     // Clear the RD bit to only get cached/authoritative answers
+	SourceIP         net.IP                   // Local address queries are sent from, nil for the OS default
+	Protocol         string                   // Transport of the test queries, one of the Protocol constants
+	pipelines        *pipelinePool            // Shared TCP/TLS connections; queries dial their own when nil
+	sockets          *connLimit               // The one --max-concurrency limit shared by every socket of the run
+	doh              *dohPool                 // Kept-alive HTTPS clients for ProtocolHTTPS
+	ConnectionStats  bool                     // Report connection reuse of the TCP/TLS pipelines
+	InsecureTLS      bool                     // Skip certificate verification for TLS, QUIC and HTTPS
+	Live             bool                     // Show a live ranking of the servers instead of the progress bar
+	MinAnswers       int                      // Answer records of the queried type a successful response needs
+	IncludeSections  bool                     // Record the authority and additional sections of responses
+	runCtx           context.Context          // Canceled when the run is interrupted, set by runDNSTests
+	Prefer           string                   // PreferDual falls back to AAAA for names without an A record
+	IPv6             bool                     // Also query AAAA for A queries, recording both families
+	SpillDir         string                   // Directory for spilling results to disk, empty to keep them in memory
+	AdaptiveTimeout  float64                  // Multiple of the calibrated median used as per-server timeout, 0 for off
+	ServerTimeouts   map[string]time.Duration // Calibrated timeouts by server label, set by runDNSTests
+	AuthTTLs         map[string]uint32        // Authoritative TTLs by lowercase domain, for inferring answer sources
+}
+
+// supportedQueryTypes lists the record types accepted by --query-type and --type
+var supportedQueryTypes = []uint16{
+	dns.TypeA, dns.TypeAAAA, dns.TypeMX, dns.TypeTXT, dns.TypeNS,
+	dns.TypeCNAME, dns.TypeSOA, dns.TypePTR, dns.TypeANY,
+}
+
+// OutputOptions controls how results are rendered and written
+type OutputOptions struct {
+	File     string             // Output file, stdout when empty
+	Format   string             // Output format
+	Compress bool               // Gzip-compress the output file
+	Append   bool               // Append the run to a JSON array in the output file
+	Layout   string             // JSON layout: flat or nested
+	Template *template.Template // User template replacing the format, if set
+	SortBy   string             // Server ordering in the text and nested JSON output
+	Summary  string             // Summary position in the text output, one of the Summary constants
+	Color    bool               // Use ANSI colors in the text and scoreboard output
+	GroupBy  string             // Grouping of the servers in the text output, one of the GroupBy constants
+
+	MinSuccessRate float64 // Success rate a server needs to be listed by the iplist format
+}
+
+// compressed reports whether the output file is written gzip-compressed,
+// either explicitly or because its name ends in ".gz"
+func (o OutputOptions) compressed() bool {
+	return o.File != "" && (o.Compress || strings.HasSuffix(o.File, ".gz"))
 }
 
 // DomainCategory represents a domain with its category
 type DomainCategory struct {
 	Domain   string
 	Category string
+	Critical bool // Marked critical=true in the domain list
 }
 
 // CategoryStats represents statistics for a category
@@ -69,687 +226,828 @@ type CategoryStats struct {
 	TotalTests      int     `json:"total_tests"`
 	SuccessfulTests int     `json:"successful_tests"`
 	FailedTests     int     `json:"failed_tests"`
+	UncachedTests   int     `json:"uncached_tests,omitempty"`
+	SkippedTests    int     `json:"skipped_tests,omitempty"`
 	SuccessRate     float64 `json:"success_rate"`
 }
 
 // Summary represents test summary
 type Summary struct {
-	TotalTests          int                      `json:"total_tests"`
-	SuccessfulTests     int                      `json:"successful_tests"`
-	FailedTests         int                      `json:"failed_tests"`
-	SuccessRate         float64                  `json:"success_rate"`
-	AverageResponseTime time.Duration            `json:"average_response_time_ms"`
-	CategoryStats       map[string]CategoryStats `json:"category_stats"`
+	TotalTests           int                      `json:"total_tests"`
+	SuccessfulTests      int                      `json:"successful_tests"`
+	FailedTests          int                      `json:"failed_tests"`
+	UncachedTests        int                      `json:"uncached_tests,omitempty"`
+	SkippedTests         int                      `json:"skipped_tests,omitempty"` // Aborted by an interruption, excluded from the success rates
+	Interrupted          bool                     `json:"interrupted,omitempty"`   // The run was stopped with Ctrl-C before all pairs were tested
+	NameMismatches       int                      `json:"name_mismatches,omitempty"`
+	AnswerSources        map[string]int           `json:"answer_sources,omitempty"` // Results by inferred answer source
+	SuccessRate          float64                  `json:"success_rate"`
+	AverageResponseTime  time.Duration            `json:"average_response_time_ms"`
+	Percentiles          *Percentiles             `json:"percentiles,omitempty"`
+	SLA                  *SLAReport               `json:"sla,omitempty"`
+	TotalQueries         int64                    `json:"total_queries"`        // Test queries sent, including samples
+	TotalBytesReceived   int64                    `json:"total_bytes_received"` // Wire size of all responses
+	SecondPassRetries    int                      `json:"second_pass_retries,omitempty"`
+	RecoveredFailures    int                      `json:"recovered_failures,omitempty"` // Failures that succeeded in the second pass
+	RetriedTests         int                      `json:"retried_tests,omitempty"`      // Results that needed --retries
+	RecoveredByRetry     int                      `json:"recovered_by_retry,omitempty"` // Retried results that succeeded
+	FamilyStats          map[string]CategoryStats `json:"family_stats,omitempty"`       // Per address family, for dual-stack servers
+	ProtocolStats        map[string]ProtocolStats `json:"protocol_stats"`
+	TypeStats            map[string]CategoryStats `json:"type_stats,omitempty"` // Per record type, when several were queried
+	CategoryStats        map[string]CategoryStats `json:"category_stats"`
+	SerialMismatches     []SerialMismatch         `json:"serial_mismatches,omitempty"`
+	TXTMismatches        []TXTMismatch            `json:"txt_mismatches,omitempty"`
+	Sampled              bool                     `json:"sampled,omitempty"`
+	SamplePercent        float64                  `json:"sample_percent,omitempty"`
+	SampleSeed           int64                    `json:"sample_seed,omitempty"`
+	MatrixSize           int                      `json:"matrix_size,omitempty"`
+	ReducedSamples       int                      `json:"reduced_samples,omitempty"`
+	ColdWarm             []ServerColdWarm         `json:"cold_warm,omitempty"`
+	CategoryWinners      []CategoryWinner         `json:"category_winners,omitempty"`
+	Confidence           []ServerConfidence       `json:"confidence,omitempty"`
+	PairConsistency      []ServerPair             `json:"pair_consistency,omitempty"` // Primary/secondary pairs, matched by description
+	AnyBehavior          []ServerAnyBehavior      `json:"any_behavior,omitempty"`
+	Critical             *CriticalReport          `json:"critical,omitempty"` // Domains marked critical=true
+	Quorum               *QuorumReport            `json:"quorum,omitempty"`
+	ExpectedZone         *ExpectedZoneReport      `json:"expected_zone,omitempty"`
+	ConnectionReuse      []ConnectionReuse        `json:"connection_reuse,omitempty"`   // Set with --connection-stats
+	BogusServers         []BogusServer            `json:"bogus_servers,omitempty"`      // Servers resolving public domains to non-routable addresses
+	TruncatingServers    []TruncatingServer       `json:"truncating_servers,omitempty"` // Servers whose UDP answers needed a TCP fallback
+	TransportDelta       []TransportDelta         `json:"transport_delta,omitempty"`    // Set with --transport-delta
+	Interception         *InterceptionReport      `json:"interception,omitempty"`       // Set with --check-interception
+	IPDistribution       []DomainIPDistribution   `json:"ip_distribution,omitempty"`    // Set with --ip-distribution
+	IPv6                 []ServerIPv6             `json:"ipv6,omitempty"`               // AAAA coverage per server, set with --ipv6
+	AdaptiveTimeouts     []ServerTimeout          `json:"adaptive_timeouts,omitempty"`
+	Cycle                int                      `json:"cycle,omitempty"` // Monitoring cycle number, set with --interval
+	FlakyServers         []FlakyServer            `json:"flaky_servers,omitempty"`
+	Alerts               []ServerAlert            `json:"alerts,omitempty"`      // Firing alerts, set with --alert-below
+	Reliability          []ServerReliability      `json:"reliability,omitempty"` // Smoothed success rates, set with --ema-alpha
+	Countries            []CountryGroup           `json:"countries,omitempty"`   // Servers grouped by country, set with --group-by country
+	Regression           *RegressionReport        `json:"regression,omitempty"`  // Comparison with --baseline
+	Ports                []ServerPorts            `json:"ports,omitempty"`       // Answering ports of servers listed with ports=
+	Filter               *ServerFilterReport      `json:"filter,omitempty"`      // Servers that passed --filter-servers
+	NonRecursiveServers  int                      `json:"non_recursive_servers,omitempty"`
+	WildcardResponders   int                      `json:"wildcard_responders,omitempty"`
+	HighLossServers      int                      `json:"high_loss_servers,omitempty"`
+	RateLimitedServers   int                      `json:"rate_limited_servers,omitempty"`
+	TTLRaisingServers    int                      `json:"ttl_raising_servers,omitempty"`    // Servers enforcing a minimum TTL
+	CookieServers        int                      `json:"cookie_servers,omitempty"`         // Servers supporting DNS cookies
+	CookieCheckedServers int                      `json:"cookie_checked_servers,omitempty"` // Servers answering the cookie probe
+	QNAMEMinServers      int                      `json:"qname_min_servers,omitempty"`      // Servers minimizing query names
+	QNAMEMinChecked      int                      `json:"qname_min_checked,omitempty"`      // Servers the QNAME minimization probe gave a verdict for
 }
 
 // Default test domains with categories
 var defaultDomains = []DomainCategory{
 	// General websites
-	{"google.com", CategoryGeneral},
-	{"youtube.com", CategoryGeneral},
-	{"facebook.com", CategoryGeneral},
-	{"instagram.com", CategoryGeneral},
-	{"twitter.com", CategoryGeneral},
-	{"x.com", CategoryGeneral},
-	{"discord.com", CategoryGeneral},
-	{"github.com", CategoryGeneral},
-	{"stackoverflow.com", CategoryGeneral},
-	{"reddit.com", CategoryGeneral},
-	{"netflix.com", CategoryGeneral},
-	{"amazon.com", CategoryGeneral},
-	{"microsoft.com", CategoryGeneral},
-	{"apple.com", CategoryGeneral},
-	{"cloudflare.com", CategoryGeneral},
-	{"wikipedia.org", CategoryGeneral},
-	{"yandex.com", CategoryGeneral},
-	{"baidu.com", CategoryGeneral},
+	{Domain: "google.com", Category: CategoryGeneral},
+	{Domain: "youtube.com", Category: CategoryGeneral},
+	{Domain: "facebook.com", Category: CategoryGeneral},
+	{Domain: "instagram.com", Category: CategoryGeneral},
+	{Domain: "twitter.com", Category: CategoryGeneral},
+	{Domain: "x.com", Category: CategoryGeneral},
+	{Domain: "discord.com", Category: CategoryGeneral},
+	{Domain: "github.com", Category: CategoryGeneral},
+	{Domain: "stackoverflow.com", Category: CategoryGeneral},
+	{Domain: "reddit.com", Category: CategoryGeneral},
+	{Domain: "netflix.com", Category: CategoryGeneral},
+	{Domain: "amazon.com", Category: CategoryGeneral},
+	{Domain: "microsoft.com", Category: CategoryGeneral},
+	{Domain: "apple.com", Category: CategoryGeneral},
+	{Domain: "cloudflare.com", Category: CategoryGeneral},
+	{Domain: "wikipedia.org", Category: CategoryGeneral},
+	{Domain: "yandex.com", Category: CategoryGeneral},
+	{Domain: "baidu.com", Category: CategoryGeneral},
 
 	// Other services
-	{"pastebin.com", CategoryOther},
-	{"roblox.com", CategoryOther},
+	{Domain: "pastebin.com", Category: CategoryOther},
+	{Domain: "roblox.com", Category: CategoryOther},
 
 	// Adult content
-	{"pornhub.com", CategoryAdult},
-	{"xvideos.com", CategoryAdult},
+	{Domain: "pornhub.com", Category: CategoryAdult},
+	{Domain: "xvideos.com", Category: CategoryAdult},
 
 	// Advertisement and tracking servers
-	{"googleadservices.com", CategoryAdServer},
-	{"googlesyndication.com", CategoryAdServer},
-	{"googletagmanager.com", CategoryAdServer},
-	{"doubleclick.net", CategoryAdServer},
-	{"google-analytics.com", CategoryAdServer},
-	{"adsystem.amazon.com", CategoryAdServer},
-	{"amazon-adsystem.com", CategoryAdServer},
-	{"connect.facebook.net", CategoryAdServer},
-	{"ads.linkedin.com", CategoryAdServer},
-	{"analytics.twitter.com", CategoryAdServer},
-	{"ads.twitter.com", CategoryAdServer},
-	{"ads.yahoo.com", CategoryAdServer},
-	{"advertising.com", CategoryAdServer},
-	{"adsystem.microsoft.com", CategoryAdServer},
-	{"bat.bing.com", CategoryAdServer},
+	{Domain: "googleadservices.com", Category: CategoryAdServer},
+	{Domain: "googlesyndication.com", Category: CategoryAdServer},
+	{Domain: "googletagmanager.com", Category: CategoryAdServer},
+	{Domain: "doubleclick.net", Category: CategoryAdServer},
+	{Domain: "google-analytics.com", Category: CategoryAdServer},
+	{Domain: "adsystem.amazon.com", Category: CategoryAdServer},
+	{Domain: "amazon-adsystem.com", Category: CategoryAdServer},
+	{Domain: "connect.facebook.net", Category: CategoryAdServer},
+	{Domain: "ads.linkedin.com", Category: CategoryAdServer},
+	{Domain: "analytics.twitter.com", Category: CategoryAdServer},
+	{Domain: "ads.twitter.com", Category: CategoryAdServer},
+	{Domain: "ads.yahoo.com", Category: CategoryAdServer},
+	{Domain: "advertising.com", Category: CategoryAdServer},
+	{Domain: "adsystem.microsoft.com", Category: CategoryAdServer},
+	{Domain: "bat.bing.com", Category: CategoryAdServer},
 }
 
 // Default DNS servers
 /*var defaultDNSServers = []DNSServer{
-	{"8.8.8.8", "Google DNS"},
-	{"8.8.4.4", "Google DNS Secondary"},
-	{"1.1.1.1", "Cloudflare DNS"},
-	{"1.0.0.1", "Cloudflare DNS Secondary"},
-	{"208.67.222.222", "OpenDNS"},
-	{"208.67.220.220", "OpenDNS Secondary"},
-	{"9.9.9.9", "Quad9 DNS"},
-	{"149.112.112.112", "Quad9 DNS Secondary"},
+	{IP: "8.8.8.8", Description: "Google DNS"},
+	{IP: "8.8.4.4", Description: "Google DNS Secondary"},
+	{IP: "1.1.1.1", Description: "Cloudflare DNS"},
+	{IP: "1.0.0.1", Description: "Cloudflare DNS Secondary"},
+	{IP: "208.67.222.222", Description: "OpenDNS"},
+	{IP: "208.67.220.220", Description: "OpenDNS Secondary"},
+	{IP: "9.9.9.9", Description: "Quad9 DNS"},
+	{IP: "149.112.112.112", Description: "Quad9 DNS Secondary"},
 }*/
 
 /*
 Source: DNSJumper Application
 */
 var defaultDNSServers = []DNSServer{
-	{"212.154.100.18", "TR - Türknet"},
-	{"193.192.98.8", "TR - Türknet Secondary"},
-	{"1.1.1.1", "AU - Cloudflare"},
-	{"1.0.0.1", "AU - Cloudflare Secondary"},
-	{"45.90.28.230", "US - NextDNS"},
-	{"45.90.30.230", "US - NextDNS Secondary"},
-	{"8.8.4.4", "US - Google Public DNS"},
-	{"8.8.8.8", "US - Google Public DNS Secondary"},
-	{"92.45.23.168", "TR - deik.org.tr"},
-	{"195.244.44.45", "TR - CubeDNS - Netinternet"},
-	{"195.244.44.44", "TR - CubeDNS - Netinternet Secondary"},
-	{"9.9.9.9", "US - Quad9 Security"},
-	{"149.112.112.112", "US - Quad9 Security Secondary"},
-	{"149.112.112.10", "US - Quad9 No Security"},
-	{"9.9.9.10", "US - Quad9 No Security Secondary"},
-	{"156.154.71.1", "US - Neustar 1"},
-	{"156.154.70.1", "US - Neustar 1 Secondary"},
-	{"209.244.0.3", "US - Level 3 - A"},
-	{"209.244.0.4", "US - Level 3 - A Secondary"},
-	{"4.2.2.1", "US - Level 3 - B"},
-	{"4.2.2.2", "US - Level 3 - B Secondary"},
-	{"4.2.2.3", "US - Level 3 - C"},
-	{"4.2.2.4", "US - Level 3 - C Secondary"},
-	{"4.2.2.5", "US - Level 3 - D"},
-	{"4.2.2.6", "US - Level 3 - D Secondary"},
-	{"204.69.234.1", "US - UltraDNS"},
-	{"204.74.101.1", "US - UltraDNS Secondary"},
-	{"156.154.70.5", "US - Neustar 2"},
-	{"156.154.71.5", "US - Neustar 2 Secondary"},
-	{"199.85.126.10", "US - Norton ConnectSafe"},
-	{"199.85.127.10", "US - Norton ConnectSafe Secondary"},
-	{"198.153.192.1", "US - Norton DNS"},
-	{"198.153.194.1", "US - Norton DNS Secondary"},
-	{"64.6.65.6", "US - VeriSign Public DNS"},
-	{"64.6.64.6", "US - VeriSign Public DNS Secondary"},
-	{"156.154.71.22", "US - Comodo"},
-	{"156.154.70.22", "US - Comodo Secondary"},
-	{"208.67.220.220", "US - OpenDNS"},
-	{"208.67.222.222", "US - OpenDNS Secondary"},
-	{"208.67.222.220", "US - OpenDNS - 2"},
-	{"195.46.39.39", "RU - Safe DNS"},
-	{"195.46.39.40", "RU - Safe DNS Secondary"},
-	{"176.9.1.117", "DE - DNSForge - Normal"},
-	{"176.9.93.198", "DE - DNSForge - Normal Secondary"},
-	{"49.12.223.2", "DE - DNSForge - Clean"},
-	{"49.12.43.208", "DE - DNSForge - Clean Secondary"},
-	{"195.92.195.94", "GB - Orange DNS"},
-	{"195.92.195.95", "GB - Orange DNS Secondary"},
-	{"49.12.222.213", "DE - DNSForge - Hard"},
-	{"88.198.122.154", "DE - DNSForge - Hard Secondary"},
-	{"138.199.149.249", "DE - DNSForge - Blank"},
-	{"78.47.71.194", "DE - DNSForge - Blank Secondary"},
-	{"163.172.141.219", "90dns - FR - US"},
-	{"207.246.121.77", "90dns - FR - US Secondary"},
-	{"185.228.169.9", "CleanBrowsing"},
-	{"185.228.168.9", "CleanBrowsing Secondary"},
-	{"8.26.56.26", "US - Comodo Secure"},
-	{"8.20.247.20", "US - Comodo Secure Secondary"},
-	{"8.20.247.10", "US - Comodo Secure Filtering"},
-	{"8.26.56.10", "US - Comodo Secure Filtering Secondary"},
-	{"212.23.8.1", "GB - Zen Internet"},
-	{"212.23.3.1", "GB - Zen Internet Secondary"},
-	{"94.140.15.15", "RU - AdGuard DNS"},
-	{"94.140.14.14", "RU - AdGuard DNS Secondary"},
-	{"74.82.42.42", "US - Hurricane Electric"},
-	{"77.88.8.1", "RU - Yandex"},
-	{"77.88.8.8", "RU - Yandex Secondary"},
-	{"205.171.2.65", "US - Qwest"},
-	{"205.171.3.65", "US - Qwest Secondary"},
-	{"80.80.80.80", "NL - Freenom World"},
-	{"80.80.81.81", "NL - Freenom World Secondary"},
-	{"216.146.36.36", "US - Dyn"},
-	{"216.146.35.35", "US - Dyn Secondary"},
-	{"95.216.149.205", "LavaDNS - dns.lavate.ch"},
-	{"46.20.159.27", "TR - Dora Telekom"},
-	{"46.20.159.27", "TR - Dora Telekom Secondary"},
-	{"76.76.19.19", "Alternate DNS"},
-	{"76.223.122.150", "Alternate DNS Secondary"},
-	{"89.233.43.71", "DK - Censurfridns"},
-	{"91.239.100.100", "DK - Censurfridns Secondary"},
-	{"80.67.169.12", "FR - FDN"},
-	{"80.67.169.40", "FR - FDN Secondary"},
-	{"199.2.252.10", "US - Sprintlink"},
-	{"204.97.212.10", "US - Sprintlink Secondary"},
-	{"84.200.69.80", "DE - DNS WATCH"},
-	{"84.200.70.40", "DE - DNS WATCH Secondary"},
-	{"204.97.212.10", "US - Sprint"},
-	{"204.117.214.10", "US - Sprint Secondary"},
+	{IP: "212.154.100.18", Description: "TR - Türknet"},
+	{IP: "193.192.98.8", Description: "TR - Türknet Secondary"},
+	{IP: "1.1.1.1", Description: "AU - Cloudflare"},
+	{IP: "1.0.0.1", Description: "AU - Cloudflare Secondary"},
+	{IP: "45.90.28.230", Description: "US - NextDNS"},
+	{IP: "45.90.30.230", Description: "US - NextDNS Secondary"},
+	{IP: "8.8.4.4", Description: "US - Google Public DNS"},
+	{IP: "8.8.8.8", Description: "US - Google Public DNS Secondary"},
+	{IP: "92.45.23.168", Description: "TR - deik.org.tr"},
+	{IP: "195.244.44.45", Description: "TR - CubeDNS - Netinternet"},
+	{IP: "195.244.44.44", Description: "TR - CubeDNS - Netinternet Secondary"},
+	{IP: "9.9.9.9", Description: "US - Quad9 Security"},
+	{IP: "149.112.112.112", Description: "US - Quad9 Security Secondary"},
+	{IP: "149.112.112.10", Description: "US - Quad9 No Security"},
+	{IP: "9.9.9.10", Description: "US - Quad9 No Security Secondary"},
+	{IP: "156.154.71.1", Description: "US - Neustar 1"},
+	{IP: "156.154.70.1", Description: "US - Neustar 1 Secondary"},
+	{IP: "209.244.0.3", Description: "US - Level 3 - A"},
+	{IP: "209.244.0.4", Description: "US - Level 3 - A Secondary"},
+	{IP: "4.2.2.1", Description: "US - Level 3 - B"},
+	{IP: "4.2.2.2", Description: "US - Level 3 - B Secondary"},
+	{IP: "4.2.2.3", Description: "US - Level 3 - C"},
+	{IP: "4.2.2.4", Description: "US - Level 3 - C Secondary"},
+	{IP: "4.2.2.5", Description: "US - Level 3 - D"},
+	{IP: "4.2.2.6", Description: "US - Level 3 - D Secondary"},
+	{IP: "204.69.234.1", Description: "US - UltraDNS"},
+	{IP: "204.74.101.1", Description: "US - UltraDNS Secondary"},
+	{IP: "156.154.70.5", Description: "US - Neustar 2"},
+	{IP: "156.154.71.5", Description: "US - Neustar 2 Secondary"},
+	{IP: "199.85.126.10", Description: "US - Norton ConnectSafe"},
+	{IP: "199.85.127.10", Description: "US - Norton ConnectSafe Secondary"},
+	{IP: "198.153.192.1", Description: "US - Norton DNS"},
+	{IP: "198.153.194.1", Description: "US - Norton DNS Secondary"},
+	{IP: "64.6.65.6", Description: "US - VeriSign Public DNS"},
+	{IP: "64.6.64.6", Description: "US - VeriSign Public DNS Secondary"},
+	{IP: "156.154.71.22", Description: "US - Comodo"},
+	{IP: "156.154.70.22", Description: "US - Comodo Secondary"},
+	{IP: "208.67.220.220", Description: "US - OpenDNS"},
+	{IP: "208.67.222.222", Description: "US - OpenDNS Secondary"},
+	{IP: "208.67.222.220", Description: "US - OpenDNS - 2"},
+	{IP: "195.46.39.39", Description: "RU - Safe DNS"},
+	{IP: "195.46.39.40", Description: "RU - Safe DNS Secondary"},
+	{IP: "176.9.1.117", Description: "DE - DNSForge - Normal"},
+	{IP: "176.9.93.198", Description: "DE - DNSForge - Normal Secondary"},
+	{IP: "49.12.223.2", Description: "DE - DNSForge - Clean"},
+	{IP: "49.12.43.208", Description: "DE - DNSForge - Clean Secondary"},
+	{IP: "195.92.195.94", Description: "GB - Orange DNS"},
+	{IP: "195.92.195.95", Description: "GB - Orange DNS Secondary"},
+	{IP: "49.12.222.213", Description: "DE - DNSForge - Hard"},
+	{IP: "88.198.122.154", Description: "DE - DNSForge - Hard Secondary"},
+	{IP: "138.199.149.249", Description: "DE - DNSForge - Blank"},
+	{IP: "78.47.71.194", Description: "DE - DNSForge - Blank Secondary"},
+	{IP: "163.172.141.219", Description: "90dns - FR - US"},
+	{IP: "207.246.121.77", Description: "90dns - FR - US Secondary"},
+	{IP: "185.228.169.9", Description: "CleanBrowsing"},
+	{IP: "185.228.168.9", Description: "CleanBrowsing Secondary"},
+	{IP: "8.26.56.26", Description: "US - Comodo Secure"},
+	{IP: "8.20.247.20", Description: "US - Comodo Secure Secondary"},
+	{IP: "8.20.247.10", Description: "US - Comodo Secure Filtering"},
+	{IP: "8.26.56.10", Description: "US - Comodo Secure Filtering Secondary"},
+	{IP: "212.23.8.1", Description: "GB - Zen Internet"},
+	{IP: "212.23.3.1", Description: "GB - Zen Internet Secondary"},
+	{IP: "94.140.15.15", Description: "RU - AdGuard DNS"},
+	{IP: "94.140.14.14", Description: "RU - AdGuard DNS Secondary"},
+	{IP: "74.82.42.42", Description: "US - Hurricane Electric"},
+	{IP: "77.88.8.1", Description: "RU - Yandex"},
+	{IP: "77.88.8.8", Description: "RU - Yandex Secondary"},
+	{IP: "205.171.2.65", Description: "US - Qwest"},
+	{IP: "205.171.3.65", Description: "US - Qwest Secondary"},
+	{IP: "80.80.80.80", Description: "NL - Freenom World"},
+	{IP: "80.80.81.81", Description: "NL - Freenom World Secondary"},
+	{IP: "216.146.36.36", Description: "US - Dyn"},
+	{IP: "216.146.35.35", Description: "US - Dyn Secondary"},
+	{IP: "95.216.149.205", Description: "LavaDNS - dns.lavate.ch"},
+	{IP: "46.20.159.27", Description: "TR - Dora Telekom"},
+	{IP: "46.20.159.27", Description: "TR - Dora Telekom Secondary"},
+	{IP: "76.76.19.19", Description: "Alternate DNS"},
+	{IP: "76.223.122.150", Description: "Alternate DNS Secondary"},
+	{IP: "89.233.43.71", Description: "DK - Censurfridns"},
+	{IP: "91.239.100.100", Description: "DK - Censurfridns Secondary"},
+	{IP: "80.67.169.12", Description: "FR - FDN"},
+	{IP: "80.67.169.40", Description: "FR - FDN Secondary"},
+	{IP: "199.2.252.10", Description: "US - Sprintlink"},
+	{IP: "204.97.212.10", Description: "US - Sprintlink Secondary"},
+	{IP: "84.200.69.80", Description: "DE - DNS WATCH"},
+	{IP: "84.200.70.40", Description: "DE - DNS WATCH Secondary"},
+	{IP: "204.97.212.10", Description: "US - Sprint"},
+	{IP: "204.117.214.10", Description: "US - Sprint Secondary"},
 }
 
 /*
 Source: https://dnsmid.com/turkey/
 var defaultDNSServers = []DNSServer{
-	{"94.73.166.124", "AS34619 - CIZGI TELEKOMUNIKASYON ANONIM SIRKETI (Turkey, Şişli)"},
-	{"194.5.236.109", "AS209828 - Genc BT Bilisim Teknolojileri Limited Sirketi (Türkiye, Istanbul)"},
-	{"212.68.34.124", "AS212219 - HOSTING DUNYAM (Turkey, Istanbul)"},
-	{"77.92.138.166", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey, Eyüpsultan)"},
-	{"195.21.58.113", "AS8928 - GTT Communications Inc. (Turkey, Istanbul)"},
-	{"92.45.47.114", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"95.9.37.245", "AS9121 - Turk Telekomunikasyon Anonim Sirketi (Turkey, Kadıköy)"},
-	{"195.244.44.44", "AS43391 - Netdirekt A.S. (Turkey, Konak)"},
-	{"90.158.111.168", "AS9021 - Is Net Elektonik Bilgi Uretim Dagitim Ticaret ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"176.53.10.136", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.248.51.121", "AS47331 - TTNet A.S. (Turkey, Antalya)"},
-	{"78.135.102.237", "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey, Sisli)"},
-	{"89.19.14.82", "AS34619 - CIZGI TELEKOMUNIKASYON ANONIM SIRKETI (Turkey, Sisli)"},
-	{"213.153.223.21", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"213.194.123.26", "AS15924 - Vodafone Net Iletisim Hizmetler AS (Turkey, Istanbul)"},
-	{"213.74.195.52", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Umraniye)"},
-	{"176.88.18.85", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Umraniye)"},
-	{"208.67.222.222", "AS36692 - Cisco OpenDNS, LLC (United States, Wright City)"},
-	{"195.46.39.39", "AS57926 - SafeDNS, Inc. (Germany, Frankfurt)"},
-	{"8.8.8.8", "AS15169 - Google LLC (United States, Ashburn)"},
+	{IP: "94.73.166.124", Description: "AS34619 - CIZGI TELEKOMUNIKASYON ANONIM SIRKETI (Turkey, Şişli)"},
+	{IP: "194.5.236.109", Description: "AS209828 - Genc BT Bilisim Teknolojileri Limited Sirketi (Türkiye, Istanbul)"},
+	{IP: "212.68.34.124", Description: "AS212219 - HOSTING DUNYAM (Turkey, Istanbul)"},
+	{IP: "77.92.138.166", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey, Eyüpsultan)"},
+	{IP: "195.21.58.113", Description: "AS8928 - GTT Communications Inc. (Turkey, Istanbul)"},
+	{IP: "92.45.47.114", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "95.9.37.245", Description: "AS9121 - Turk Telekomunikasyon Anonim Sirketi (Turkey, Kadıköy)"},
+	{IP: "195.244.44.44", Description: "AS43391 - Netdirekt A.S. (Turkey, Konak)"},
+	{IP: "90.158.111.168", Description: "AS9021 - Is Net Elektonik Bilgi Uretim Dagitim Ticaret ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "176.53.10.136", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.248.51.121", Description: "AS47331 - TTNet A.S. (Turkey, Antalya)"},
+	{IP: "78.135.102.237", Description: "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey, Sisli)"},
+	{IP: "89.19.14.82", Description: "AS34619 - CIZGI TELEKOMUNIKASYON ANONIM SIRKETI (Turkey, Sisli)"},
+	{IP: "213.153.223.21", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "213.194.123.26", Description: "AS15924 - Vodafone Net Iletisim Hizmetler AS (Turkey, Istanbul)"},
+	{IP: "213.74.195.52", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Umraniye)"},
+	{IP: "176.88.18.85", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Umraniye)"},
+	{IP: "208.67.222.222", Description: "AS36692 - Cisco OpenDNS, LLC (United States, Wright City)"},
+	{IP: "195.46.39.39", Description: "AS57926 - SafeDNS, Inc. (Germany, Frankfurt)"},
+	{IP: "8.8.8.8", Description: "AS15169 - Google LLC (United States, Ashburn)"},
 }*/
 
 /*
 Source: https://public-dns.info/nameserver/tr.html
 
 var defaultDNSServers = []DNSServer{
-	{"95.9.194.13", "AS47331 - Turk Telekom (Turkey, Konyaalti)"},
-	{"92.45.59.195", "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"93.184.144.5", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Edirne)"},
-	{"94.54.47.200", "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Ankara)"},
-	{"176.53.92.22", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"185.250.193.165", "AS201079 - AKA Bilisim Yazilim Arge Ins. Taah. San. Tic. A.S. (Turkey)"},
-	{"213.14.10.165", "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"62.248.9.91", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"176.235.200.91", "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey)"},
-	{"212.98.235.50", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"176.236.223.149", "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey)"},
-	{"176.236.223.156", "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey)"},
-	{"185.200.36.182", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey)"},
-	{"31.7.36.36", "AS57152 - Teknet Yazlim Ve Bilgisayar Teknolojileri (Turkey, Antalya)"},
-	{"31.7.37.37", "AS57152 - Teknet Yazlim Ve Bilgisayar Teknolojileri (Turkey, Antalya)"},
-	{"2.59.119.25", "AS212219 - Talha Bogaz (Turkey)"},
-	{"5.25.56.138", "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"5.25.82.30", "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"5.25.98.224", "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"5.25.116.109", "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"5.25.113.223", "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
-	{"45.195.77.74", "AS43260 - Dgn Teknoloji A.s. (Turkey)"},
-	{"193.192.113.130", "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"78.135.69.51", "AS205953 - NAZNET Bilisim ve Telekomunikasyon Elektronik Haberlesme Hiz. ith. ihr. San. ve Tic. Ltd. s (Turkey, Nazilli)"},
-	{"45.195.77.57", "AS43260 - Dgn Teknoloji A.s. (Turkey)"},
-	{"176.235.165.91", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"176.53.85.154", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"88.249.41.163", "AS9121 - Turk Telekom (Turkey)"},
-	{"212.125.13.61", "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"78.135.81.205", "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
-	{"31.145.58.190", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Tekirdağ)"},
-	{"195.142.119.220", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"212.68.34.124", "AS212219 - Talha Bogaz (Turkey)"},
-	{"212.68.40.6", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"149.0.16.217", "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bursa)"},
-	{"213.248.179.191", "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey)"},
-	{"109.228.208.152", "AS34296 - Millenicom Telekomunikasyon Hizmetleri Anonim Sirketi (Turkey, Antalya)"},
-	{"77.73.216.5", "AS42716 - Assan Bilisim A.S. (Turkey, Istanbul)"},
-	{"82.222.48.42", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"46.221.5.200", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"91.93.153.68", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"213.153.223.21", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"78.135.107.209", "AS211859 - Ozkula Internet Hizmetleri Tic. LTD. STI. (Turkey)"},
-	{"31.206.250.130", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Denizli)"},
-	{"178.211.56.71", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"185.67.205.193", "AS59886 - Layer Sistem tic. ltd. sti. (Turkey)"},
-	{"78.189.141.206", "AS9121 - Turk Telekom (Turkey, Bursa)"},
-	{"94.78.85.253", "AS44558 - Netonline Bilisim Sirketi LTD (Turkey)"},
-	{"85.98.211.107", "AS9121 - Turk Telekom (Turkey, Denizli)"},
-	{"31.145.56.14", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"85.29.51.9", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"45.11.96.36", "AS48678 - PENTECH BILISIM TEKNOLOJILERI SANAYI VE TICARET LIMITED SIRKETi (Turkey)"},
-	{"176.88.41.253", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.250.207.65", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"78.186.181.121", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"31.145.110.132", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"213.248.134.130", "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Yukarikaraman)"},
-	{"88.249.67.177", "AS9121 - Turk Telekom (Turkey, Akçaabat)"},
-	{"194.145.138.11", "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
-	{"46.221.14.44", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bigadic)"},
-	{"46.196.212.8", "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Gaziantep)"},
-	{"31.210.79.250", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"194.5.236.248", "AS209828 - Genc BT Bilisim Teknolojileri Limited Sirketi (Turkey)"},
-	{"85.98.93.171", "AS9121 - Turk Telekom (Turkey, Didim)"},
-	{"95.9.85.219", "AS9121 - Turk Telekom (Turkey, Kayseri)"},
-	{"204.157.133.81", "AS44547 - Netundweb Telekomunikasyon Ticaret Limited Sirketi (Turkey)"},
-	{"88.247.99.66", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"185.250.240.179", "AS211804 - Sistemdc webhosting and server services (Turkey)"},
-	{"213.153.224.29", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"176.235.135.204", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"91.93.139.159", "AS43352 - Teletek Bulut Bilisim ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"204.157.133.250", "AS44547 - Netundweb Telekomunikasyon Ticaret Limited Sirketi (Turkey)"},
-	{"24.133.181.111", "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Ankara)"},
-	{"31.192.208.20", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"176.88.166.103", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Burdur)"},
-	{"204.157.133.30", "AS44547 - Netundweb Telekomunikasyon Ticaret Limited Sirketi (Turkey)"},
-	{"213.153.224.28", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"212.98.231.69", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey)"},
-	{"37.1.145.34", "AS50941 - Vargonen Teknoloji ve Bilisim Sanayi Ticaret Anonim Sirketi (Turkey)"},
-	{"37.1.145.102", "AS50941 - Vargonen Teknoloji ve Bilisim Sanayi Ticaret Anonim Sirketi (Turkey)"},
-	{"31.206.52.78", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kosekoy)"},
-	{"82.222.57.87", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"188.132.234.170", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey, Istanbul)"},
-	{"212.98.224.69", "AS48678 - PENTECH BILISIM TEKNOLOJILERI SANAYI VE TICARET LIMITED SIRKETi (Turkey)"},
-	{"85.96.196.109", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"45.156.31.240", "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
-	{"195.142.127.83", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Konya)"},
-	{"185.92.2.100", "AS202536 - Isim Kayit Bilisim (Turkey, Kosekoy)"},
-	{"213.153.229.193", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"195.21.58.113", "AS8928 - GTT Communications Inc. (Turkey)"},
-	{"38.242.150.124", "AS51167 - Contabo GmbH (Turkey, Diyarbakır)"},
-	{"78.186.250.194", "AS9121 - Turk Telekom (Turkey, Bursa)"},
-	{"89.19.8.120", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"212.98.224.174", "AS48678 - PENTECH BILISIM TEKNOLOJILERI SANAYI VE TICARET LIMITED SIRKETi (Turkey)"},
-	{"176.236.37.163", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"77.79.101.131", "AS39582 - Grid Telekomunikasyon Hizmetleri AS (Turkey)"},
-	{"95.9.250.227", "AS47331 - Turk Telekom (Turkey, Adana)"},
-	{"37.75.10.106", "AS199484 - SAGLAYICI Teknoloji Bilisim Yayincilik Hiz. Ticaret Ltd. Sti. (Turkey, Istanbul)"},
-	{"91.151.83.147", "AS60707 - Kapteyan Bilisim Teknolojileri San. ve Tic. A.S. (Turkey)"},
-	{"79.98.134.211", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"80.253.246.63", "AS212219 - Talha Bogaz (Turkey)"},
-	{"195.33.236.164", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Izmir)"},
-	{"188.3.122.46", "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"78.188.115.140", "AS9121 - Turk Telekom (Turkey, Fatih)"},
-	{"88.247.23.246", "AS9121 - Turk Telekom (Turkey, Antalya)"},
-	{"194.62.40.20", "AS42724 - Talido Bilisim Teknolojileri A.S (Turkey, Istanbul)"},
-	{"88.225.232.205", "AS9121 - Turk Telekom (Turkey, Antalya)"},
-	{"78.187.76.85", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"95.173.168.180", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"78.189.170.100", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"213.74.223.74", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"91.93.172.170", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.247.215.148", "AS9121 - Turk Telekom (Turkey, Germencik)"},
-	{"88.238.138.10", "AS9121 - Turk Telekom (Turkey, Ankara)"},
-	{"81.8.30.102", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kadirli)"},
-	{"78.168.240.115", "AS47331 - Turk Telekom (Turkey, Adana)"},
-	{"195.46.129.94", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Alanya)"},
-	{"81.214.127.23", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.247.26.195", "AS47331 - Turk Telekom (Turkey, Niğde)"},
-	{"92.45.47.28", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.225.219.169", "AS9121 - Turk Telekom (Turkey, Konya)"},
-	{"185.248.14.200", "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Esenyurt)"},
-	{"95.9.226.53", "AS9121 - Turk Telekom (Turkey, Antalya)"},
-	{"185.153.222.232", "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
-	{"213.142.148.40", "AS212219 - Talha Bogaz (Turkey)"},
-	{"38.10.71.214", "AS208972 - Gibirnet Iletisim Hizmetleri Sanayi Ve Ticaret Limited Sirketi (Turkey, Sanliurfa)"},
-	{"81.214.36.153", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"85.105.252.93", "AS47331 - Turk Telekom (Turkey, Ankara)"},
-	{"94.102.13.152", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"91.151.88.109", "AS212219 - Talha Bogaz (Turkey)"},
-	{"78.186.173.215", "AS9121 - Turk Telekom (Turkey, Mugla)"},
-	{"78.189.16.71", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"94.73.156.76", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"213.74.249.98", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Çanakkale)"},
-	{"94.73.156.78", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"212.58.26.33", "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
-	{"185.208.102.147", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Kilis)"},
-	{"81.214.12.150", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"81.213.150.85", "AS47331 - Turk Telekom (Turkey, Nilufer)"},
-	{"78.188.63.155", "AS47331 - Turk Telekom (Turkey, Elâzığ)"},
-	{"94.124.73.153", "AS208095 - Internetten Teknoloji Bil.san.tic.ltd.sti. (Turkey)"},
-	{"78.189.46.123", "AS9121 - Turk Telekom (Turkey, Avcilar)"},
-	{"31.210.52.168", "AS49334 - Sh Online Iletisim Anonim Sirketi (Turkey)"},
-	{"82.222.60.46", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"95.8.200.28", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"85.108.206.75", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"185.85.207.35", "AS201079 - AKA Bilisim Yazilim Arge Ins. Taah. San. Tic. A.S. (Turkey)"},
-	{"185.140.125.220", "AS57152 - Teknet Yazlim Ve Bilgisayar Teknolojileri (Turkey)"},
-	{"188.132.221.60", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antakya)"},
-	{"185.15.198.12", "AS201520 - Dedicated Telekomunikasyon Teknoloji Hiz. Tic. San. LTD. STI. (Turkey)"},
-	{"78.188.246.235", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"95.142.132.78", "AS49840 - Enson Net Ltd (Turkey)"},
-	{"88.249.68.14", "AS47331 - Turk Telekom (Turkey, Niğde)"},
-	{"176.236.77.102", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"89.43.31.10", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"89.252.167.61", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"77.75.35.138", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"78.189.180.162", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"95.9.95.194", "AS9121 - Turk Telekom (Turkey, Ankara)"},
-	{"193.192.113.146", "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"82.222.49.18", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.227.153.194", "AS47331 - Turk Telekom (Turkey, Cankaya)"},
-	{"95.9.241.172", "AS47331 - Turk Telekom (Turkey, Adana)"},
-	{"88.234.22.129", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"194.124.36.207", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antalya)"},
-	{"85.105.18.139", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"95.173.162.124", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"78.187.231.245", "AS47331 - Turk Telekom (Turkey, Ankara)"},
-	{"85.106.31.119", "AS9121 - Turk Telekom (Turkey, Diyarbakır)"},
-	{"213.14.66.51", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Izmir)"},
-	{"185.169.183.54", "AS206119 - Veganet Teknolojileri ve Hizmetleri LTD STI (Turkey, Istanbul)"},
-	{"95.0.226.132", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"94.102.6.240", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"94.102.7.90", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"185.8.12.24", "AS61345 - Flytom Networks Ltd (Turkey, Mersin)"},
-	{"89.19.8.118", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"81.213.79.71", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"95.173.162.118", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"80.93.216.18", "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
-	{"94.101.87.175", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"85.99.244.122", "AS9121 - Turk Telekom (Turkey, Fethiye)"},
-	{"185.208.102.49", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Kilis)"},
-	{"94.124.73.151", "AS208095 - Internetten Teknoloji Bil.san.tic.ltd.sti. (Turkey)"},
-	{"78.188.58.228", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.189.229.223", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"77.92.133.5", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"88.249.58.86", "AS47331 - Turk Telekom (Turkey, Kayseri)"},
-	{"176.88.10.83", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Sisli)"},
-	{"77.75.37.178", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"194.124.36.201", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antalya)"},
-	{"77.223.142.102", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"78.186.206.121", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"176.53.35.127", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"95.9.190.34", "AS9121 - Turk Telekom (Turkey, Konya)"},
-	{"92.45.47.114", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"176.88.18.87", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Ankara)"},
-	{"77.223.128.221", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"213.194.123.26", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Antalya)"},
-	{"78.111.106.91", "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
-	{"77.75.35.139", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"78.182.254.16", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"89.19.16.37", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"88.227.51.145", "AS9121 - Turk Telekom (Turkey, Basaksehir)"},
-	{"85.153.132.196", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"85.95.240.30", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"78.188.115.188", "AS9121 - Turk Telekom (Turkey, Fatih)"},
-	{"193.192.121.230", "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"212.57.11.185", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mugla)"},
-	{"78.135.102.237", "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
-	{"77.92.138.166", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"88.250.243.205", "AS9121 - Turk Telekom (Turkey, Mugla)"},
-	{"90.158.111.168", "AS9021 - Is Net Elektonik Bilgi Uretim Dagitim Ticaret ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"38.242.147.147", "AS51167 - Contabo GmbH (Turkey, Diyarbakır)"},
-	{"78.188.181.64", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.189.127.251", "AS47331 - Turk Telekom (Turkey, Adana)"},
-	{"93.94.252.171", "AS47123 - TI Sparkle Turkey Telekomunukasyon A.S (Turkey)"},
-	{"88.236.253.84", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.242.0.188", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"84.44.14.35", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"78.135.85.37", "AS212219 - Talha Bogaz (Turkey)"},
-	{"194.124.36.68", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antalya)"},
-	{"212.68.34.235", "AS212219 - Talha Bogaz (Turkey)"},
-	{"37.148.213.124", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"94.73.160.131", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"185.33.232.4", "AS51557 - Isimtescil Bilisim A.S. (Turkey)"},
-	{"95.9.108.212", "AS9121 - Turk Telekom (Turkey, Osmaniye)"},
-	{"195.46.151.9", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"93.184.152.105", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
-	{"85.95.242.71", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"85.99.232.65", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"84.51.47.66", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Osmaniye)"},
-	{"95.9.130.102", "AS9121 - Turk Telekom (Turkey, Kahramanmaraş)"},
-	{"77.92.133.11", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"176.235.221.96", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"78.188.215.107", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"185.22.187.150", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"31.210.78.210", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"195.142.153.122", "AS199484 - SAGLAYICI Teknoloji Bilisim Yayincilik Hiz. Ticaret Ltd. Sti. (Turkey, Istanbul)"},
-	{"77.223.128.218", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"85.95.238.173", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"88.250.66.143", "AS9121 - Turk Telekom (Turkey, Adapazarı)"},
-	{"78.186.131.161", "AS9121 - Turk Telekom (Turkey, Kadıköy)"},
-	{"45.156.31.140", "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
-	{"94.138.223.150", "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
-	{"94.102.6.241", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"213.14.66.54", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Izmir)"},
-	{"78.186.134.59", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"77.90.131.59", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kadıköy)"},
-	{"91.191.170.4", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"81.213.148.253", "AS47331 - Turk Telekom (Turkey, Nilufer)"},
-	{"89.19.22.46", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"88.249.166.158", "AS9121 - Turk Telekom (Turkey, Çanakkale)"},
-	{"78.184.250.152", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"176.236.139.87", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mersin)"},
-	{"78.179.111.225", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"176.88.112.53", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mugla)"},
-	{"185.117.123.41", "AS213145 - Fibim Fibernet Gsm Sanayi Ve Ticaret Anonim Sirketi (Turkey, Alanya)"},
-	{"78.186.36.168", "AS47331 - Turk Telekom (Turkey, Cankaya)"},
-	{"95.173.162.126", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"93.184.152.43", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
-	{"193.36.63.184", "AS201086 - ServerPlusInternet Sunucu Hizmetleri (Turkey, Bursa)"},
-	{"78.186.159.117", "AS47331 - Turk Telekom (Turkey, Etimesgut)"},
-	{"85.100.138.3", "AS47331 - Turk Telekom (Turkey, Adana)"},
-	{"78.188.10.14", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"185.226.160.76", "AS205192 - Omur Bilisim Teknolojileri (Turkey, Ankara)"},
-	{"93.184.152.109", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
-	{"92.45.47.27", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"89.252.173.104", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"176.88.18.88", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Ankara)"},
-	{"195.87.69.30", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bursa)"},
-	{"85.98.40.40", "AS47331 - Turk Telekom (Turkey)"},
-	{"78.189.111.211", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"94.124.73.139", "AS208095 - Internetten Teknoloji Bil.san.tic.ltd.sti. (Turkey)"},
-	{"91.93.64.53", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"88.250.238.6", "AS9121 - Turk Telekom (Turkey, Menemen)"},
-	{"88.228.16.148", "AS9121 - Turk Telekom (Turkey, Ankara)"},
-	{"78.189.47.69", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.111.106.90", "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
-	{"93.177.103.194", "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
-	{"84.44.14.37", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"78.189.237.37", "AS9121 - Turk Telekom (Turkey, Akhisar)"},
-	{"176.88.181.98", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Adana)"},
-	{"84.44.9.10", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"93.184.152.108", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
-	{"91.93.131.69", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Eyuepsultan)"},
-	{"78.186.49.28", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"94.102.8.154", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"212.125.11.12", "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.248.55.71", "AS9121 - Turk Telekom (Turkey, Mugla)"},
-	{"88.248.56.216", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"89.19.7.213", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"88.247.206.159", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"82.222.152.165", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"195.46.154.156", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"91.102.162.194", "AS41801 - Datafon Teknoloji San.Tic.Ltd.Sti. (Turkey)"},
-	{"217.65.177.83", "AS34296 - Millenicom Telekomunikasyon Hizmetleri Anonim Sirketi (Turkey, Kayseri)"},
-	{"92.45.25.227", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Akhisar)"},
-	{"93.184.146.30", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Kırklareli)"},
-	{"78.186.194.187", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"188.132.221.58", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antakya)"},
-	{"213.74.195.52", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Cankaya)"},
-	{"78.188.205.173", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"92.45.200.125", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"185.233.247.205", "AS206119 - Veganet Teknolojileri ve Hizmetleri LTD STI (Turkey)"},
-	{"194.146.50.241", "AS200456 - Verigom Telekomunikasyon Limited Sirketi (Turkey)"},
-	{"95.173.162.111", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"85.105.94.129", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"81.215.214.136", "AS9121 - Turk Telekom (Turkey, Tokat Province)"},
-	{"213.155.107.188", "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
-	{"94.54.12.73", "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Mersin)"},
-	{"88.247.165.243", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"88.248.195.254", "AS9121 - Turk Telekom (Turkey, Muratpasa)"},
-	{"176.53.35.120", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"88.248.104.242", "AS9121 - Turk Telekom (Turkey, Edremit)"},
-	{"94.73.154.204", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"91.93.131.70", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Eyuepsultan)"},
-	{"85.106.20.174", "AS9121 - Turk Telekom (Turkey, Cekmekoey)"},
-	{"94.54.88.208", "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Ankara)"},
-	{"81.214.73.194", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"94.102.76.212", "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
-	{"85.102.255.171", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"81.214.69.83", "AS9121 - Turk Telekom (Turkey, Samsun)"},
-	{"93.184.146.28", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Kırklareli)"},
-	{"93.113.63.27", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"85.97.199.245", "AS9121 - Turk Telekom (Turkey, Denizli)"},
-	{"94.101.87.231", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"78.189.29.186", "AS9121 - Turk Telekom (Turkey, Kartal)"},
-	{"82.150.94.174", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"195.46.129.74", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Alanya)"},
-	{"88.249.51.252", "AS9121 - Turk Telekom (Turkey, Alanya)"},
-	{"78.186.120.157", "AS47331 - Turk Telekom (Turkey, Adalar)"},
-	{"185.81.153.147", "AS202505 - Netbudur Telekomunikasyon Limited Sirketi (Turkey)"},
-	{"88.245.96.166", "AS9121 - Turk Telekom (Turkey, Kütahya)"},
-	{"78.189.137.251", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.248.51.121", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"85.99.234.230", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.250.55.67", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"81.214.254.111", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"92.45.47.30", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"95.9.81.100", "AS47331 - Turk Telekom (Turkey, Kayseri)"},
-	{"85.95.244.88", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"31.169.79.37", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"92.44.191.15", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Nilufer)"},
-	{"94.138.207.90", "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
-	{"85.159.71.156", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"77.245.158.121", "AS42868 - Niobe Bilisim Teknolojileri Yazilim San. Tic. Ltd. Sti. (Turkey)"},
-	{"91.241.49.28", "AS209828 - Genc BT Bilisim Teknolojileri Limited Sirketi (Turkey)"},
-	{"91.191.173.202", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"88.250.224.28", "AS9121 - Turk Telekom (Turkey, Bursa)"},
-	{"78.188.7.231", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"188.132.193.152", "AS201233 - Yonca Duran (Turkey)"},
-	{"94.102.6.239", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"81.214.141.75", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"176.53.10.136", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"82.222.48.19", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"77.92.155.196", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"213.238.172.225", "AS60707 - Kapteyan Bilisim Teknolojileri San. ve Tic. A.S. (Turkey, Istanbul)"},
-	{"85.95.242.120", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"91.93.132.36", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"94.101.82.233", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"91.151.94.246", "AS211560 - Muhammet Ugur Ozturk (Turkey)"},
-	{"94.102.2.244", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"213.14.11.162", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Yildirim)"},
-	{"31.145.137.156", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bursa)"},
-	{"31.210.69.163", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"78.188.22.60", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"92.44.44.184", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"212.57.29.100", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"88.247.151.174", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"185.48.180.218", "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
-	{"85.95.238.113", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"95.9.37.245", "AS9121 - Turk Telekom (Turkey, Karatay)"},
-	{"91.93.58.175", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Adana)"},
-	{"212.98.194.132", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Seyhan)"},
-	{"95.6.86.31", "AS9121 - Turk Telekom (Turkey, Soeke)"},
-	{"95.173.162.85", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"85.102.10.64", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"81.8.30.98", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kadirli)"},
-	{"94.138.206.14", "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
-	{"185.200.36.93", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Reyhanli)"},
-	{"85.95.238.114", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"78.186.138.124", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"94.73.166.124", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"194.145.138.232", "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
-	{"81.8.106.42", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Talas)"},
-	{"78.188.42.166", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.188.37.78", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.186.60.77", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.250.245.64", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"81.213.149.81", "AS47331 - Turk Telekom (Turkey, Kayapinar)"},
-	{"85.95.242.156", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"78.189.139.221", "AS47331 - Turk Telekom (Turkey, Ankara)"},
-	{"93.184.146.27", "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Kırklareli)"},
-	{"85.97.195.137", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"185.85.204.177", "AS201079 - AKA Bilisim Yazilim Arge Ins. Taah. San. Tic. A.S. (Turkey)"},
-	{"85.99.242.115", "AS9121 - Turk Telekom (Turkey, Izmir)"},
-	{"91.93.153.74", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"85.105.171.92", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.111.106.93", "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
-	{"176.53.35.113", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"94.138.223.151", "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
-	{"88.248.99.53", "AS9121 - Turk Telekom (Turkey, Balıkesir)"},
-	{"78.186.25.166", "AS47331 - Turk Telekom (Turkey)"},
-	{"95.9.233.135", "AS47331 - Turk Telekom (Turkey)"},
-	{"77.79.92.164", "AS39582 - Grid Telekomunikasyon Hizmetleri AS (Turkey, Zonguldak)"},
-	{"88.247.20.13", "AS9121 - Turk Telekom (Turkey, Karatay)"},
-	{"94.73.156.77", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"81.215.63.47", "AS47331 - Turk Telekom (Turkey, Van)"},
-	{"78.186.129.210", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"93.177.103.34", "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
-	{"84.51.15.20", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Konya)"},
-	{"188.132.205.174", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"93.177.103.23", "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
-	{"95.6.70.227", "AS9121 - Turk Telekom (Turkey, Bagcilar)"},
-	{"85.105.122.250", "AS47331 - Turk Telekom (Turkey, Ankara)"},
-	{"90.158.200.15", "AS9021 - Is Net Elektonik Bilgi Uretim Dagitim Ticaret ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"78.135.113.203", "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
-	{"81.22.109.110", "AS48737 - Dorabase Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"85.95.239.215", "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
-	{"178.250.88.190", "AS29399 - Ramtek Telekomunikasyon Hizmetleri Sanayi Ve Ticaret Limited Sirketi (Turkey, Yalova)"},
-	{"88.250.63.46", "AS47331 - Turk Telekom (Turkey, Van)"},
-	{"78.189.189.80", "AS9121 - Turk Telekom (Turkey, Atakum)"},
-	{"88.250.204.241", "AS9121 - Turk Telekom (Turkey, Magnesia ad Sipylum)"},
-	{"78.188.74.191", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"95.9.84.66", "AS9121 - Turk Telekom (Turkey, Kayseri)"},
-	{"94.73.154.205", "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
-	{"95.173.162.122", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"89.252.129.2", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey, Istanbul)"},
-	{"95.173.184.115", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"185.169.183.130", "AS206119 - Veganet Teknolojileri ve Hizmetleri LTD STI (Turkey, Istanbul)"},
-	{"79.98.134.213", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"212.64.215.122", "AS61135 - Comnet Bilgi Iletisim Teknolojileri Ticaret A.s. (Turkey)"},
-	{"85.105.216.109", "AS9121 - Turk Telekom (Turkey, Konya)"},
-	{"91.230.149.174", "AS212301 - Makdos Bilisim Teknolojileri Sanayi Ticaret Limited Sirketi (Turkey)"},
-	{"81.215.2.60", "AS47331 - Turk Telekom (Turkey, Ankara)"},
-	{"79.98.134.214", "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
-	{"91.93.153.73", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"81.214.70.155", "AS9121 - Turk Telekom (Turkey, Samsun)"},
-	{"95.173.162.75", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"195.46.129.72", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Alanya)"},
-	{"88.249.37.14", "AS47331 - Turk Telekom (Turkey, Batman)"},
-	{"194.27.101.249", "AS211249 - Yildiz Teknik Universitesi (Turkey)"},
-	{"95.0.124.163", "AS9121 - Turk Telekom (Turkey)"},
-	{"85.105.252.11", "AS47331 - Turk Telekom (Turkey, Ankara)"},
-	{"78.186.58.171", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.186.191.47", "AS9121 - Turk Telekom (Turkey, Bursa)"},
-	{"81.214.12.7", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.247.12.187", "AS9121 - Turk Telekom (Turkey, Ankara)"},
-	{"195.33.213.254", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Adapazarı)"},
-	{"78.186.166.25", "AS9121 - Turk Telekom (Turkey, Bursa)"},
-	{"84.44.32.43", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
-	{"81.214.85.50", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"88.249.65.185", "AS9121 - Turk Telekom (Turkey, Yusufeli)"},
-	{"78.189.87.241", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"77.223.128.220", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"77.223.128.222", "AS43391 - Netdirekt A.S. (Turkey)"},
-	{"85.105.160.195", "AS47331 - Turk Telekom (Turkey, Kartal)"},
-	{"78.188.42.52", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"212.154.19.244", "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
-	{"81.8.106.45", "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kayseri)"},
-	{"212.57.11.190", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mugla)"},
-	{"81.214.190.74", "AS47331 - Turk Telekom (Turkey, Izmir)"},
-	{"78.186.163.250", "AS47331 - Turk Telekom (Turkey, Pamukkale)"},
-	{"81.214.54.88", "AS9121 - Turk Telekom (Turkey, Istanbul)"},
-	{"176.236.31.156", "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
-	{"94.102.2.15", "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
-	{"78.186.147.181", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"85.99.244.74", "AS47331 - Turk Telekom (Turkey, Izmir)"},
-	{"85.105.81.252", "AS47331 - Turk Telekom (Turkey, Istanbul)"},
-	{"78.186.191.171", "AS47331 - Turk Telekom (Turkey, Bursa)"},
-	{"188.132.203.3", "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antakya)"},
-	{"78.135.102.232", "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
+	{IP: "95.9.194.13", Description: "AS47331 - Turk Telekom (Turkey, Konyaalti)"},
+	{IP: "92.45.59.195", Description: "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "93.184.144.5", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Edirne)"},
+	{IP: "94.54.47.200", Description: "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Ankara)"},
+	{IP: "176.53.92.22", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "185.250.193.165", Description: "AS201079 - AKA Bilisim Yazilim Arge Ins. Taah. San. Tic. A.S. (Turkey)"},
+	{IP: "213.14.10.165", Description: "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "62.248.9.91", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "176.235.200.91", Description: "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey)"},
+	{IP: "212.98.235.50", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "176.236.223.149", Description: "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey)"},
+	{IP: "176.236.223.156", Description: "AS34984 - Tellcom Iletisim Hizmetleri A.s. (Turkey)"},
+	{IP: "185.200.36.182", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey)"},
+	{IP: "31.7.36.36", Description: "AS57152 - Teknet Yazlim Ve Bilgisayar Teknolojileri (Turkey, Antalya)"},
+	{IP: "31.7.37.37", Description: "AS57152 - Teknet Yazlim Ve Bilgisayar Teknolojileri (Turkey, Antalya)"},
+	{IP: "2.59.119.25", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "5.25.56.138", Description: "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "5.25.82.30", Description: "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "5.25.98.224", Description: "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "5.25.116.109", Description: "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "5.25.113.223", Description: "AS16135 - Turkcell Iletisim Hizmetleri A.s. (Turkey, Istanbul)"},
+	{IP: "45.195.77.74", Description: "AS43260 - Dgn Teknoloji A.s. (Turkey)"},
+	{IP: "193.192.113.130", Description: "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "78.135.69.51", Description: "AS205953 - NAZNET Bilisim ve Telekomunikasyon Elektronik Haberlesme Hiz. ith. ihr. San. ve Tic. Ltd. s (Turkey, Nazilli)"},
+	{IP: "45.195.77.57", Description: "AS43260 - Dgn Teknoloji A.s. (Turkey)"},
+	{IP: "176.235.165.91", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "176.53.85.154", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "88.249.41.163", Description: "AS9121 - Turk Telekom (Turkey)"},
+	{IP: "212.125.13.61", Description: "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "78.135.81.205", Description: "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
+	{IP: "31.145.58.190", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Tekirdağ)"},
+	{IP: "195.142.119.220", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "212.68.34.124", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "212.68.40.6", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "149.0.16.217", Description: "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bursa)"},
+	{IP: "213.248.179.191", Description: "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey)"},
+	{IP: "109.228.208.152", Description: "AS34296 - Millenicom Telekomunikasyon Hizmetleri Anonim Sirketi (Turkey, Antalya)"},
+	{IP: "77.73.216.5", Description: "AS42716 - Assan Bilisim A.S. (Turkey, Istanbul)"},
+	{IP: "82.222.48.42", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "46.221.5.200", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "91.93.153.68", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "213.153.223.21", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "78.135.107.209", Description: "AS211859 - Ozkula Internet Hizmetleri Tic. LTD. STI. (Turkey)"},
+	{IP: "31.206.250.130", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Denizli)"},
+	{IP: "178.211.56.71", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "185.67.205.193", Description: "AS59886 - Layer Sistem tic. ltd. sti. (Turkey)"},
+	{IP: "78.189.141.206", Description: "AS9121 - Turk Telekom (Turkey, Bursa)"},
+	{IP: "94.78.85.253", Description: "AS44558 - Netonline Bilisim Sirketi LTD (Turkey)"},
+	{IP: "85.98.211.107", Description: "AS9121 - Turk Telekom (Turkey, Denizli)"},
+	{IP: "31.145.56.14", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "85.29.51.9", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "45.11.96.36", Description: "AS48678 - PENTECH BILISIM TEKNOLOJILERI SANAYI VE TICARET LIMITED SIRKETi (Turkey)"},
+	{IP: "176.88.41.253", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.250.207.65", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "78.186.181.121", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "31.145.110.132", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "213.248.134.130", Description: "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Yukarikaraman)"},
+	{IP: "88.249.67.177", Description: "AS9121 - Turk Telekom (Turkey, Akçaabat)"},
+	{IP: "194.145.138.11", Description: "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
+	{IP: "46.221.14.44", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bigadic)"},
+	{IP: "46.196.212.8", Description: "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Gaziantep)"},
+	{IP: "31.210.79.250", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "194.5.236.248", Description: "AS209828 - Genc BT Bilisim Teknolojileri Limited Sirketi (Turkey)"},
+	{IP: "85.98.93.171", Description: "AS9121 - Turk Telekom (Turkey, Didim)"},
+	{IP: "95.9.85.219", Description: "AS9121 - Turk Telekom (Turkey, Kayseri)"},
+	{IP: "204.157.133.81", Description: "AS44547 - Netundweb Telekomunikasyon Ticaret Limited Sirketi (Turkey)"},
+	{IP: "88.247.99.66", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "185.250.240.179", Description: "AS211804 - Sistemdc webhosting and server services (Turkey)"},
+	{IP: "213.153.224.29", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "176.235.135.204", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "91.93.139.159", Description: "AS43352 - Teletek Bulut Bilisim ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "204.157.133.250", Description: "AS44547 - Netundweb Telekomunikasyon Ticaret Limited Sirketi (Turkey)"},
+	{IP: "24.133.181.111", Description: "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Ankara)"},
+	{IP: "31.192.208.20", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "176.88.166.103", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Burdur)"},
+	{IP: "204.157.133.30", Description: "AS44547 - Netundweb Telekomunikasyon Ticaret Limited Sirketi (Turkey)"},
+	{IP: "213.153.224.28", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "212.98.231.69", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey)"},
+	{IP: "37.1.145.34", Description: "AS50941 - Vargonen Teknoloji ve Bilisim Sanayi Ticaret Anonim Sirketi (Turkey)"},
+	{IP: "37.1.145.102", Description: "AS50941 - Vargonen Teknoloji ve Bilisim Sanayi Ticaret Anonim Sirketi (Turkey)"},
+	{IP: "31.206.52.78", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kosekoy)"},
+	{IP: "82.222.57.87", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "188.132.234.170", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "212.98.224.69", Description: "AS48678 - PENTECH BILISIM TEKNOLOJILERI SANAYI VE TICARET LIMITED SIRKETi (Turkey)"},
+	{IP: "85.96.196.109", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "45.156.31.240", Description: "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
+	{IP: "195.142.127.83", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Konya)"},
+	{IP: "185.92.2.100", Description: "AS202536 - Isim Kayit Bilisim (Turkey, Kosekoy)"},
+	{IP: "213.153.229.193", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "195.21.58.113", Description: "AS8928 - GTT Communications Inc. (Turkey)"},
+	{IP: "38.242.150.124", Description: "AS51167 - Contabo GmbH (Turkey, Diyarbakır)"},
+	{IP: "78.186.250.194", Description: "AS9121 - Turk Telekom (Turkey, Bursa)"},
+	{IP: "89.19.8.120", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "212.98.224.174", Description: "AS48678 - PENTECH BILISIM TEKNOLOJILERI SANAYI VE TICARET LIMITED SIRKETi (Turkey)"},
+	{IP: "176.236.37.163", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "77.79.101.131", Description: "AS39582 - Grid Telekomunikasyon Hizmetleri AS (Turkey)"},
+	{IP: "95.9.250.227", Description: "AS47331 - Turk Telekom (Turkey, Adana)"},
+	{IP: "37.75.10.106", Description: "AS199484 - SAGLAYICI Teknoloji Bilisim Yayincilik Hiz. Ticaret Ltd. Sti. (Turkey, Istanbul)"},
+	{IP: "91.151.83.147", Description: "AS60707 - Kapteyan Bilisim Teknolojileri San. ve Tic. A.S. (Turkey)"},
+	{IP: "79.98.134.211", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "80.253.246.63", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "195.33.236.164", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Izmir)"},
+	{IP: "188.3.122.46", Description: "AS8386 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "78.188.115.140", Description: "AS9121 - Turk Telekom (Turkey, Fatih)"},
+	{IP: "88.247.23.246", Description: "AS9121 - Turk Telekom (Turkey, Antalya)"},
+	{IP: "194.62.40.20", Description: "AS42724 - Talido Bilisim Teknolojileri A.S (Turkey, Istanbul)"},
+	{IP: "88.225.232.205", Description: "AS9121 - Turk Telekom (Turkey, Antalya)"},
+	{IP: "78.187.76.85", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "95.173.168.180", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "78.189.170.100", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "213.74.223.74", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "91.93.172.170", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.247.215.148", Description: "AS9121 - Turk Telekom (Turkey, Germencik)"},
+	{IP: "88.238.138.10", Description: "AS9121 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "81.8.30.102", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kadirli)"},
+	{IP: "78.168.240.115", Description: "AS47331 - Turk Telekom (Turkey, Adana)"},
+	{IP: "195.46.129.94", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Alanya)"},
+	{IP: "81.214.127.23", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.247.26.195", Description: "AS47331 - Turk Telekom (Turkey, Niğde)"},
+	{IP: "92.45.47.28", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.225.219.169", Description: "AS9121 - Turk Telekom (Turkey, Konya)"},
+	{IP: "185.248.14.200", Description: "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Esenyurt)"},
+	{IP: "95.9.226.53", Description: "AS9121 - Turk Telekom (Turkey, Antalya)"},
+	{IP: "185.153.222.232", Description: "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
+	{IP: "213.142.148.40", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "38.10.71.214", Description: "AS208972 - Gibirnet Iletisim Hizmetleri Sanayi Ve Ticaret Limited Sirketi (Turkey, Sanliurfa)"},
+	{IP: "81.214.36.153", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "85.105.252.93", Description: "AS47331 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "94.102.13.152", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "91.151.88.109", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "78.186.173.215", Description: "AS9121 - Turk Telekom (Turkey, Mugla)"},
+	{IP: "78.189.16.71", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "94.73.156.76", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "213.74.249.98", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Çanakkale)"},
+	{IP: "94.73.156.78", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "212.58.26.33", Description: "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
+	{IP: "185.208.102.147", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Kilis)"},
+	{IP: "81.214.12.150", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "81.213.150.85", Description: "AS47331 - Turk Telekom (Turkey, Nilufer)"},
+	{IP: "78.188.63.155", Description: "AS47331 - Turk Telekom (Turkey, Elâzığ)"},
+	{IP: "94.124.73.153", Description: "AS208095 - Internetten Teknoloji Bil.san.tic.ltd.sti. (Turkey)"},
+	{IP: "78.189.46.123", Description: "AS9121 - Turk Telekom (Turkey, Avcilar)"},
+	{IP: "31.210.52.168", Description: "AS49334 - Sh Online Iletisim Anonim Sirketi (Turkey)"},
+	{IP: "82.222.60.46", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "95.8.200.28", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "85.108.206.75", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "185.85.207.35", Description: "AS201079 - AKA Bilisim Yazilim Arge Ins. Taah. San. Tic. A.S. (Turkey)"},
+	{IP: "185.140.125.220", Description: "AS57152 - Teknet Yazlim Ve Bilgisayar Teknolojileri (Turkey)"},
+	{IP: "188.132.221.60", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antakya)"},
+	{IP: "185.15.198.12", Description: "AS201520 - Dedicated Telekomunikasyon Teknoloji Hiz. Tic. San. LTD. STI. (Turkey)"},
+	{IP: "78.188.246.235", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "95.142.132.78", Description: "AS49840 - Enson Net Ltd (Turkey)"},
+	{IP: "88.249.68.14", Description: "AS47331 - Turk Telekom (Turkey, Niğde)"},
+	{IP: "176.236.77.102", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "89.43.31.10", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "89.252.167.61", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "77.75.35.138", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "78.189.180.162", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "95.9.95.194", Description: "AS9121 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "193.192.113.146", Description: "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "82.222.49.18", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.227.153.194", Description: "AS47331 - Turk Telekom (Turkey, Cankaya)"},
+	{IP: "95.9.241.172", Description: "AS47331 - Turk Telekom (Turkey, Adana)"},
+	{IP: "88.234.22.129", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "194.124.36.207", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antalya)"},
+	{IP: "85.105.18.139", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "95.173.162.124", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "78.187.231.245", Description: "AS47331 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "85.106.31.119", Description: "AS9121 - Turk Telekom (Turkey, Diyarbakır)"},
+	{IP: "213.14.66.51", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Izmir)"},
+	{IP: "185.169.183.54", Description: "AS206119 - Veganet Teknolojileri ve Hizmetleri LTD STI (Turkey, Istanbul)"},
+	{IP: "95.0.226.132", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "94.102.6.240", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "94.102.7.90", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "185.8.12.24", Description: "AS61345 - Flytom Networks Ltd (Turkey, Mersin)"},
+	{IP: "89.19.8.118", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "81.213.79.71", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "95.173.162.118", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "80.93.216.18", Description: "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
+	{IP: "94.101.87.175", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "85.99.244.122", Description: "AS9121 - Turk Telekom (Turkey, Fethiye)"},
+	{IP: "185.208.102.49", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Kilis)"},
+	{IP: "94.124.73.151", Description: "AS208095 - Internetten Teknoloji Bil.san.tic.ltd.sti. (Turkey)"},
+	{IP: "78.188.58.228", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.189.229.223", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "77.92.133.5", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "88.249.58.86", Description: "AS47331 - Turk Telekom (Turkey, Kayseri)"},
+	{IP: "176.88.10.83", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Sisli)"},
+	{IP: "77.75.37.178", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "194.124.36.201", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antalya)"},
+	{IP: "77.223.142.102", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "78.186.206.121", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "176.53.35.127", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "95.9.190.34", Description: "AS9121 - Turk Telekom (Turkey, Konya)"},
+	{IP: "92.45.47.114", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "176.88.18.87", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Ankara)"},
+	{IP: "77.223.128.221", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "213.194.123.26", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Antalya)"},
+	{IP: "78.111.106.91", Description: "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
+	{IP: "77.75.35.139", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "78.182.254.16", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "89.19.16.37", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "88.227.51.145", Description: "AS9121 - Turk Telekom (Turkey, Basaksehir)"},
+	{IP: "85.153.132.196", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "85.95.240.30", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "78.188.115.188", Description: "AS9121 - Turk Telekom (Turkey, Fatih)"},
+	{IP: "193.192.121.230", Description: "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "212.57.11.185", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mugla)"},
+	{IP: "78.135.102.237", Description: "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
+	{IP: "77.92.138.166", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "88.250.243.205", Description: "AS9121 - Turk Telekom (Turkey, Mugla)"},
+	{IP: "90.158.111.168", Description: "AS9021 - Is Net Elektonik Bilgi Uretim Dagitim Ticaret ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "38.242.147.147", Description: "AS51167 - Contabo GmbH (Turkey, Diyarbakır)"},
+	{IP: "78.188.181.64", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.189.127.251", Description: "AS47331 - Turk Telekom (Turkey, Adana)"},
+	{IP: "93.94.252.171", Description: "AS47123 - TI Sparkle Turkey Telekomunukasyon A.S (Turkey)"},
+	{IP: "88.236.253.84", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.242.0.188", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "84.44.14.35", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "78.135.85.37", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "194.124.36.68", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antalya)"},
+	{IP: "212.68.34.235", Description: "AS212219 - Talha Bogaz (Turkey)"},
+	{IP: "37.148.213.124", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "94.73.160.131", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "185.33.232.4", Description: "AS51557 - Isimtescil Bilisim A.S. (Turkey)"},
+	{IP: "95.9.108.212", Description: "AS9121 - Turk Telekom (Turkey, Osmaniye)"},
+	{IP: "195.46.151.9", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "93.184.152.105", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
+	{IP: "85.95.242.71", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "85.99.232.65", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "84.51.47.66", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Osmaniye)"},
+	{IP: "95.9.130.102", Description: "AS9121 - Turk Telekom (Turkey, Kahramanmaraş)"},
+	{IP: "77.92.133.11", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "176.235.221.96", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "78.188.215.107", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "185.22.187.150", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "31.210.78.210", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "195.142.153.122", Description: "AS199484 - SAGLAYICI Teknoloji Bilisim Yayincilik Hiz. Ticaret Ltd. Sti. (Turkey, Istanbul)"},
+	{IP: "77.223.128.218", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "85.95.238.173", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "88.250.66.143", Description: "AS9121 - Turk Telekom (Turkey, Adapazarı)"},
+	{IP: "78.186.131.161", Description: "AS9121 - Turk Telekom (Turkey, Kadıköy)"},
+	{IP: "45.156.31.140", Description: "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
+	{IP: "94.138.223.150", Description: "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
+	{IP: "94.102.6.241", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "213.14.66.54", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Izmir)"},
+	{IP: "78.186.134.59", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "77.90.131.59", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kadıköy)"},
+	{IP: "91.191.170.4", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "81.213.148.253", Description: "AS47331 - Turk Telekom (Turkey, Nilufer)"},
+	{IP: "89.19.22.46", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "88.249.166.158", Description: "AS9121 - Turk Telekom (Turkey, Çanakkale)"},
+	{IP: "78.184.250.152", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "176.236.139.87", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mersin)"},
+	{IP: "78.179.111.225", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "176.88.112.53", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mugla)"},
+	{IP: "185.117.123.41", Description: "AS213145 - Fibim Fibernet Gsm Sanayi Ve Ticaret Anonim Sirketi (Turkey, Alanya)"},
+	{IP: "78.186.36.168", Description: "AS47331 - Turk Telekom (Turkey, Cankaya)"},
+	{IP: "95.173.162.126", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "93.184.152.43", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
+	{IP: "193.36.63.184", Description: "AS201086 - ServerPlusInternet Sunucu Hizmetleri (Turkey, Bursa)"},
+	{IP: "78.186.159.117", Description: "AS47331 - Turk Telekom (Turkey, Etimesgut)"},
+	{IP: "85.100.138.3", Description: "AS47331 - Turk Telekom (Turkey, Adana)"},
+	{IP: "78.188.10.14", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "185.226.160.76", Description: "AS205192 - Omur Bilisim Teknolojileri (Turkey, Ankara)"},
+	{IP: "93.184.152.109", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
+	{IP: "92.45.47.27", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "89.252.173.104", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "176.88.18.88", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Ankara)"},
+	{IP: "195.87.69.30", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bursa)"},
+	{IP: "85.98.40.40", Description: "AS47331 - Turk Telekom (Turkey)"},
+	{IP: "78.189.111.211", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "94.124.73.139", Description: "AS208095 - Internetten Teknoloji Bil.san.tic.ltd.sti. (Turkey)"},
+	{IP: "91.93.64.53", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "88.250.238.6", Description: "AS9121 - Turk Telekom (Turkey, Menemen)"},
+	{IP: "88.228.16.148", Description: "AS9121 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "78.189.47.69", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.111.106.90", Description: "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
+	{IP: "93.177.103.194", Description: "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
+	{IP: "84.44.14.37", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "78.189.237.37", Description: "AS9121 - Turk Telekom (Turkey, Akhisar)"},
+	{IP: "176.88.181.98", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Adana)"},
+	{IP: "84.44.9.10", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "93.184.152.108", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Istanbul)"},
+	{IP: "91.93.131.69", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Eyuepsultan)"},
+	{IP: "78.186.49.28", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "94.102.8.154", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "212.125.11.12", Description: "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.248.55.71", Description: "AS9121 - Turk Telekom (Turkey, Mugla)"},
+	{IP: "88.248.56.216", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "89.19.7.213", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "88.247.206.159", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "82.222.152.165", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "195.46.154.156", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "91.102.162.194", Description: "AS41801 - Datafon Teknoloji San.Tic.Ltd.Sti. (Turkey)"},
+	{IP: "217.65.177.83", Description: "AS34296 - Millenicom Telekomunikasyon Hizmetleri Anonim Sirketi (Turkey, Kayseri)"},
+	{IP: "92.45.25.227", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Akhisar)"},
+	{IP: "93.184.146.30", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Kırklareli)"},
+	{IP: "78.186.194.187", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "188.132.221.58", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antakya)"},
+	{IP: "213.74.195.52", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Cankaya)"},
+	{IP: "78.188.205.173", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "92.45.200.125", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "185.233.247.205", Description: "AS206119 - Veganet Teknolojileri ve Hizmetleri LTD STI (Turkey)"},
+	{IP: "194.146.50.241", Description: "AS200456 - Verigom Telekomunikasyon Limited Sirketi (Turkey)"},
+	{IP: "95.173.162.111", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "85.105.94.129", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "81.215.214.136", Description: "AS9121 - Turk Telekom (Turkey, Tokat Province)"},
+	{IP: "213.155.107.188", Description: "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
+	{IP: "94.54.12.73", Description: "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Mersin)"},
+	{IP: "88.247.165.243", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "88.248.195.254", Description: "AS9121 - Turk Telekom (Turkey, Muratpasa)"},
+	{IP: "176.53.35.120", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "88.248.104.242", Description: "AS9121 - Turk Telekom (Turkey, Edremit)"},
+	{IP: "94.73.154.204", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "91.93.131.70", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Eyuepsultan)"},
+	{IP: "85.106.20.174", Description: "AS9121 - Turk Telekom (Turkey, Cekmekoey)"},
+	{IP: "94.54.88.208", Description: "AS47524 - Turksat Uydu Haberlesme ve Kablo TV Isletme A.S. (Turkey, Ankara)"},
+	{IP: "81.214.73.194", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "94.102.76.212", Description: "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
+	{IP: "85.102.255.171", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "81.214.69.83", Description: "AS9121 - Turk Telekom (Turkey, Samsun)"},
+	{IP: "93.184.146.28", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Kırklareli)"},
+	{IP: "93.113.63.27", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "85.97.199.245", Description: "AS9121 - Turk Telekom (Turkey, Denizli)"},
+	{IP: "94.101.87.231", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "78.189.29.186", Description: "AS9121 - Turk Telekom (Turkey, Kartal)"},
+	{IP: "82.150.94.174", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "195.46.129.74", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Alanya)"},
+	{IP: "88.249.51.252", Description: "AS9121 - Turk Telekom (Turkey, Alanya)"},
+	{IP: "78.186.120.157", Description: "AS47331 - Turk Telekom (Turkey, Adalar)"},
+	{IP: "185.81.153.147", Description: "AS202505 - Netbudur Telekomunikasyon Limited Sirketi (Turkey)"},
+	{IP: "88.245.96.166", Description: "AS9121 - Turk Telekom (Turkey, Kütahya)"},
+	{IP: "78.189.137.251", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.248.51.121", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "85.99.234.230", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.250.55.67", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "81.214.254.111", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "92.45.47.30", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "95.9.81.100", Description: "AS47331 - Turk Telekom (Turkey, Kayseri)"},
+	{IP: "85.95.244.88", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "31.169.79.37", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "92.44.191.15", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Nilufer)"},
+	{IP: "94.138.207.90", Description: "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
+	{IP: "85.159.71.156", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "77.245.158.121", Description: "AS42868 - Niobe Bilisim Teknolojileri Yazilim San. Tic. Ltd. Sti. (Turkey)"},
+	{IP: "91.241.49.28", Description: "AS209828 - Genc BT Bilisim Teknolojileri Limited Sirketi (Turkey)"},
+	{IP: "91.191.173.202", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "88.250.224.28", Description: "AS9121 - Turk Telekom (Turkey, Bursa)"},
+	{IP: "78.188.7.231", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "188.132.193.152", Description: "AS201233 - Yonca Duran (Turkey)"},
+	{IP: "94.102.6.239", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "81.214.141.75", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "176.53.10.136", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "82.222.48.19", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "77.92.155.196", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "213.238.172.225", Description: "AS60707 - Kapteyan Bilisim Teknolojileri San. ve Tic. A.S. (Turkey, Istanbul)"},
+	{IP: "85.95.242.120", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "91.93.132.36", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "94.101.82.233", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "91.151.94.246", Description: "AS211560 - Muhammet Ugur Ozturk (Turkey)"},
+	{IP: "94.102.2.244", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "213.14.11.162", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Yildirim)"},
+	{IP: "31.145.137.156", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Bursa)"},
+	{IP: "31.210.69.163", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "78.188.22.60", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "92.44.44.184", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "212.57.29.100", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "88.247.151.174", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "185.48.180.218", Description: "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
+	{IP: "85.95.238.113", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "95.9.37.245", Description: "AS9121 - Turk Telekom (Turkey, Karatay)"},
+	{IP: "91.93.58.175", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Adana)"},
+	{IP: "212.98.194.132", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Seyhan)"},
+	{IP: "95.6.86.31", Description: "AS9121 - Turk Telekom (Turkey, Soeke)"},
+	{IP: "95.173.162.85", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "85.102.10.64", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "81.8.30.98", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kadirli)"},
+	{IP: "94.138.206.14", Description: "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
+	{IP: "185.200.36.93", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Reyhanli)"},
+	{IP: "85.95.238.114", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "78.186.138.124", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "94.73.166.124", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "194.145.138.232", Description: "AS204457 - Atlantis Telekomunikasyon Bilisim Hizmetleri San. Tic. Ltd (Turkey, Istanbul)"},
+	{IP: "81.8.106.42", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Talas)"},
+	{IP: "78.188.42.166", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.188.37.78", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.186.60.77", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.250.245.64", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "81.213.149.81", Description: "AS47331 - Turk Telekom (Turkey, Kayapinar)"},
+	{IP: "85.95.242.156", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "78.189.139.221", Description: "AS47331 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "93.184.146.27", Description: "AS47288 - FIXNET Telekomunikasyon Limited Sirketi (Turkey, Kırklareli)"},
+	{IP: "85.97.195.137", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "185.85.204.177", Description: "AS201079 - AKA Bilisim Yazilim Arge Ins. Taah. San. Tic. A.S. (Turkey)"},
+	{IP: "85.99.242.115", Description: "AS9121 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "91.93.153.74", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "85.105.171.92", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.111.106.93", Description: "AS20649 - FS Veri Merkezi Internet Teknolojileri Limited Sirketi (Turkey)"},
+	{IP: "176.53.35.113", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "94.138.223.151", Description: "AS49126 - IHS Kurumsal Teknoloji Hizmetleri A.S (Turkey)"},
+	{IP: "88.248.99.53", Description: "AS9121 - Turk Telekom (Turkey, Balıkesir)"},
+	{IP: "78.186.25.166", Description: "AS47331 - Turk Telekom (Turkey)"},
+	{IP: "95.9.233.135", Description: "AS47331 - Turk Telekom (Turkey)"},
+	{IP: "77.79.92.164", Description: "AS39582 - Grid Telekomunikasyon Hizmetleri AS (Turkey, Zonguldak)"},
+	{IP: "88.247.20.13", Description: "AS9121 - Turk Telekom (Turkey, Karatay)"},
+	{IP: "94.73.156.77", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "81.215.63.47", Description: "AS47331 - Turk Telekom (Turkey, Van)"},
+	{IP: "78.186.129.210", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "93.177.103.34", Description: "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
+	{IP: "84.51.15.20", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Konya)"},
+	{IP: "188.132.205.174", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "93.177.103.23", Description: "AS207326 - HostLAB Bilisim Teknolojileri A.S. (Turkey, Istanbul)"},
+	{IP: "95.6.70.227", Description: "AS9121 - Turk Telekom (Turkey, Bagcilar)"},
+	{IP: "85.105.122.250", Description: "AS47331 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "90.158.200.15", Description: "AS9021 - Is Net Elektonik Bilgi Uretim Dagitim Ticaret ve Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "78.135.113.203", Description: "AS42910 - PremierDC Veri Merkezi Anonim Sirketi (Turkey)"},
+	{IP: "81.22.109.110", Description: "AS48737 - Dorabase Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "85.95.239.215", Description: "AS206991 - Iksir Internet Hizmetleri A.S. (Turkey)"},
+	{IP: "178.250.88.190", Description: "AS29399 - Ramtek Telekomunikasyon Hizmetleri Sanayi Ve Ticaret Limited Sirketi (Turkey, Yalova)"},
+	{IP: "88.250.63.46", Description: "AS47331 - Turk Telekom (Turkey, Van)"},
+	{IP: "78.189.189.80", Description: "AS9121 - Turk Telekom (Turkey, Atakum)"},
+	{IP: "88.250.204.241", Description: "AS9121 - Turk Telekom (Turkey, Magnesia ad Sipylum)"},
+	{IP: "78.188.74.191", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "95.9.84.66", Description: "AS9121 - Turk Telekom (Turkey, Kayseri)"},
+	{IP: "94.73.154.205", Description: "AS34619 - Cizgi Telekomunikasyon Anonim Sirketi (Turkey)"},
+	{IP: "95.173.162.122", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "89.252.129.2", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey, Istanbul)"},
+	{IP: "95.173.184.115", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "185.169.183.130", Description: "AS206119 - Veganet Teknolojileri ve Hizmetleri LTD STI (Turkey, Istanbul)"},
+	{IP: "79.98.134.213", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "212.64.215.122", Description: "AS61135 - Comnet Bilgi Iletisim Teknolojileri Ticaret A.s. (Turkey)"},
+	{IP: "85.105.216.109", Description: "AS9121 - Turk Telekom (Turkey, Konya)"},
+	{IP: "91.230.149.174", Description: "AS212301 - Makdos Bilisim Teknolojileri Sanayi Ticaret Limited Sirketi (Turkey)"},
+	{IP: "81.215.2.60", Description: "AS47331 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "79.98.134.214", Description: "AS42926 - Radore Veri Merkezi Hizmetleri A.S. (Turkey)"},
+	{IP: "91.93.153.73", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "81.214.70.155", Description: "AS9121 - Turk Telekom (Turkey, Samsun)"},
+	{IP: "95.173.162.75", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "195.46.129.72", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Alanya)"},
+	{IP: "88.249.37.14", Description: "AS47331 - Turk Telekom (Turkey, Batman)"},
+	{IP: "194.27.101.249", Description: "AS211249 - Yildiz Teknik Universitesi (Turkey)"},
+	{IP: "95.0.124.163", Description: "AS9121 - Turk Telekom (Turkey)"},
+	{IP: "85.105.252.11", Description: "AS47331 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "78.186.58.171", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.186.191.47", Description: "AS9121 - Turk Telekom (Turkey, Bursa)"},
+	{IP: "81.214.12.7", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.247.12.187", Description: "AS9121 - Turk Telekom (Turkey, Ankara)"},
+	{IP: "195.33.213.254", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Adapazarı)"},
+	{IP: "78.186.166.25", Description: "AS9121 - Turk Telekom (Turkey, Bursa)"},
+	{IP: "84.44.32.43", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Istanbul)"},
+	{IP: "81.214.85.50", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "88.249.65.185", Description: "AS9121 - Turk Telekom (Turkey, Yusufeli)"},
+	{IP: "78.189.87.241", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "77.223.128.220", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "77.223.128.222", Description: "AS43391 - Netdirekt A.S. (Turkey)"},
+	{IP: "85.105.160.195", Description: "AS47331 - Turk Telekom (Turkey, Kartal)"},
+	{IP: "78.188.42.52", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "212.154.19.244", Description: "AS12735 - TurkNet Iletisim Hizmetleri A.S. (Turkey, Istanbul)"},
+	{IP: "81.8.106.45", Description: "AS15924 - Vodafone Net Iletisim Hizmetleri Anonim Sirketi (Turkey, Kayseri)"},
+	{IP: "212.57.11.190", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey, Mugla)"},
+	{IP: "81.214.190.74", Description: "AS47331 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "78.186.163.250", Description: "AS47331 - Turk Telekom (Turkey, Pamukkale)"},
+	{IP: "81.214.54.88", Description: "AS9121 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "176.236.31.156", Description: "AS34984 - Superonline Iletisim Hizmetleri A.S. (Turkey)"},
+	{IP: "94.102.2.15", Description: "AS51559 - Netinternet Bilisim Teknolojileri AS (Turkey)"},
+	{IP: "78.186.147.181", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "85.99.244.74", Description: "AS47331 - Turk Telekom (Turkey, Izmir)"},
+	{IP: "85.105.81.252", Description: "AS47331 - Turk Telekom (Turkey, Istanbul)"},
+	{IP: "78.186.191.171", Description: "AS47331 - Turk Telekom (Turkey, Bursa)"},
+	{IP: "188.132.203.3", Description: "AS202561 - High Speed Telekomunikasyon ve Hab. Hiz. Ltd. Sti. (Turkey, Antakya)"},
+	{IP: "78.135.102.232", Description: "AS8685 - Doruk Iletisim ve Otomasyon Sanayi ve Ticaret A.S. (Turkey)"},
 }*/
 
 func main() {
 	var (
-		listFile    = flag.String("list", "", "DNS server list file (optional)")
-		domainsFile = flag.String("domains", "", "Domain list file (optional)")
-		outputFile  = flag.String("output", "", "Output file for results (optional, defaults to stdout)")
-		helpFlag    = flag.Bool("help", false, "Show help")
-		formatFlag  = flag.String("format", DefaultFormat, "Output format: json, text")
-		timeoutFlag = flag.Int("timeout", DefaultTimeout, "Timeout in seconds for DNS queries")
-		workersFlag = flag.Int("workers", DefaultWorkerCount, "Number of concurrent workers")
+		listFile            = flag.String("list", "", "DNS server list file (optional)")
+		domainsFile         = flag.String("domains", "", "Domain list file (optional)")
+		helpFlag            = flag.Bool("help", false, "Show help")
+		formatFlag          = flag.String("format", DefaultFormat, "Output format: json, text, loki, ndjson, server-csv, scoreboard, iplist")
+		timeoutFlag         = flag.Int("timeout", DefaultTimeout, "Timeout in seconds for DNS queries")
+		retriesFlag         = flag.Int("retries", DefaultRetries, "Retries of a query failing with a timeout or network error, with backoff")
+		workersFlag         = flag.Int("workers", DefaultWorkerCount, "Number of concurrent workers")
+		gzipFlag            = flag.Bool("gzip", false, "Gzip-compress the output file (implied by a .gz extension)")
+		recurseFlag         = flag.Bool("check-recursion", false, "Check whether each server recurses for uncached names")
+		appendFlag          = flag.Bool("append", false, "Append this run to the JSON array in the output file")
+		parallelFlag        = flag.String("parallel-over", ParallelOverAll, "Dispatch strategy: all, servers, domains")
+		compareFlag         = flag.String("compare-servers", "", "Compare two DNS servers head-to-head (comma-separated, in server list syntax)")
+		geoipFlag           = flag.String("geoip", "", "MaxMind-style .mmdb database(s) for country/ASN enrichment (comma-separated)")
+		strictFlag          = flag.Bool("strict", false, "Treat any invalid or malformed list entry as a fatal error")
+		queryTypeFlag       = flag.String("query-type", "A", "Record type(s) to query, comma-separated: A, AAAA, MX, TXT, NS, CNAME, SOA, PTR, ANY")
+		qpsFlag             = flag.Int("qps", 0, "Maximum queries per second across all workers (0 for unlimited)")
+		perServerFlag       = flag.Int("max-per-server", 0, "Maximum concurrent queries per server (0 for unlimited)")
+		jitterFlag          = flag.Duration("jitter", 0, "Random delay of up to this duration before each query")
+		politeFlag          = flag.Bool("polite", false, "Use conservative rate limits suitable for scanning public resolvers")
+		sampleFlag          = flag.Float64("sample-percent", 0, "Randomly test only this percentage of server/domain pairs")
+		sampleSeedFlag      = flag.Int64("sample-seed", 0, "Seed for --sample-percent (random when 0)")
+		samplesFlag         = flag.Int("samples", 1, "Number of queries per server/domain pair")
+		emitSamplesFlag     = flag.Bool("emit-samples", false, "Include every sample latency in the JSON output")
+		excludeFile         = flag.String("exclude-servers", "", "File of server IPs to skip, one per line")
+		excludeFlag         = flag.String("exclude", "", "Comma-separated server IPs to skip")
+		noColorFlag         = flag.Bool("no-color", false, "Disable colored text output")
+		noRecurseFlag       = flag.Bool("no-recurse", false, "Clear the RD bit so servers only answer from cache or their own zones")
+		lossProbeFlag       = flag.Int("loss-probe", 0, "Send this many identical queries per server to measure UDP packet loss")
+		jsonLayoutFlag      = flag.String("json-layout", JSONLayoutFlat, "JSON layout: flat, nested (server -> category -> results)")
+		deadlineFlag        = flag.Duration("deadline", 0, "Time budget for --samples; slow servers get fewer samples (e.g. 5m)")
+		percentileFlag      = flag.String("percentile-method", PercentileLinear, "Percentile interpolation: linear, nearest (nearest-rank)")
+		wildcardFlag        = flag.Bool("check-wildcard", false, "Check whether each server resolves random nonexistent subdomains")
+		templateFlag        = flag.String("template", "", "Render the results through this Go text/template file instead of --format")
+		granularityFlag     = flag.String("compare-granularity", "exact", "Compare resolved IPs exactly or by network prefix, e.g. /24 (--compare-servers, --quorum and server pairs)")
+		firstSuccessFlag    = flag.Bool("first-success", false, "Only check whether any server resolves each domain, stopping at the first success")
+		successRcodesFlag   = flag.String("success-rcodes", "", "Comma-separated RCODEs counted as success, e.g. NOERROR,NXDOMAIN")
+		rateProbeFlag       = flag.Bool("rate-limit-probe", false, "Raise the query rate to each server step by step to find its rate limit")
+		rateMaxFlag         = flag.Int("rate-limit-max", DefaultRateLimitMax, "Highest QPS sent to a server by --rate-limit-probe")
+		sortByFlag          = flag.String("sort-by", SortByIP, "Server order in the detailed output: ip, latency, success")
+		cookieFlag          = flag.Bool("check-cookies", false, "Check whether each server supports DNS cookies (RFC 7873)")
+		checkpointFlag      = flag.String("checkpoint", "", "Save progress to this file and resume from it after an interruption")
+		dryRunFlag          = flag.Bool("dry-run", false, "Validate the inputs and print the test plan without sending queries")
+		slaFlag             = flag.Duration("latency-sla", 0, "Mark servers whose p95 (or average) latency exceeds this, e.g. 50ms")
+		slaMetricFlag       = flag.String("latency-sla-metric", SLAMetricP95, "Latency compared against --latency-sla: p95, avg")
+		listFormatFlag      = flag.String("list-format", ListFormatAuto, "Format of the --list file: auto, plain, unbound, bind")
+		secondPassFlag      = flag.Bool("second-pass", false, "Retry the failed server/domain pairs once after the run")
+		minTTLFlag          = flag.String("min-ttl-probe", "", "Domain with a low authoritative TTL used to detect servers enforcing a minimum TTL")
+		coldWarmFlag        = flag.Bool("cold-warm", false, "Report cold (first) and warm (cached) latency per pair; implies --samples 3")
+		sourceIPFlag        = flag.String("source-ip", "", "Local IP address to send queries from")
+		interfaceFlag       = flag.String("interface", "", "Network interface to send queries from (uses its first address)")
+		protocolFlag        = flag.String("protocol", ProtocolUDP, "Transport for test queries: udp, tcp, tls (DNS-over-TLS), quic (DNS-over-QUIC), https (DNS-over-HTTPS)")
+		intervalFlag        = flag.Duration("interval", 0, "Repeat the run every interval as a monitoring cycle (e.g. 5m)")
+		cyclesFlag          = flag.Int("cycles", 0, "Stop monitoring after this many cycles (default: until interrupted)")
+		flakyFlipsFlag      = flag.Int("flaky-flips", 3, "Up/down changes within the last 10 cycles that flag a server as flaky")
+		adaptiveTimeoutFlag = flag.Float64("adaptive-timeout", 0, "Calibrate each server and use this multiple of its median response time as its timeout (e.g. 3)")
+		spillDirFlag        = flag.String("spill-dir", "", "Spill the full results to sorted temp files in this directory for ndjson output, keeping compact copies in memory")
+		filterServersFlag   = flag.String("filter-servers", "", "Only report servers meeting all conditions, e.g. success>=99,p95<=50ms,adblock>=90,dnssec,no-hijack")
+		machineFlag         = flag.Bool("machine", false, "Print only the structured results: no progress bar or info and warning messages, JSON by default")
+		preferFlag          = flag.String("prefer", PreferIPv4, "Records that count as an answer to A queries: ipv4, dual (fall back to AAAA)")
+		alertBelowFlag      = flag.Float64("alert-below", 0, "Alert when a server's success rate stays below this percentage for --alert-cycles cycles")
+		alertCyclesFlag     = flag.Int("alert-cycles", 3, "Consecutive cycles below --alert-below before alerting")
+		alertWebhookFlag    = flag.String("alert-webhook", "", "POST alerts as JSON to this URL instead of exiting")
+		mergeDupsFlag       = flag.Bool("merge-duplicates", false, "Test servers listed more than once a single time, combining their descriptions")
+		failCriticalFlag    = flag.Bool("fail-on-critical", false, "Exit with status 3 when a critical domain fails on a majority of servers")
+		quorumFlag          = flag.String("quorum", "", "Reference resolvers (comma-separated, at least 3) whose majority answer flags disagreeing servers")
+		summaryPosFlag      = flag.String("summary-position", SummaryBoth, "Where the text output prints the summary: top, bottom, both")
+		ipDistributionFlag  = flag.Bool("ip-distribution", false, "Report, per domain, the resolved addresses and the servers returning each")
+		expectedZoneFlag    = flag.String("expected-zone", "", "Zone file whose A/AAAA records are the expected answers; other answers are flagged")
+		connectionStatsFlag = flag.Bool("connection-stats", false, "Report, per server, connections established versus reused (tcp and tls protocols)")
+		insecureFlag        = flag.Bool("insecure-skip-verify", false, "Don't verify server certificates (tls, quic and https protocols)")
+		domainFlag          = flag.String("domain", "", "Test only this domain, instead of a domain list")
+		liveFlag            = flag.Bool("live", false, "With --domain, show the servers ranked by response time as their answers arrive")
+		timeSeriesFlag      = flag.String("timeseries-dir", "", "Append each cycle's average latency and success rate per server to <ip>.csv in this directory")
+		transportDeltaFlag  = flag.Bool("transport-delta", false, "Also query every server over UDP and TCP and report the TCP latency penalty per server")
+		minAnswersFlag      = flag.Int("min-answers", 1, "Only count a response as successful with at least this many answer records of the queried type")
+		includeSectionsFlag = flag.Bool("include-sections", false, "Record a summary of the authority and additional sections of every response")
+		emaAlphaFlag        = flag.Float64("ema-alpha", 0, "Smoothing factor (0-1] of a moving average of each server's success rate across cycles, used for ranking and alerting")
+		qnameMinFlag        = flag.Bool("check-qname-min", false, "Check whether each server uses QNAME minimization (RFC 9156)")
+		groupByFlag         = flag.String("group-by", GroupByNone, "Group the servers by: country (from --geoip or the 'XX - ' description prefix)")
+		baselineFlag        = flag.String("baseline", "", "Previous --format json output to compare with; exit with status 4 when a server regressed")
+		regressionFlag      = flag.String("regression-threshold", "20%", "Latency increase over --baseline that counts as a regression")
+		bootstrapFlag       = flag.String("bootstrap", "", "Resolver IP[:PORT] used to look up hostnames in the server list (default: system resolver)")
+		maxConcurrencyFlag  = flag.Int("max-concurrency", 0, "Maximum concurrent queries and open sockets in total, including pooled connections (0 for unlimited)")
+		interceptionFlag    = flag.Bool("check-interception", false, "Check whether the network intercepts port 53, answering queries meant for public resolvers")
+		minSuccessRateFlag  = flag.Float64("min-success-rate", DefaultMinSuccessRate, "Success rate (%) a server needs to be listed by --format iplist")
+		typeFlag            = flag.String("type", "", "Record type to query, same as --query-type")
+		ipv6Flag            = flag.Bool("ipv6", false, "Also query AAAA for every domain and record both address families")
 	)
 
+	var outputFlags outputList
+	flag.Var(&outputFlags, "output", "Output destination PATH[:FORMAT], repeatable, - for stdout (defaults to stdout)")
+
 	flag.Parse()
 
 	if *helpFlag {
@@ -757,425 +1055,2650 @@ func main() {
 		return
 	}
 
-	// Load DNS servers
-	var dnsServers []DNSServer
-	if *listFile != "" {
-		servers, err := loadDNSServersFromFile(*listFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading DNS servers from file: %v\n", err)
-			os.Exit(1)
+	if *machineFlag {
+		infoOutput = io.Discard
+		formatSet := false
+		flag.Visit(func(f *flag.Flag) { formatSet = formatSet || f.Name == "format" })
+		if !formatSet {
+			*formatFlag = "json"
 		}
-		dnsServers = servers
-	} else {
-		dnsServers = defaultDNSServers
-		fmt.Fprintf(os.Stderr, "Using default DNS servers list\n")
 	}
 
-	// Load domains
-	var domains []DomainCategory
-	if *domainsFile != "" {
-		domainsFromFile, err := loadDomainsFromFile(*domainsFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading domains from file: %v\n", err)
-			os.Exit(1)
+	outputOpts := OutputOptions{
+		Format:   *formatFlag,
+		Compress: *gzipFlag,
+		Append:   *appendFlag,
+		Layout:   *jsonLayoutFlag,
+		SortBy:   *sortByFlag,
+		Summary:  *summaryPosFlag,
+		GroupBy:  *groupByFlag,
+
+		MinSuccessRate: *minSuccessRateFlag,
+	}
+	// Polite mode only fills in the limits that were not set explicitly
+	if *politeFlag {
+		explicit := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+		if !explicit["workers"] {
+			*workersFlag = PoliteWorkers
+		}
+		if !explicit["qps"] {
+			*qpsFlag = PoliteQPS
+		}
+		if !explicit["max-per-server"] {
+			*perServerFlag = PoliteMaxPerServer
+		}
+		if !explicit["jitter"] {
+			*jitterFlag = PoliteJitter
 		}
-		domains = domainsFromFile
-		fmt.Fprintf(os.Stderr, "Using domains from file: %s\n", *domainsFile)
-	} else {
-		domains = defaultDomains
-		fmt.Fprintf(os.Stderr, "Using default domains list\n")
 	}
 
-	fmt.Fprintf(os.Stderr, "Testing %d DNS servers against %d domains...\n", len(dnsServers), len(domains))
+	if *typeFlag != "" {
+		queryTypeSet := false
+		flag.Visit(func(f *flag.Flag) { queryTypeSet = queryTypeSet || f.Name == "query-type" })
+		if queryTypeSet && !strings.EqualFold(*typeFlag, *queryTypeFlag) {
+			fmt.Fprintf(os.Stderr, "Error: --type and --query-type disagree (%s, %s)\n", *typeFlag, *queryTypeFlag)
+			os.Exit(1)
+		}
+		*queryTypeFlag = *typeFlag
+	}
+	queryTypes, err := parseQueryTypes(*queryTypeFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	queryType := queryTypes[0]
 
-	// Run tests
-	results := runDNSTests(dnsServers, domains, time.Duration(*timeoutFlag)*time.Second, *workersFlag)
+	successRcodes, err := parseSuccessRcodes(*successRcodesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Output results
-	if err := outputResults(results, *outputFile, *formatFlag); err != nil {
-		fmt.Fprintf(os.Stderr, "Error outputting results: %v\n", err)
+	sourceIP, err := sourceAddress(*sourceIPFlag, *interfaceFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-}
 
-func printHelp() {
-	fmt.Println("DNS Check Tool")
-	fmt.Println("Usage: dns-check-go [options]")
-	fmt.Println("")
-	fmt.Println("Options:")
-	fmt.Println("  --list <file>      DNS server list file (IP per line, optional description after space)")
-	fmt.Println("  --domains <file>   Domain list file (domain per line, optional category after space)")
-	fmt.Println("  --output <file>    Output file for results (default: stdout)")
-	fmt.Printf("  --format <format>  Output format: json, text (default: %s)\n", DefaultFormat)
-	fmt.Printf("  --timeout <sec>    Timeout for DNS queries in seconds (default: %d)\n", DefaultTimeout)
-	fmt.Printf("  --workers <num>    Number of concurrent workers (default: %d)\n", DefaultWorkerCount)
-	fmt.Println("  --help            Show this help message")
-	fmt.Println("")
-	fmt.Println("Examples:")
-	fmt.Println("  go run . --list ./dns-servers.txt --domains ./domains.txt --output ./results.json")
-	fmt.Println("  go run . --output ./results.txt --format text")
-	fmt.Println("  go run .  (uses default DNS servers and domains)")
-}
+	testOpts := TestOptions{
+		Timeout:          time.Duration(*timeoutFlag) * time.Second,
+		Retries:          *retriesFlag,
+		Workers:          *workersFlag,
+		ParallelOver:     *parallelFlag,
+		QueryType:        queryType,
+		QueryTypes:       queryTypes,
+		QPS:              *qpsFlag,
+		MaxPerServer:     *perServerFlag,
+		MaxConcurrency:   *maxConcurrencyFlag,
+		Jitter:           *jitterFlag,
+		SamplePercent:    *sampleFlag,
+		SampleSeed:       *sampleSeedFlag,
+		Samples:          *samplesFlag,
+		Deadline:         *deadlineFlag,
+		PercentileMethod: *percentileFlag,
+		SuccessRcodes:    successRcodes,
+		Checkpoint:       *checkpointFlag,
+		LatencySLA:       *slaFlag,
+		SecondPass:       *secondPassFlag,
+		SLAMetric:        *slaMetricFlag,
+		EmitSamples:      *emitSamplesFlag,
+		ColdWarm:         *coldWarmFlag,
+		NoRecurse:        *noRecurseFlag,
+		SourceIP:         sourceIP,
+		Protocol:         *protocolFlag,
+		ConnectionStats:  *connectionStatsFlag,
+		InsecureTLS:      *insecureFlag,
+		Live:             *liveFlag,
+		MinAnswers:       *minAnswersFlag,
+		IncludeSections:  *includeSectionsFlag,
+		Prefer:           *preferFlag,
+		IPv6:             *ipv6Flag,
+		AdaptiveTimeout:  *adaptiveTimeoutFlag,
+		SpillDir:         *spillDirFlag,
+	}
+	if testOpts.ComparePrefix, err = parseCompareGranularity(*granularityFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing compare granularity: %v\n", err)
+		os.Exit(1)
+	}
+	if testOpts.SamplePercent < 0 || testOpts.SamplePercent > 100 {
+		fmt.Fprintf(os.Stderr, "Error: --sample-percent must be between 0 and 100\n")
+		os.Exit(1)
+	}
+	if testOpts.MaxConcurrency < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --max-concurrency must not be negative\n")
+		os.Exit(1)
+	}
+	if testOpts.MaxConcurrency > 0 && testOpts.Workers > testOpts.MaxConcurrency {
+		fmt.Fprintf(infoOutput, "Using %d workers, the --max-concurrency limit, instead of %d\n", testOpts.MaxConcurrency, testOpts.Workers)
+		testOpts.Workers = testOpts.MaxConcurrency
+	}
+	testOpts.sockets = newConnLimit(testOpts.MaxConcurrency)
+	if testOpts.SampleSeed == 0 {
+		testOpts.SampleSeed = time.Now().UnixNano()
+	}
+	if testOpts.AdaptiveTimeout < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --adaptive-timeout must not be negative\n")
+		os.Exit(1)
+	}
+	if testOpts.Retries < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --retries must not be negative\n")
+		os.Exit(1)
+	}
+	if testOpts.ColdWarm && testOpts.Samples < ColdWarmMinSamples {
+		testOpts.Samples = ColdWarmMinSamples
+	}
+	switch testOpts.SLAMetric {
+	case SLAMetricP95, SLAMetricAverage:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --latency-sla-metric value: %s\n", testOpts.SLAMetric)
+		os.Exit(1)
+	}
+	switch testOpts.Protocol {
+	case ProtocolUDP, ProtocolTCP, ProtocolTLS, ProtocolQUIC, ProtocolHTTPS:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --protocol value: %s\n", testOpts.Protocol)
+		os.Exit(1)
+	}
+	if testOpts.MinAnswers < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --min-answers must be at least 1\n")
+		os.Exit(1)
+	}
+	if testOpts.Live && *domainFlag == "" {
+		fmt.Fprintf(os.Stderr, "Error: --live requires --domain\n")
+		os.Exit(1)
+	}
+	if testOpts.Live && *machineFlag {
+		fmt.Fprintf(os.Stderr, "Error: --live cannot be combined with --machine\n")
+		os.Exit(1)
+	}
+	if testOpts.ConnectionStats && testOpts.Protocol != ProtocolTCP && testOpts.Protocol != ProtocolTLS {
+		fmt.Fprintf(os.Stderr, "Error: --connection-stats requires --protocol tcp or tls\n")
+		os.Exit(1)
+	}
+	if len(queryTypes) > 1 {
+		// These features compare or resume results by server and domain alone
+		conflicts := []struct {
+			name string
+			set  bool
+		}{
+			{"--checkpoint", *checkpointFlag != ""},
+			{"--quorum", *quorumFlag != ""},
+			{"--expected-zone", *expectedZoneFlag != ""},
+			{"--ip-distribution", *ipDistributionFlag},
+			{"--compare-servers", *compareFlag != ""},
+			{"--first-success", *firstSuccessFlag},
+			{"--live", *liveFlag},
+			{"--ipv6", *ipv6Flag},
+			{"--prefer dual", testOpts.Prefer == PreferDual},
+		}
+		for _, conflict := range conflicts {
+			if conflict.set {
+				fmt.Fprintf(os.Stderr, "Error: querying several record types cannot be combined with %s\n", conflict.name)
+				os.Exit(1)
+			}
+		}
+	}
+	if testOpts.IPv6 && (testOpts.QueryType != dns.TypeA || testOpts.Prefer == PreferDual) {
+		fmt.Fprintf(os.Stderr, "Error: --ipv6 requires --query-type A and cannot be combined with --prefer dual\n")
+		os.Exit(1)
+	}
+	switch testOpts.Prefer {
+	case PreferIPv4, PreferDual:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --prefer value: %s\n", testOpts.Prefer)
+		os.Exit(1)
+	}
+	switch testOpts.PercentileMethod {
+	case PercentileLinear, PercentileNearestRank:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --percentile-method value: %s\n", testOpts.PercentileMethod)
+		os.Exit(1)
+	}
+	switch testOpts.ParallelOver {
+	case ParallelOverAll, ParallelOverServers, ParallelOverDomains:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --parallel-over value: %s\n", testOpts.ParallelOver)
+		os.Exit(1)
+	}
 
-func loadDNSServersFromFile(filename string) ([]DNSServer, error) {
-	file, err := os.Open(filename)
+	outputs, err := outputDestinations(outputFlags, outputOpts, *noColorFlag)
 	if err != nil {
-		return nil, err
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	defer file.Close()
 
-	var servers []DNSServer
-	scanner := bufio.NewScanner(file)
+	appending := false
+	for _, opts := range outputs {
+		appending = appending || opts.Append
+	}
+	if outputOpts.Append && !appending {
+		fmt.Fprintf(os.Stderr, "Error: --append requires a JSON --output file\n")
+		os.Exit(1)
+	}
+	if *templateFlag != "" && outputOpts.Append {
+		fmt.Fprintf(os.Stderr, "Error: --append cannot be combined with --template\n")
+		os.Exit(1)
+	}
+	if *alertBelowFlag < 0 || *alertBelowFlag > 100 {
+		fmt.Fprintf(os.Stderr, "Error: --alert-below must be between 0 and 100\n")
+		os.Exit(1)
+	}
+	if *alertCyclesFlag < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --alert-cycles must be at least 1\n")
+		os.Exit(1)
+	}
+	if *alertWebhookFlag != "" && *alertBelowFlag == 0 {
+		fmt.Fprintf(os.Stderr, "Error: --alert-webhook requires --alert-below\n")
+		os.Exit(1)
+	}
+	if *emaAlphaFlag < 0 || *emaAlphaFlag > 1 {
+		fmt.Fprintf(os.Stderr, "Error: --ema-alpha must be between 0 and 1\n")
+		os.Exit(1)
+	}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+	var quorum []DNSServer
+	if *quorumFlag != "" {
+		var err error
+		if quorum, err = parseQuorum(*quorumFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --quorum: %v\n", err)
+			os.Exit(1)
 		}
-
-		parts := strings.Fields(line)
-		if len(parts) == 0 {
-			continue
+		if queryType != dns.TypeA {
+			fmt.Fprintf(os.Stderr, "Error: --quorum only supports --query-type A\n")
+			os.Exit(1)
 		}
+	}
 
-		ip := parts[0]
-		// Validate IP
-		if net.ParseIP(ip) == nil {
-			fmt.Fprintf(os.Stderr, "Warning: Invalid IP address '%s', skipping\n", ip)
-			continue
+	if *timeSeriesFlag != "" {
+		if err := os.MkdirAll(*timeSeriesFlag, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --timeseries-dir: %v\n", err)
+			os.Exit(1)
 		}
+	}
 
-		server := DNSServer{IP: ip}
-		if len(parts) > 1 {
-			server.Description = strings.Join(parts[1:], " ")
+	var baseline *TestResults
+	var regressionThreshold float64
+	if *baselineFlag != "" {
+		run, err := loadBaseline(*baselineFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --baseline: %v\n", err)
+			os.Exit(1)
+		}
+		baseline = &run
+		if regressionThreshold, err = parseRegressionThreshold(*regressionFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --regression-threshold: %v\n", err)
+			os.Exit(1)
 		}
-
-		servers = append(servers, server)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	var zone *expectedZone
+	if *expectedZoneFlag != "" {
+		var err error
+		if zone, err = loadExpectedZone(*expectedZoneFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --expected-zone: %v\n", err)
+			os.Exit(1)
+		}
+		if queryType != dns.TypeA {
+			fmt.Fprintf(os.Stderr, "Error: --expected-zone only supports --query-type A\n")
+			os.Exit(1)
+		}
 	}
 
-	return servers, nil
-}
-
-func loadDomainsFromFile(filename string) ([]DomainCategory, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
+	var shortlist *serverFilter
+	if *filterServersFlag != "" {
+		var err error
+		shortlist, err = parseServerFilter(*filterServersFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --filter-servers: %v\n", err)
+			os.Exit(1)
+		}
 	}
-	defer file.Close()
-
-	var domains []DomainCategory
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+	if *machineFlag {
+		if err := checkMachineOutputs(outputs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
-
-		parts := strings.Fields(line)
-		if len(parts) == 0 {
-			continue
+		if *dryRunFlag || *templateFlag != "" {
+			fmt.Fprintf(os.Stderr, "Error: --machine cannot be combined with --dry-run or --template\n")
+			os.Exit(1)
 		}
-
-		domain := parts[0]
-		category := CategoryOther // Default category
-
-		if len(parts) > 1 {
-			switch strings.ToLower(parts[1]) {
-			case "general":
-				category = CategoryGeneral
-			case "ad-server", "adserver":
-				category = CategoryAdServer
-			case "adult":
-				category = CategoryAdult
-			case "other":
-				category = CategoryOther
-			default:
-				category = CategoryOther
+	}
+	// Only ndjson can be written from the spilled results without loading
+	// them all; the other features need every full result in memory
+	if *spillDirFlag != "" {
+		for _, opts := range outputs {
+			if opts.Format != "ndjson" || *templateFlag != "" {
+				fmt.Fprintf(os.Stderr, "Error: --spill-dir only supports ndjson output\n")
+				os.Exit(1)
 			}
 		}
-
-		domains = append(domains, DomainCategory{Domain: domain, Category: category})
+		if *secondPassFlag || *checkpointFlag != "" || *geoipFlag != "" || *compareFlag != "" || *firstSuccessFlag || *filterServersFlag != "" || *quorumFlag != "" || *ipDistributionFlag || *expectedZoneFlag != "" || *timeSeriesFlag != "" {
+			fmt.Fprintf(os.Stderr, "Error: --spill-dir cannot be combined with --second-pass, --checkpoint, --geoip, --compare-servers, --first-success, --filter-servers, --quorum, --ip-distribution, --expected-zone or --timeseries-dir\n")
+			os.Exit(1)
+		}
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	for i := range outputs {
+		if *templateFlag != "" {
+			tmpl, err := loadTemplate(*templateFlag, outputs[i].Color)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing template: %v\n", err)
+				os.Exit(1)
+			}
+			outputs[i].Template = tmpl
+		}
+		if err := prepareOutputDir(outputs[i]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot write output file %s: %v\n", outputs[i].File, err)
+			os.Exit(1)
+		}
 	}
-
-	return domains, nil
-}
-
-func runDNSTests(servers []DNSServer, domains []DomainCategory, timeout time.Duration, workers int) TestResults {
-	type job struct {
-		server DNSServer
-		domain DomainCategory
+	switch outputOpts.SortBy {
+	case SortByIP, SortByLatency, SortBySuccess:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --sort-by value: %s\n", outputOpts.SortBy)
+		os.Exit(1)
 	}
-
-	totalJobs := len(servers) * len(domains)
-	jobs := make(chan job, totalJobs)
-	results := make(chan TestResult, totalJobs)
-
-	// Progress tracking
-	var completedJobs int64
-	startTime := time.Now()
-
-	// Start progress bar goroutine
-	done := make(chan bool)
-	go showProgress(&completedJobs, totalJobs, startTime, done)
-
-	// Start workers
-	var wg sync.WaitGroup
-	for i := 0; i < workers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for j := range jobs {
-				result := testDNS(j.server, j.domain.Domain, timeout)
-				result.Category = j.domain.Category
-				results <- result
-				atomic.AddInt64(&completedJobs, 1)
-			}
-		}()
+	switch outputOpts.Summary {
+	case SummaryTop, SummaryBottom, SummaryBoth:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --summary-position value: %s\n", outputOpts.Summary)
+		os.Exit(1)
+	}
+	if outputOpts.MinSuccessRate < 0 || outputOpts.MinSuccessRate > 100 {
+		fmt.Fprintf(os.Stderr, "Error: --min-success-rate must be between 0 and 100\n")
+		os.Exit(1)
+	}
+	switch outputOpts.GroupBy {
+	case GroupByNone, GroupByCountry:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --group-by value: %s\n", outputOpts.GroupBy)
+		os.Exit(1)
+	}
+	switch outputOpts.Layout {
+	case JSONLayoutFlat:
+	case JSONLayoutNested:
+		if outputOpts.Append {
+			fmt.Fprintf(os.Stderr, "Error: --append only supports the flat JSON layout\n")
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --json-layout value: %s\n", outputOpts.Layout)
+		os.Exit(1)
 	}
 
-	// Send jobs
-	go func() {
-		defer close(jobs)
-		for _, server := range servers {
-			for _, domain := range domains {
-				jobs <- job{server: server, domain: domain}
-			}
+	if *bootstrapFlag != "" {
+		ip, port, err := parseServerAddress(*bootstrapFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --bootstrap value: %v\n", err)
+			os.Exit(1)
 		}
-	}()
+		serverBootstrap = DNSServer{IP: ip, Port: port}.address("53")
+	}
 
-	// Close results channel when all workers are done
-	go func() {
+	// Load DNS servers
+	var dnsServers []DNSServer
+	if *listFile != "" {
+		servers, err := loadServerList(*listFile, *listFormatFlag, *strictFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading DNS servers from file: %v\n", err)
+			os.Exit(1)
+		}
+		dnsServers = servers
+	} else if value := os.Getenv(EnvServers); value != "" {
+		servers, err := parseDNSServers(envListReader(value), EnvServers, *strictFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading DNS servers from %s: %v\n", EnvServers, err)
+			os.Exit(1)
+		}
+		dnsServers = servers
+		fmt.Fprintf(infoOutput, "Using DNS servers from %s\n", EnvServers)
+	} else {
+		dnsServers = defaultDNSServers
+		fmt.Fprintf(infoOutput, "Using default DNS servers list\n")
+	}
+	if testOpts.InsecureTLS && (testOpts.Protocol == ProtocolUDP || testOpts.Protocol == ProtocolTCP) && !hasProtocol(dnsServers, ProtocolHTTPS) {
+		fmt.Fprintf(os.Stderr, "Error: --insecure-skip-verify requires --protocol tls, quic or https, or a DoH URL in the server list\n")
+		os.Exit(1)
+	}
+
+	// Drop denylisted servers
+	if *excludeFile != "" || *excludeFlag != "" {
+		excluded := make(map[string]bool)
+		if *excludeFile != "" {
+			ips, err := loadExcludeList(*excludeFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading exclude list: %v\n", err)
+				os.Exit(1)
+			}
+			for _, ip := range ips {
+				excluded[ip] = true
+			}
+		}
+		for _, ip := range strings.Split(*excludeFlag, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				excluded[ip] = true
+			}
+		}
+
+		var removed int
+		dnsServers, removed = excludeServers(dnsServers, excluded)
+		fmt.Fprintf(infoOutput, "Excluded %d DNS servers\n", removed)
+	}
+	if *mergeDupsFlag {
+		var merged int
+		dnsServers, merged = mergeDuplicateServers(dnsServers)
+		fmt.Fprintf(infoOutput, "Merged %d duplicate DNS servers\n", merged)
+	}
+	if len(dnsServers) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no DNS servers to test; check the server list for valid IP addresses\n")
+		os.Exit(1)
+	}
+
+	// Load domains
+	var domains []DomainCategory
+	if *domainFlag != "" {
+		if *domainsFile != "" {
+			fmt.Fprintf(os.Stderr, "Error: --domain cannot be combined with --domains\n")
+			os.Exit(1)
+		}
+		if _, ok := dns.IsDomainName(*domainFlag); !ok {
+			fmt.Fprintf(os.Stderr, "Error: invalid --domain '%s'\n", *domainFlag)
+			os.Exit(1)
+		}
+		domains = []DomainCategory{{Domain: *domainFlag, Category: CategoryOther}}
+	} else if *domainsFile != "" {
+		domainsFromFile, err := loadDomainsFromFile(*domainsFile, *strictFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading domains from file: %v\n", err)
+			os.Exit(1)
+		}
+		domains = domainsFromFile
+		fmt.Fprintf(infoOutput, "Using domains from file: %s\n", *domainsFile)
+	} else if value := os.Getenv(EnvDomains); value != "" {
+		domainsFromEnv, err := parseDomains(envListReader(value), EnvDomains, *strictFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading domains from %s: %v\n", EnvDomains, err)
+			os.Exit(1)
+		}
+		domains = domainsFromEnv
+		fmt.Fprintf(infoOutput, "Using domains from %s\n", EnvDomains)
+	} else {
+		domains = defaultDomains
+		fmt.Fprintf(infoOutput, "Using default domains list\n")
+	}
+	if len(domains) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no domains to test; check the domain list for valid entries\n")
+		os.Exit(1)
+	}
+
+	// Open the GeoIP database(s) up front so a bad path fails before the run
+	var enricher *GeoIPEnricher
+	if *geoipFlag != "" {
+		var err error
+		enricher, err = openGeoIP(*geoipFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening GeoIP database: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Everything is loaded and validated; only describe the run
+	if *dryRunFlag {
+		printTestPlan(dnsServers, domains, testOpts, outputs)
+		if enricher != nil {
+			enricher.Close()
+		}
+		return
+	}
+
+	// Head-to-head comparison of two servers
+	if *compareFlag != "" {
+		serverA, serverB, err := parseCompareServers(*compareFlag, dnsServers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing compare servers: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Fprintf(infoOutput, "Comparing %s and %s against %d domains...\n", serverA.label(), serverB.label(), len(domains))
+
+		results := runDNSTests([]DNSServer{serverA, serverB}, domains, testOpts)
+		comparison := compareServers(results, serverA, serverB, domains, testOpts.ComparePrefix)
+
+		for _, opts := range outputs {
+			if err := outputComparison(comparison, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error outputting results: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	// Reachability only: stop at the first server resolving each domain
+	if *firstSuccessFlag {
+		fmt.Fprintf(infoOutput, "Checking whether %d domains resolve on any of %d servers...\n", len(domains), len(dnsServers))
+
+		report := checkReachability(dnsServers, domains, testOpts)
+		for _, opts := range outputs {
+			if err := outputReachability(report, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error outputting results: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	fmt.Fprintf(infoOutput, "Testing %d DNS servers against %d domains...\n", len(dnsServers), len(domains))
+
+	// Per-server behavioral probes, run after the tests of every cycle
+	var probes []serverProbe
+	if *recurseFlag {
+		probes = append(probes, probeRecursion)
+	}
+	if (*wildcardFlag || (shortlist != nil && shortlist.noHijack)) && len(domains) > 0 {
+		probes = append(probes, probeWildcard(domains[0].Domain))
+	}
+	if shortlist != nil && shortlist.dnssec {
+		probes = append(probes, probeDNSSEC)
+	}
+	if *cookieFlag {
+		probes = append(probes, probeCookies)
+	}
+	if *qnameMinFlag {
+		probes = append(probes, probeQNAMEMinimization)
+	}
+	if *minTTLFlag != "" && len(dnsServers) > 0 {
+		// The reference TTL comes from the zone's nameserver, found via the first server
+		authTTL, err := authoritativeTTL(*minTTLFlag, dnsServers[0], testOpts.Timeout)
+		if err != nil {
+			fmt.Fprintf(infoOutput, "Warning: cannot get the authoritative TTL of %s, skipping the minimum TTL check: %v\n", *minTTLFlag, err)
+		} else {
+			probes = append(probes, probeMinTTL(*minTTLFlag, authTTL))
+			testOpts.AuthTTLs = map[string]uint32{strings.ToLower(strings.TrimSuffix(*minTTLFlag, ".")): authTTL}
+		}
+	}
+	if *rateProbeFlag && len(domains) > 0 {
+		probes = append(probes, probeRateLimit(*rateMaxFlag, domains[0].Domain))
+	}
+	if *lossProbeFlag > 0 && len(domains) > 0 {
+		probes = append(probes, probePacketLoss(*lossProbeFlag, domains[0].Domain))
+	}
+
+	// With --interval the run repeats as monitoring cycles until interrupted
+	// or --cycles is reached
+	var tracker *flakyTracker
+	if *intervalFlag > 0 {
+		tracker = newFlakyTracker(*flakyFlipsFlag)
+	}
+	var alerts *alertTracker
+	if *alertBelowFlag > 0 {
+		alerts = newAlertTracker(*alertBelowFlag, *alertCyclesFlag)
+	}
+	var reliability *reliabilityTracker
+	if *emaAlphaFlag > 0 {
+		reliability = newReliabilityTracker(*emaAlphaFlag)
+	}
+
+	for cycle := 1; ; cycle++ {
+		cycleStart := time.Now()
+
+		// Run tests
+		results := runDNSTests(dnsServers, domains, testOpts)
+
+		// Annotate resolved IPs with country and ASN data
+		if enricher != nil {
+			enricher.enrich(results.Results)
+		}
+
+		if *ipDistributionFlag {
+			results.Summary.IPDistribution = ipDistribution(results.Results)
+		}
+
+		if *groupByFlag == GroupByCountry {
+			results.Summary.Countries = countryGroups(results.Results)
+		}
+
+		if baseline != nil && !results.Summary.Interrupted {
+			results.Summary.Regression = compareBaseline(results.Results, *baseline, *baselineFlag, regressionThreshold)
+		}
+
+		if quorum != nil && !results.Summary.Interrupted {
+			consensus := resolveQuorum(quorum, domains, testOpts)
+			applyQuorum(&results, quorum, domains, consensus, testOpts.ComparePrefix)
+		}
+
+		if zone != nil {
+			applyExpectedZone(&results, zone, domains)
+		}
+
+		if len(probes) > 0 && !results.Summary.Interrupted {
+			fmt.Fprintf(infoOutput, "Probing %d DNS servers...\n", len(dnsServers))
+			profiles := runServerProbes(dnsServers, testOpts, probes)
+			applyServerProfiles(&results, profiles)
+		}
+
+		if *transportDeltaFlag && !results.Summary.Interrupted {
+			results.Summary.TransportDelta = measureTransportDelta(dnsServers, domains, testOpts)
+		}
+
+		if *interceptionFlag && !results.Summary.Interrupted {
+			report := detectInterception(interceptionResolvers, blackholeAddresses, testOpts.Timeout)
+			if report.Intercepted {
+				fmt.Fprintf(infoOutput, "Warning: port 53 appears intercepted on this network: %s\n", strings.Join(report.Reasons, "; "))
+			}
+			results.Summary.Interception = report
+		}
+
+		if *timeSeriesFlag != "" {
+			if err := appendTimeSeries(*timeSeriesFlag, results.Timestamp, results.Results); err != nil {
+				fmt.Fprintf(infoOutput, "Warning: cannot write time series: %v\n", err)
+			}
+		}
+
+		if tracker != nil {
+			tracker.record(results.Results)
+			results.Summary.Cycle = cycle
+			results.Summary.FlakyServers = tracker.flaky()
+		}
+
+		if reliability != nil {
+			reliability.record(results.Results)
+			results.Summary.Reliability = reliability.report()
+		}
+
+		// Without a webhook, a firing alert ends the run with AlertExitCode
+		// once this cycle's results are written
+		alertExit := false
+		if alerts != nil {
+			changed := alerts.record(results.Results, results.Summary.smoothedRates())
+			results.Summary.Alerts = alerts.active()
+			if len(changed) > 0 && *alertWebhookFlag != "" {
+				payload := AlertPayload{RunID: results.RunID, Timestamp: results.Timestamp, Cycle: cycle, Alerts: changed}
+				if err := postAlerts(*alertWebhookFlag, payload); err != nil {
+					fmt.Fprintf(infoOutput, "Warning: cannot post alerts to %s: %v\n", *alertWebhookFlag, err)
+				}
+			}
+			for _, alert := range changed {
+				alertExit = alertExit || (alert.State == AlertFiring && *alertWebhookFlag == "")
+			}
+		}
+
+		if shortlist != nil {
+			applyServerFilter(&results, shortlist, testOpts.PercentileMethod)
+		}
+
+		// Output results
+		// Results are computed once and rendered to every destination
+		for _, opts := range outputs {
+			if err := outputResults(results, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error outputting results: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// The run is complete, a later run must start over
+		if testOpts.Checkpoint != "" {
+			os.Remove(testOpts.Checkpoint)
+		}
+		if results.spill != nil {
+			results.spill.remove()
+		}
+
+		if *failCriticalFlag && results.Summary.Critical != nil && len(results.Summary.Critical.Failing) > 0 {
+			fmt.Fprintf(os.Stderr, "Critical: %d critical domains failed on a majority of servers\n", len(results.Summary.Critical.Failing))
+			os.Exit(CriticalExitCode)
+		}
+		if regression := results.Summary.Regression; regression != nil && regression.Regressed > 0 {
+			fmt.Fprintf(os.Stderr, "Regression: %d servers regressed against %s\n", regression.Regressed, *baselineFlag)
+			os.Exit(RegressionExitCode)
+		}
+		if alertExit {
+			fmt.Fprintf(os.Stderr, "Alert: %d servers below %.2f%% success for %d cycles\n", len(results.Summary.Alerts), *alertBelowFlag, *alertCyclesFlag)
+			os.Exit(AlertExitCode)
+		}
+
+		if *intervalFlag <= 0 || (*cyclesFlag > 0 && cycle >= *cyclesFlag) || results.Summary.Interrupted {
+			break
+		}
+		time.Sleep(time.Until(cycleStart.Add(*intervalFlag)))
+		fmt.Fprintf(infoOutput, "Monitoring cycle %d: testing %d DNS servers against %d domains...\n", cycle+1, len(dnsServers), len(domains))
+	}
+
+	if enricher != nil {
+		enricher.Close()
+	}
+}
+
+func printHelp() {
+	fmt.Println("DNS Check Tool")
+	fmt.Println("Usage: dns-check-go [options]")
+	fmt.Println("")
+	fmt.Println("Options:")
+	fmt.Println("  --list <file>      DNS server list file (IP per line, optional description after space)")
+	fmt.Println("  --domains <file>   Domain list file (domain per line, optional category after space)")
+	fmt.Println("  --output <dest>    Output destination PATH[:FORMAT], repeatable, - for stdout (default: stdout)")
+	fmt.Printf("  --format <format>  Output format: json, text, loki, ndjson, server-csv, scoreboard, iplist (default: %s)\n", DefaultFormat)
+	fmt.Printf("  --timeout <sec>    Timeout for DNS queries in seconds (default: %d)\n", DefaultTimeout)
+	fmt.Printf("  --retries <n>      Retries of a query failing with a timeout or network error, with backoff (default: %d)\n", DefaultRetries)
+	fmt.Printf("  --workers <num>    Number of concurrent workers (default: %d)\n", DefaultWorkerCount)
+	fmt.Println("  --parallel-over <mode>  Dispatch strategy: all, servers, domains (default: all)")
+	fmt.Println("  --gzip            Gzip-compress the output file (implied by a .gz extension)")
+	fmt.Println("  --geoip <files>   MaxMind-style .mmdb database(s) for country/ASN of resolved IPs")
+	fmt.Println("  --check-recursion Flag servers that do not recurse for uncached names")
+	fmt.Println("  --append          Append the run to a JSON array in the output file")
+	fmt.Println("  --compare-servers <a,b>  Compare two DNS servers head-to-head")
+	fmt.Println("  --strict          Fail on any invalid or malformed line in the list files")
+	fmt.Println("  --query-type <types>  Record type(s) to query, comma-separated: A, AAAA, MX, TXT, NS, CNAME, SOA, PTR, ANY (default: A)")
+	fmt.Println("  --type <types>    Same as --query-type, e.g. A,AAAA,MX")
+	fmt.Println("  --qps <num>       Maximum queries per second across all workers (default: unlimited)")
+	fmt.Println("  --max-per-server <num>  Maximum concurrent queries per server (default: unlimited)")
+	fmt.Println("  --max-concurrency <num>  Maximum concurrent queries and open sockets in total, including pooled connections (default: unlimited)")
+	fmt.Println("  --jitter <dur>    Random delay of up to this duration before each query (e.g. 100ms)")
+	fmt.Printf("  --polite          Conservative preset: %d workers, %d qps, %d per server, %v jitter\n", PoliteWorkers, PoliteQPS, PoliteMaxPerServer, PoliteJitter)
+	fmt.Println("  --sample-percent <pct>  Randomly test only this percentage of server/domain pairs")
+	fmt.Println("  --sample-seed <num>     Seed for --sample-percent, for reproducible samples")
+	fmt.Println("  --samples <num>   Number of queries per server/domain pair (default: 1)")
+	fmt.Println("  --emit-samples    Include every sample latency in the JSON output")
+	fmt.Println("  --exclude-servers <file>  File of server IPs to skip, one per line")
+	fmt.Println("  --exclude <ips>   Comma-separated server IPs to skip")
+	fmt.Println("  --no-color        Disable colored text output (also disabled by NO_COLOR or when not a terminal)")
+	fmt.Println("  --no-recurse      Send queries with recursion disabled (RD=0)")
+	fmt.Println("  --loss-probe <num>  Send this many identical queries per server to measure UDP packet loss")
+	fmt.Println("  --json-layout <l>  JSON layout: flat, nested (server -> category -> results)")
+	fmt.Println("  --deadline <dur>   Time budget for --samples; slow servers get fewer samples (e.g. 5m)")
+	fmt.Println("  --percentile-method <m>  Percentile interpolation: linear, nearest (default: linear)")
+	fmt.Println("  --check-wildcard  Flag servers that resolve random nonexistent subdomains")
+	fmt.Println("  --template <file>  Render the results through this Go text/template file instead of --format")
+	fmt.Println("  --compare-granularity <g>  Compare resolved IPs exactly or by network prefix, e.g. /24, in --compare-servers, --quorum and server pairs (default: exact)")
+	fmt.Println("  --first-success   Only check whether any server resolves each domain, stopping at the first success")
+	fmt.Println("  --success-rcodes <list>  RCODEs counted as success, e.g. NOERROR,NXDOMAIN (default: NOERROR with an answer)")
+	fmt.Println("  --rate-limit-probe  Raise the query rate to each server step by step to find its rate limit")
+	fmt.Println("  --rate-limit-max <qps>  Highest QPS sent to a server by --rate-limit-probe (default: 50)")
+	fmt.Println("  --sort-by <order>  Server order in the detailed output: ip, latency, success (default: ip)")
+	fmt.Println("  --check-cookies   Check whether each server supports DNS cookies (RFC 7873)")
+	fmt.Println("  --checkpoint <file>  Save progress to this file and resume from it after an interruption")
+	fmt.Println("  --dry-run         Validate the inputs and print the test plan without sending queries")
+	fmt.Println("  --latency-sla <dur>  Mark servers whose p95 (or average) latency exceeds this, e.g. 50ms")
+	fmt.Println("  --latency-sla-metric <m>  Latency compared against --latency-sla: p95, avg (default: p95)")
+	fmt.Println("  --list-format <f>  Format of the --list file: auto, plain, unbound, bind (default: auto)")
+	fmt.Println("  --second-pass     Retry the failed server/domain pairs once after the run")
+	fmt.Println("  --min-ttl-probe <domain>  Detect servers enforcing a minimum TTL using a domain with a low TTL")
+	fmt.Println("  --cold-warm       Report cold (first) and warm (cached) latency per pair; implies --samples 3")
+	fmt.Println("  --source-ip <ip>  Local IP address to send queries from")
+	fmt.Println("  --interface <name>  Network interface to send queries from (uses its first address)")
+	fmt.Println("  --protocol <proto>  Transport for test queries: udp, tcp, tls (DNS-over-TLS), quic (DNS-over-QUIC), https (DNS-over-HTTPS) (default: udp)")
+	fmt.Println("  --interval <dur>  Repeat the run every interval as a monitoring cycle (e.g. 5m)")
+	fmt.Println("  --cycles <num>    Stop monitoring after this many cycles (default: until interrupted)")
+	fmt.Println("  --flaky-flips <num>  Up/down changes within the last 10 cycles that flag a server as flaky (default: 3)")
+	fmt.Println("  --adaptive-timeout <x>  Per-server timeout of x times the calibrated median response time (e.g. 3)")
+	fmt.Println("  --spill-dir <dir>  Spill the full results to sorted temp files for ndjson output, keeping compact copies in memory")
+	fmt.Println("  --filter-servers <expr>  Only report servers meeting all conditions (success>=PCT, p95<=DUR, avg<=DUR, adblock>=PCT, dnssec, no-hijack)")
+	fmt.Println("  --machine         Print only the structured results (JSON by default); errors still go to stderr")
+	fmt.Println("  --prefer <mode>   Records that count as an answer to A queries: ipv4, dual (fall back to AAAA) (default: ipv4)")
+	fmt.Println("  --alert-below <pct>  Alert when a server's success rate stays below this for --alert-cycles cycles")
+	fmt.Println("  --alert-cycles <n>  Consecutive cycles below --alert-below before alerting (default: 3)")
+	fmt.Println("  --alert-webhook <url>  POST alerts as JSON to this URL instead of exiting")
+	fmt.Println("  --merge-duplicates  Test servers listed more than once a single time, combining their descriptions")
+	fmt.Println("  --fail-on-critical  Exit with status 3 when a critical domain fails on a majority of servers")
+	fmt.Println("  --quorum <ips>    Reference resolvers (at least 3) whose majority answer flags disagreeing servers")
+	fmt.Println("  --summary-position <pos>  Where the text output prints the summary: top, bottom, both (default: both)")
+	fmt.Println("  --ip-distribution  Report, per domain, the resolved addresses and the servers returning each")
+	fmt.Println("  --expected-zone <file>  Zone file whose A/AAAA records are the expected answers; other answers are flagged")
+	fmt.Println("  --connection-stats  Report, per server, connections established versus reused (tcp and tls protocols)")
+	fmt.Println("  --insecure-skip-verify  Don't verify server certificates, e.g. of self-signed test resolvers (tls, quic and https protocols)")
+	fmt.Println("  --domain <name>   Test only this domain, instead of a domain list")
+	fmt.Println("  --live            With --domain, show the servers ranked by response time as their answers arrive")
+	fmt.Println("  --timeseries-dir <dir>  Append each cycle's average latency and success rate per server to <ip>.csv in this directory")
+	fmt.Println("  --transport-delta  Also query every server over UDP and TCP and report the TCP latency penalty per server")
+	fmt.Println("  --min-answers <n>  Only count a response as successful with at least this many answer records of the queried type (default: 1)")
+	fmt.Println("  --include-sections  Record a summary of the authority and additional sections of every response")
+	fmt.Println("  --ema-alpha <a>   Smoothing factor (0-1] of a moving average of each server's success rate across cycles, used for ranking and alerting")
+	fmt.Println("  --check-qname-min  Check whether each server uses QNAME minimization (RFC 9156)")
+	fmt.Println("  --group-by <key>  Group the servers by: country (from --geoip or the 'XX - ' description prefix)")
+	fmt.Println("  --baseline <file>  Previous --format json output to compare with; exit with status 4 when a server regressed")
+	fmt.Println("  --regression-threshold <pct>  Latency increase over --baseline that counts as a regression (default: 20%)")
+	fmt.Println("  --bootstrap <ip>   Resolver IP[:PORT] used to look up hostnames in the server list (default: system resolver)")
+	fmt.Println("  --check-interception  Warn when the network intercepts port 53 (unrouted addresses answer or public resolvers share one egress)")
+	fmt.Println("  --min-success-rate <pct>  Success rate a server needs to be listed by --format iplist (default: 100)")
+	fmt.Println("  --ipv6            Also query AAAA for every domain and record both address families")
+	fmt.Println("  --help            Show this help message")
+	fmt.Println("")
+	fmt.Println("Examples:")
+	fmt.Println("  go run . --list ./dns-servers.txt --domains ./domains.txt --output ./results.json")
+	fmt.Println("  go run . --output ./results.txt --format text")
+	fmt.Println("  go run . --compare-servers 1.1.1.1,8.8.8.8")
+	fmt.Println("  go run .  (uses default DNS servers and domains)")
+}
+
+// loadDNSServersFromFile loads servers from a list file. Invalid entries are
+// skipped with a warning, or reported as an error when strict is set.
+func loadDNSServersFromFile(filename string, strict bool) ([]DNSServer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseDNSServers(file, filename, strict)
+}
+
+// parseDNSServers reads a server list in the plain format; name identifies
+// the source in strict mode errors
+func parseDNSServers(r io.Reader, name string, strict bool) ([]DNSServer, error) {
+	var servers []DNSServer
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+
+		// Validate the IP, with an optional port, or the DoH URL. A hostname
+		// is resolved here and tested at each of its addresses.
+		var hostname, dohURL string
+		var addresses []string
+		ip, port, err := parseServerAddress(parts[0])
+		if strings.HasPrefix(parts[0], dohURLPrefix) {
+			var host string
+			if dohURL, host, err = parseDoHURL(parts[0]); err == nil {
+				ip, port = host, ""
+				if net.ParseIP(host) == nil {
+					hostname = host
+				}
+			}
+		} else if host, hostPort, ok := splitServerHostname(parts[0]); err != nil && ok {
+			hostname, port, err = host, hostPort, nil
+		}
+		if err != nil {
+			if strict {
+				return nil, fmt.Errorf("%s:%d: %v", name, lineNum, err)
+			}
+			fmt.Fprintf(infoOutput, "Warning: %v on line %d, skipping\n", err, lineNum)
+			continue
+		}
+		if hostname != "" {
+			if addresses, err = resolveServerHostname(hostname); err != nil {
+				if strict {
+					return nil, fmt.Errorf("%s:%d: %v", name, lineNum, err)
+				}
+				fmt.Fprintf(infoOutput, "Warning: %v on line %d, skipping\n", err, lineNum)
+				continue
+			}
+		}
+
+		server := DNSServer{IP: ip, Port: port, Hostname: hostname, DoHURL: dohURL}
+		if dohURL != "" {
+			server.Protocol = ProtocolHTTPS
+		}
+
+		// DoH, port= and ports= tokens may appear anywhere after the address
+		var tokenErr error
+		var ports []string
+		kept := parts[:1]
+		for _, part := range parts[1:] {
+			if strings.HasPrefix(part, portToken) && dohURL == "" {
+				value := strings.TrimPrefix(part, portToken)
+				if !isPortField(value) && tokenErr == nil {
+					tokenErr = fmt.Errorf("invalid port '%s' in %s", value, part)
+				}
+				if port != "" && tokenErr == nil {
+					tokenErr = fmt.Errorf("'%s' has more than one port", parts[0])
+				}
+				port, server.Port = value, value
+				continue
+			}
+			if strings.HasPrefix(part, portsToken) {
+				var err error
+				if ports, err = parsePortList(strings.TrimPrefix(part, portsToken)); err != nil && tokenErr == nil {
+					tokenErr = err
+				}
+				continue
+			}
+			isToken, err := parseDoHToken(&server, part)
+			if err != nil && tokenErr == nil {
+				tokenErr = err
+			}
+			if !isToken {
+				kept = append(kept, part)
+			}
+		}
+		if port != "" && len(ports) > 0 && tokenErr == nil {
+			tokenErr = fmt.Errorf("'%s' has a port and a ports= token", parts[0])
+		}
+		if tokenErr != nil {
+			if strict {
+				return nil, fmt.Errorf("%s:%d: %v", name, lineNum, tokenErr)
+			}
+			fmt.Fprintf(infoOutput, "Warning: %v on line %d, skipping\n", tokenErr, lineNum)
+			continue
+		}
+		parts = kept
+
+		// A second address of the other family makes the server dual-stack
+		if len(parts) > 1 && hostname == "" && dohURL == "" {
+			if v4, v6, ok := dualStackPair(ip, parts[1]); ok {
+				server.IP, server.IPv6 = v4, v6
+				parts = parts[1:]
+			}
+		}
+		if len(parts) > 1 {
+			server.Description = strings.Join(parts[1:], " ")
+		} else if hostname != "" {
+			// Outputs showing only the IP and description still name the host
+			server.Description = hostname
+		}
+
+		// A server with ports= is tested separately on each of them
+		if len(addresses) == 0 {
+			addresses = []string{server.IP}
+		}
+		if len(ports) == 0 {
+			ports = []string{server.Port}
+		}
+		for _, address := range addresses {
+			for _, port := range ports {
+				server.IP, server.Port = address, port
+				servers = append(servers, server)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return servers, nil
+}
+
+// loadExcludeList loads server IPs to skip, one per line. Anything after the
+// IP is ignored so a regular server list can be used as a denylist.
+func loadExcludeList(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var ips []string
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ips = append(ips, strings.Fields(line)[0])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ips, nil
+}
+
+// excludeServers removes the servers matching an excluded entry by either of
+// their addresses or by label, e.g. 127.0.0.1:5335 for one port only. It
+// returns the remaining servers and how many were removed.
+func excludeServers(servers []DNSServer, excluded map[string]bool) ([]DNSServer, int) {
+	var kept []DNSServer
+	for _, server := range servers {
+		if !excluded[server.IP] && !excluded[server.IPv6] && !excluded[server.label()] {
+			kept = append(kept, server)
+		}
+	}
+	return kept, len(servers) - len(kept)
+}
+
+// mergeDuplicateServers merges the servers listed more than once with the
+// same address and port into the first entry, joining their distinct
+// descriptions with " / ". It returns the merged servers and how many
+// entries were folded into an earlier one.
+func mergeDuplicateServers(servers []DNSServer) ([]DNSServer, int) {
+	var merged []DNSServer
+	index := make(map[string]int)
+	for _, server := range servers {
+		i, seen := index[server.label()]
+		if !seen {
+			index[server.label()] = len(merged)
+			merged = append(merged, server)
+			continue
+		}
+
+		first := &merged[i]
+		if first.IPv6 == "" {
+			first.IPv6 = server.IPv6
+		}
+		if server.Description != "" && !containsDescription(first.Description, server.Description) {
+			if first.Description == "" {
+				first.Description = server.Description
+			} else {
+				first.Description += " / " + server.Description
+			}
+		}
+	}
+	return merged, len(servers) - len(merged)
+}
+
+// containsDescription reports whether a combined description already
+// includes description
+func containsDescription(combined, description string) bool {
+	for _, part := range strings.Split(combined, " / ") {
+		if part == description {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDomainsFromFile loads domains from a list file. Unknown categories fall
+// back to Other, unless strict is set, in which case they and malformed lines
+// are reported as an error.
+func loadDomainsFromFile(filename string, strict bool) ([]DomainCategory, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseDomains(file, filename, strict)
+}
+
+// parseDomains reads a domain list; name identifies the source in strict
+// mode errors
+func parseDomains(r io.Reader, name string, strict bool) ([]DomainCategory, error) {
+	var domains []DomainCategory
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+
+		domain := parts[0]
+		category := CategoryOther // Default category
+
+		if strict {
+			if _, ok := dns.IsDomainName(domain); !ok {
+				return nil, fmt.Errorf("%s:%d: invalid domain name '%s'", name, lineNum, domain)
+			}
+		}
+
+		// Attributes (key=value) follow the optional category
+		critical := false
+		for len(parts) > 1 && strings.Contains(parts[len(parts)-1], "=") {
+			attribute := parts[len(parts)-1]
+			parts = parts[:len(parts)-1]
+			switch strings.ToLower(attribute) {
+			case "critical=true":
+				critical = true
+			case "critical=false":
+			default:
+				if strict {
+					return nil, fmt.Errorf("%s:%d: unknown attribute '%s'", name, lineNum, attribute)
+				}
+			}
+		}
+		if strict && len(parts) > 2 {
+			return nil, fmt.Errorf("%s:%d: malformed line, expected 'DOMAIN [CATEGORY] [critical=true]'", name, lineNum)
+		}
+
+		if len(parts) > 1 {
+			switch strings.ToLower(parts[1]) {
+			case "general":
+				category = CategoryGeneral
+			case "ad-server", "adserver":
+				category = CategoryAdServer
+			case "adult":
+				category = CategoryAdult
+			case "other":
+				category = CategoryOther
+			default:
+				if strict {
+					return nil, fmt.Errorf("%s:%d: unknown category '%s'", name, lineNum, parts[1])
+				}
+				category = CategoryOther
+			}
+		}
+
+		domains = append(domains, DomainCategory{Domain: domain, Category: category, Critical: critical})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
+// parseQueryType converts a record type name into its dns type constant
+func parseQueryType(name string) (uint16, error) {
+	qtype, ok := dns.StringToType[strings.ToUpper(strings.TrimSpace(name))]
+	if ok {
+		for _, supported := range supportedQueryTypes {
+			if qtype == supported {
+				return qtype, nil
+			}
+		}
+	}
+
+	var names []string
+	for _, supported := range supportedQueryTypes {
+		names = append(names, dns.TypeToString[supported])
+	}
+	return 0, fmt.Errorf("unsupported query type '%s' (supported: %s)", name, strings.Join(names, ", "))
+}
+
+// parseSuccessRcodes parses the comma-separated --success-rcodes list, returning
+// nil when it is empty so the default NOERROR-with-answer check applies
+func parseSuccessRcodes(list string) (map[int]bool, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	rcodes := make(map[int]bool)
+	for _, name := range strings.Split(list, ",") {
+		rcode, ok := dns.StringToRcode[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown RCODE '%s' in --success-rcodes", strings.TrimSpace(name))
+		}
+		rcodes[rcode] = true
+	}
+	return rcodes, nil
+}
+
+// samplePairs randomly selects SamplePercent of the total server/domain pairs,
+// returning nil when the whole matrix should be tested
+func samplePairs(total int, opts TestOptions) []bool {
+	if opts.SamplePercent <= 0 || opts.SamplePercent >= 100 || total == 0 {
+		return nil
+	}
+
+	count := int(math.Round(float64(total) * opts.SamplePercent / 100))
+	if count < 1 {
+		count = 1
+	}
+
+	rng := rand.New(rand.NewSource(opts.SampleSeed))
+	selected := make([]bool, total)
+	for _, idx := range rng.Perm(total)[:count] {
+		selected[idx] = true
+	}
+
+	return selected
+}
+
+func runDNSTests(servers []DNSServer, domains []DomainCategory, opts TestOptions) TestResults {
+	type job struct {
+		endpoint serverEndpoint
+		domain   DomainCategory
+		qtype    uint16
+	}
+
+	// Dual-stack servers are tested once per address family
+	endpoints := serverEndpoints(servers)
+
+	// Select the server/domain pairs to test and batch them according to the
+	// dispatch strategy
+	selected := samplePairs(len(endpoints)*len(domains), opts)
+
+	// Pairs completed by an interrupted run are taken from the checkpoint
+	// instead of being queried again
+	var allResults []TestResult
+	completed := make(map[string]bool)
+	if opts.Checkpoint != "" {
+		previous, err := loadCheckpoint(opts.Checkpoint)
+		if err != nil {
+			fmt.Fprintf(infoOutput, "Warning: cannot read checkpoint %s, starting over: %v\n", opts.Checkpoint, err)
+		}
+
+		byKey := make(map[string]TestResult)
+		for _, result := range previous {
+			byKey[checkpointKey(result.Server, result.Family, result.Domain)] = result
+		}
+		for s, endpoint := range endpoints {
+			for d, domain := range domains {
+				key := checkpointKey(endpoint.server, endpoint.family, domain.Domain)
+				if result, ok := byKey[key]; ok && (selected == nil || selected[s*len(domains)+d]) {
+					allResults = append(allResults, result)
+					completed[key] = true
+				}
+			}
+		}
+		if len(allResults) > 0 {
+			fmt.Fprintf(infoOutput, "Resuming from checkpoint: %d pairs already completed\n", len(allResults))
+		}
+	}
+
+	include := func(s, d int) bool {
+		return (selected == nil || selected[s*len(domains)+d]) &&
+			!completed[checkpointKey(endpoints[s].server, endpoints[s].family, domains[d].Domain)]
+	}
+
+	// Every selected pair is queried once per record type
+	qtypes := opts.queryTypes()
+
+	var batches [][]job
+	switch opts.ParallelOver {
+	case ParallelOverServers:
+		for s, endpoint := range endpoints {
+			var batch []job
+			for d, domain := range domains {
+				if include(s, d) {
+					for _, qtype := range qtypes {
+						batch = append(batch, job{endpoint: endpoint, domain: domain, qtype: qtype})
+					}
+				}
+			}
+			if len(batch) > 0 {
+				batches = append(batches, batch)
+			}
+		}
+	case ParallelOverDomains:
+		for d, domain := range domains {
+			var batch []job
+			for s, endpoint := range endpoints {
+				if include(s, d) {
+					for _, qtype := range qtypes {
+						batch = append(batch, job{endpoint: endpoint, domain: domain, qtype: qtype})
+					}
+				}
+			}
+			if len(batch) > 0 {
+				batches = append(batches, batch)
+			}
+		}
+	default:
+		for s, endpoint := range endpoints {
+			for d, domain := range domains {
+				if include(s, d) {
+					for _, qtype := range qtypes {
+						batches = append(batches, []job{{endpoint: endpoint, domain: domain, qtype: qtype}})
+					}
+				}
+			}
+		}
+	}
+
+	totalJobs := 0
+	for _, batch := range batches {
+		totalJobs += len(batch)
+	}
+
+	jobs := make(chan []job, len(batches))
+	results := make(chan TestResult, totalJobs)
+
+	// Progress tracking
+	var completedJobs int64
+	startTime := time.Now()
+
+	// Start progress bar goroutine, or the live ranking in its place
+	done := make(chan bool)
+	var ranking *liveRanking
+	if opts.Live && len(domains) > 0 {
+		ranking = newLiveRanking(infoOutput, domains[0].Domain, totalJobs)
+		go ranking.run(done)
+	} else {
+		go showProgress(&completedJobs, totalJobs, startTime, done)
+	}
+
+	// Rate limiting
+	var targets []DNSServer
+	for _, endpoint := range endpoints {
+		targets = append(targets, endpoint.target)
+	}
+	limiter := newThrottle(targets, opts)
+	defer limiter.stop()
+	budget := newSampleBudget(opts, totalJobs)
+	client := newDNSClient(opts)
+	counter := &queryCounter{}
+
+	// TCP and TLS queries to a server share one pipelined connection
+	if opts.Protocol == ProtocolTCP || opts.Protocol == ProtocolTLS {
+		opts.pipelines = newPipelinePool(opts)
+		defer opts.pipelines.close()
+	}
+	defer openDoHPool(&opts, servers)()
+	// Both pools share the --max-concurrency limit, so waiting for a slot
+	// frees idle connections of either
+	if opts.pipelines != nil && opts.doh != nil {
+		opts.pipelines.reclaim = opts.reclaimIdle
+		opts.doh.reclaim = opts.reclaimIdle
+	}
+
+	// With --spill-dir the full results go to disk and only compact copies
+	// are kept for the summary
+	var spill *resultSpill
+	if opts.SpillDir != "" {
+		var err error
+		if spill, err = newResultSpill(opts.SpillDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating spill directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Calibrate per-server timeouts before the main run
+	var adaptiveTimeouts []ServerTimeout
+	if opts.AdaptiveTimeout > 0 && len(domains) > 0 {
+		adaptiveTimeouts = calibrateTimeouts(client, targets, domains, opts)
+		opts.ServerTimeouts = make(map[string]time.Duration)
+		for _, timeout := range adaptiveTimeouts {
+			opts.ServerTimeouts[timeout.Server.label()] = timeout.Timeout
+		}
+	}
+
+	// Without a checkpoint, Ctrl-C cancels the run: in-flight queries are
+	// recorded as skipped, the remaining pairs aren't tested and the partial
+	// results are reported. A second Ctrl-C exits right away.
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	defer cancelRun()
+	opts.runCtx = runCtx
+	if opts.Checkpoint == "" {
+		cancelSignal := make(chan os.Signal, 1)
+		signal.Notify(cancelSignal, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(cancelSignal)
+		go func() {
+			select {
+			case <-cancelSignal:
+				signal.Stop(cancelSignal)
+				cancelRun()
+			case <-runCtx.Done():
+			}
+		}()
+	}
+
+	// Start workers
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				// Jobs within a batch run sequentially, in order
+				for _, j := range batch {
+					if opts.interrupted() {
+						break
+					}
+					jobOpts := opts
+					jobOpts.QueryType = j.qtype
+					result := testDNSSamples(client, counter, j.endpoint.target, j.domain.Domain, jobOpts, limiter, budget)
+					result.Server = j.endpoint.server
+					result.Family = j.endpoint.family
+					result.Category = j.domain.Category
+					result.Critical = j.domain.Critical
+					result.BogusAnswer = isBogusAnswer(result)
+					results <- result
+					atomic.AddInt64(&completedJobs, 1)
+				}
+			}
+		}()
+	}
+
+	// Send jobs
+	go func() {
+		defer close(jobs)
+		for _, batch := range batches {
+			jobs <- batch
+		}
+	}()
+
+	// Close results channel when all workers are done
+	go func() {
 		wg.Wait()
 		close(results)
 	}()
 
-	// Collect results
-	var allResults []TestResult
-	for result := range results {
-		allResults = append(allResults, result)
+	// Collect results, persisting them to the checkpoint periodically and
+	// when the run is interrupted
+	var interrupt chan os.Signal
+	if opts.Checkpoint != "" {
+		interrupt = make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(interrupt)
+	}
+
+	lastSave := time.Now()
+collect:
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				break collect
+			}
+			if spill != nil {
+				if err := spill.add(result); err != nil {
+					fmt.Fprintf(os.Stderr, "\nError spilling results: %v\n", err)
+					os.Exit(1)
+				}
+				result = compactResult(result)
+			}
+			allResults = append(allResults, result)
+			if ranking != nil {
+				ranking.add(result)
+			}
+
+			if opts.Checkpoint != "" && time.Since(lastSave) >= CheckpointInterval {
+				if err := saveCheckpoint(opts.Checkpoint, allResults); err != nil {
+					fmt.Fprintf(infoOutput, "\nWarning: cannot write checkpoint: %v\n", err)
+				}
+				lastSave = time.Now()
+			}
+		case <-interrupt:
+			if err := saveCheckpoint(opts.Checkpoint, allResults); err != nil {
+				fmt.Fprintf(os.Stderr, "\nError writing checkpoint: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "\n\nInterrupted, %d completed pairs saved to %s\n", len(allResults), opts.Checkpoint)
+			os.Exit(130)
+		}
+	}
+
+	// Stop progress bar
+	done <- true
+	if ranking != nil {
+		ranking.draw(true)
+	}
+	fmt.Fprintf(infoOutput, "\n\n")
+
+	// Transient failures during a heavy run often pass on a later attempt
+	var retried, recovered int
+	if opts.SecondPass && !opts.interrupted() {
+		retried, recovered = retryFailed(allResults, opts, client, counter, limiter)
+	}
+
+	// Sort results by server IP then domain
+	sort.Slice(allResults, func(i, j int) bool { return resultBefore(allResults[i], allResults[j]) })
+
+	// Calculate summary
+	summary := calculateSummary(allResults)
+	summary.Percentiles = responsePercentiles(allResults, opts.PercentileMethod)
+	summary.PairConsistency = pairConsistency(allResults, opts.ComparePrefix)
+	summary.SecondPassRetries = retried
+	summary.RecoveredFailures = recovered
+	if opts.LatencySLA > 0 {
+		summary.SLA = evaluateSLA(allResults, opts.LatencySLA, opts.SLAMetric, opts.PercentileMethod)
+	}
+	summary.AdaptiveTimeouts = adaptiveTimeouts
+	if opts.IPv6 && opts.QueryType == dns.TypeA {
+		summary.IPv6 = ipv6Coverage(allResults)
+	}
+	summary.Interrupted = opts.interrupted()
+	if opts.ConnectionStats && opts.pipelines != nil {
+		summary.ConnectionReuse = opts.pipelines.reuse()
+	}
+	summary.TotalQueries = counter.queries.Load()
+	summary.TotalBytesReceived = counter.bytes.Load()
+	if selected != nil {
+		summary.Sampled = true
+		summary.SamplePercent = opts.SamplePercent
+		summary.SampleSeed = opts.SampleSeed
+		summary.MatrixSize = len(endpoints) * len(domains)
+	}
+
+	return TestResults{
+		RunID:     newRunID(),
+		Timestamp: time.Now(),
+		Results:   allResults,
+		Summary:   summary,
+		spill:     spill,
+	}
+}
+
+// resultBefore orders results by server IP, then domain, then address family
+func resultBefore(a, b TestResult) bool {
+	if a.Server.IP != b.Server.IP {
+		return a.Server.IP < b.Server.IP
+	}
+	if a.Server.Port != b.Server.Port {
+		return a.Server.Port < b.Server.Port
+	}
+	if a.Domain != b.Domain {
+		return a.Domain < b.Domain
+	}
+	if a.QueryType != b.QueryType {
+		return a.QueryType < b.QueryType
+	}
+	return a.Family < b.Family
+}
+
+// newRunID returns an identifier that is unique per run, sortable by start time
+func newRunID() string {
+	return fmt.Sprintf("%s-%08x", time.Now().UTC().Format("20060102T150405Z"), rand.Uint32())
+}
+
+func showProgress(completed *int64, total int, startTime time.Time, done chan bool) {
+	// Without jobs there is no progress to draw, and the percentage would
+	// divide by zero; the run still signals done
+	if total == 0 {
+		<-done
+		return
+	}
+
+	ticker := time.NewTicker(ProgressUpdateRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			current := atomic.LoadInt64(completed)
+			elapsed := time.Since(startTime)
+
+			// Calculate progress
+			progress := float64(current) / float64(total)
+			percentage := progress * 100
+
+			// Calculate ETA
+			var eta time.Duration
+			if current > 0 {
+				avgTimePerJob := elapsed / time.Duration(current)
+				remaining := int64(total) - current
+				eta = avgTimePerJob * time.Duration(remaining)
+			}
+
+			// Create progress bar
+			filled := int(progress * float64(ProgressBarWidth))
+			bar := strings.Repeat("█", filled) + strings.Repeat("░", ProgressBarWidth-filled)
+
+			// Format time durations
+			elapsedStr := formatDuration(elapsed)
+			etaStr := formatDuration(eta)
+
+			// Print progress
+			fmt.Fprintf(infoOutput, "\r[%s] %d/%d (%.1f%%) | Elapsed: %s | ETA: %s",
+				bar, current, total, percentage, elapsedStr, etaStr)
+		}
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	minutes := int(d.Minutes())
+	seconds := int(d.Seconds()) % 60
+	return fmt.Sprintf("%dm%ds", minutes, seconds)
+}
+
+// newDNSClient returns the client shared by all workers of a run. Exchange is
+// safe for concurrent use; timeouts are set per query through the context.
+func newDNSClient(opts TestOptions) *dns.Client {
+	client := &dns.Client{
+		Timeout: opts.Timeout,
+	}
+	switch opts.Protocol {
+	case ProtocolTCP:
+		client.Net = "tcp"
+	case ProtocolTLS:
+		client.Net = "tcp-tls"
+		client.TLSConfig = &tls.Config{InsecureSkipVerify: opts.InsecureTLS}
+	}
+	if opts.SourceIP != nil {
+		client.Dialer = &net.Dialer{
+			LocalAddr: &net.UDPAddr{IP: opts.SourceIP},
+		}
+		if client.Net != "" {
+			client.Dialer.LocalAddr = &net.TCPAddr{IP: opts.SourceIP}
+		}
+	}
+	return client
+}
+
+func testDNS(client *dns.Client, counter *queryCounter, server DNSServer, domain string, opts TestOptions) TestResult {
+	// A server listed by DoH URL is queried over HTTPS whatever --protocol says
+	if server.Protocol != "" {
+		opts.Protocol = server.Protocol
+	}
+	if opts.IPv6 && opts.QueryType == dns.TypeA {
+		return testBothFamilies(client, counter, server, domain, opts)
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), opts.QueryType)
+	msg.RecursionDesired = !opts.NoRecurse
+
+	timeout := opts.queryTimeout(server)
+
+	tcpFallback := false
+	send := func(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+		// Pooled connections take their --max-concurrency slot when dialed;
+		// any other query holds one while its socket is open
+		pooled := opts.Protocol == ProtocolHTTPS ||
+			(opts.pipelines != nil && (opts.Protocol == ProtocolTCP || opts.Protocol == ProtocolTLS))
+		if !pooled {
+			if err := opts.sockets.acquire(ctx, opts.reclaimIdle); err != nil {
+				return nil, err
+			}
+			defer opts.sockets.release()
+		}
+
+		var response *dns.Msg
+		var err error
+		switch opts.Protocol {
+		case ProtocolQUIC:
+			response, err = exchangeQUIC(ctx, msg, server, opts)
+		case ProtocolHTTPS:
+			pool := opts.doh
+			if pool == nil {
+				// Callers without a shared pool get a one-shot client
+				pool = newDoHPool(opts)
+				defer pool.close()
+			}
+			response, err = pool.exchange(ctx, msg, server)
+		case ProtocolTCP, ProtocolTLS:
+			if opts.pipelines != nil {
+				response, err = opts.pipelines.exchange(ctx, msg, server)
+				break
+			}
+			response, _, err = client.ExchangeContext(ctx, msg, server.address(protocolPort(opts.Protocol)))
+		default:
+			response, _, err = client.ExchangeContext(ctx, msg, server.address("53"))
+			// A truncated answer is fetched again over TCP, as resolvers do
+			if err == nil && response.Truncated {
+				counter.record(response)
+				tcpFallback = true
+				response, _, err = tcpFallbackClient(client).ExchangeContext(ctx, msg, server.address("53"))
+			}
+		}
+		counter.record(response)
+		return response, err
+	}
+
+	// A timeout or network error is retried after a backoff, each attempt
+	// with its own timeout. The time spent on failed attempts is left out of
+	// the response time.
+	retries := 0
+	var retryTime time.Duration
+	exchange := func(msg *dns.Msg) (*dns.Msg, error) {
+		for attempt := 0; ; attempt++ {
+			// Only the attempt that produced the answer counts as a TCP fallback
+			tcpFallback = false
+			attemptStart := time.Now()
+			ctx, cancel := context.WithTimeout(opts.context(), timeout)
+			response, err := send(ctx, msg)
+			cancel()
+			if err == nil || attempt >= opts.Retries || opts.interrupted() {
+				return response, err
+			}
+			// An interruption during the backoff ends the query, which is
+			// then recorded as skipped
+			if !waitBackoff(opts.context(), retryBackoff(attempt+1)) {
+				return response, err
+			}
+			retries++
+			retryTime += time.Since(attemptStart)
+		}
+	}
+
+	start := time.Now()
+	response, err := exchange(msg)
+	answerType := opts.QueryType
+	answerTCPFallback := tcpFallback
+	// The response time covers both queries, the time it takes to resolve
+	// the name at all
+	if err == nil && needsAAAAFallback(response, opts) {
+		fallback := msg.Copy()
+		fallback.Question[0].Qtype = dns.TypeAAAA
+		if aaaa, aaaaErr := exchange(fallback); aaaaErr == nil && aaaa.Rcode == dns.RcodeSuccess && len(aaaa.Answer) > 0 {
+			response = aaaa
+			answerType = dns.TypeAAAA
+			answerTCPFallback = tcpFallback
+		}
+	}
+	responseTime := time.Since(start) - retryTime
+
+	result := TestResult{
+		Server:       server,
+		Domain:       domain,
+		ResponseTime: responseTime,
+		Protocol:     opts.Protocol,
+		QueryType:    dns.TypeToString[opts.QueryType],
+		TCPFallback:  answerTCPFallback,
+		Retries:      retries,
+	}
+	if opts.SourceIP != nil {
+		result.SourceIP = opts.SourceIP.String()
+	}
+	if opts.ServerTimeouts != nil {
+		result.Timeout = timeout
+	}
+
+	if err != nil {
+		result.Success = false
+		result.Error = err.Error()
+		// A query cut short by the interruption says nothing about the server
+		if opts.interrupted() {
+			result.Skipped = true
+			result.Error = SkippedError
+		}
+		return result
+	}
+
+	// Recorded before any outcome is decided, as a referral instead of an
+	// answer is exactly when they matter
+	if opts.IncludeSections && response != nil {
+		result.Sections = summarizeSections(response)
+	}
+
+	// Negative answers carry the SOA whose TTL bounds how long the
+	// resolver caches the nonexistence. ANY answers hold records of other
+	// types, so only NXDOMAIN counts as negative for them.
+	if response != nil && (response.Rcode == dns.RcodeNameError ||
+		(response.Rcode == dns.RcodeSuccess && answerType != dns.TypeANY && !hasRecord(response.Answer, answerType))) {
+		result.NegativeTTL, _ = negativeTTL(response)
+	}
+
+	if response != nil {
+		result.AnswerSource = inferAnswerSource(response, answerType, opts.AuthTTLs[strings.ToLower(strings.TrimSuffix(domain, "."))])
+	}
+
+	// ANY is a behavioral check: every response, a refusal included, is a
+	// successful observation of how the server handles it
+	if opts.QueryType == dns.TypeANY {
+		result.Success = true
+		result.Any = classifyAny(response)
+		return result
+	}
+
+	// With RD cleared, an empty NOERROR response just means the server has
+	// nothing cached for the name, which is not a failure of the server
+	if opts.NoRecurse && response != nil && response.Rcode == dns.RcodeSuccess && len(response.Answer) == 0 {
+		result.Success = false
+		result.Uncached = true
+		result.Error = "Not in cache (non-recursive query)"
+		return result
+	}
+
+	// The echoed question must match what was asked; anything else points at
+	// spoofing or a buggy forwarder
+	if response != nil && len(response.Question) > 0 && !strings.EqualFold(response.Question[0].Name, msg.Question[0].Name) {
+		result.NameMismatch = true
+		result.ResponseName = response.Question[0].Name
+	}
+
+	// Outcomes other than NOERROR only count as success when allowed by
+	// --success-rcodes, e.g. NXDOMAIN when verifying a domain was removed
+	if opts.SuccessRcodes != nil && response != nil {
+		result.Rcode = dns.RcodeToString[response.Rcode]
+		if response.Rcode != dns.RcodeSuccess || !opts.SuccessRcodes[dns.RcodeSuccess] {
+			result.Success = opts.SuccessRcodes[response.Rcode]
+			if !result.Success {
+				result.Error = fmt.Sprintf("RCODE %s not in --success-rcodes", result.Rcode)
+			}
+			return result
+		}
+	}
+
+	if response == nil || len(response.Answer) == 0 {
+		result.Success = false
+		result.Error = "No answer received"
+		result.Blocked = response != nil && response.Rcode == dns.RcodeNameError
+		return result
+	}
+
+	switch opts.QueryType {
+	case dns.TypeSOA:
+		if soa := extractSOA(response.Answer); soa != nil {
+			result.Success = true
+			result.SOA = soa
+			result.IP, _ = firstRdata(response.Answer, dns.TypeSOA)
+		}
+	case dns.TypeTXT:
+		if txt, ok := extractTXT(response.Answer); ok {
+			result.Success = true
+			result.TXT = txt
+			result.IP, _ = firstRdata(response.Answer, dns.TypeTXT)
+		}
+	case dns.TypeA, dns.TypeAAAA:
+		// Collect the A records, or AAAA records after a --prefer dual
+		// fallback; the first one is the resolved IP
+		for _, answer := range response.Answer {
+			if a, ok := answer.(*dns.A); ok && answerType == dns.TypeA {
+				result.ResolvedIPs = append(result.ResolvedIPs, a.A.String())
+			}
+			if aaaa, ok := answer.(*dns.AAAA); ok && answerType == dns.TypeAAAA {
+				result.ResolvedIPs = append(result.ResolvedIPs, aaaa.AAAA.String())
+			}
+		}
+		if len(result.ResolvedIPs) > 0 {
+			result.Success = true
+			result.IP = result.ResolvedIPs[0]
+			result.Blocked = sinkholeAddresses[result.IP]
+		}
+		if result.Success && opts.Prefer == PreferDual {
+			result.AnswerFamily = FamilyIPv4
+			if answerType == dns.TypeAAAA {
+				result.AnswerFamily = FamilyIPv6
+			}
+		}
+	default:
+		// Other types record the data of their first record, e.g. the MX target
+		if rdata, ok := firstRdata(response.Answer, answerType); ok {
+			result.Success = true
+			result.IP = rdata
+		}
+	}
+
+	// A partial RRset, e.g. from a truncating resolver, falls short of
+	// --min-answers
+	if result.Success && opts.MinAnswers > 1 {
+		result.AnswerCount = countAnswers(response.Answer, answerType)
+		if result.AnswerCount < opts.MinAnswers {
+			result.Success = false
+			result.Error = fmt.Sprintf("Only %d %s records in response, --min-answers is %d",
+				result.AnswerCount, dns.TypeToString[answerType], opts.MinAnswers)
+			return result
+		}
+	}
+
+	if !result.Success {
+		if needsAAAAFallback(response, opts) {
+			result.Error = "No A or AAAA record found in response"
+		} else {
+			result.Error = fmt.Sprintf("No %s record found in response", dns.TypeToString[opts.QueryType])
+		}
+	} else if ttl, ok := answerTTL(response.Answer, answerType); ok {
+		result.TTL = &ttl
+	}
+
+	return result
+}
+
+// countAnswers returns the number of answer records of type qtype, or of any
+// type for ANY queries
+func countAnswers(answers []dns.RR, qtype uint16) int {
+	count := 0
+	for _, answer := range answers {
+		if qtype == dns.TypeANY || answer.Header().Rrtype == qtype {
+			count++
+		}
+	}
+	return count
+}
+
+func calculateSummary(results []TestResult) Summary {
+	totalTests := len(results)
+	successfulTests := 0
+	uncachedTests := 0
+	skippedTests := 0
+	nameMismatches := 0
+	reducedSamples := 0
+	var totalResponseTime time.Duration
+
+	// Category-based statistics
+	categoryStats := make(map[string]CategoryStats)
+	categoryResults := make(map[string][]TestResult)
+
+	// Group results by category
+	for _, result := range results {
+		categoryResults[result.Category] = append(categoryResults[result.Category], result)
+
+		if result.Success {
+			successfulTests++
+			totalResponseTime += result.ResponseTime
+		}
+		if result.Uncached {
+			uncachedTests++
+		}
+		if result.Skipped {
+			skippedTests++
+		}
+		if result.NameMismatch {
+			nameMismatches++
+		}
+		if result.SamplesRequested > 0 {
+			reducedSamples++
+		}
+	}
+
+	// Calculate category statistics
+	for category, catResults := range categoryResults {
+		catTotal := len(catResults)
+		catSuccessful := 0
+		catUncached := 0
+		catSkipped := 0
+
+		for _, result := range catResults {
+			if result.Success {
+				catSuccessful++
+			}
+			if result.Uncached {
+				catUncached++
+			}
+			if result.Skipped {
+				catSkipped++
+			}
+		}
+
+		catFailed := catTotal - catSuccessful - catUncached - catSkipped
+		var catSuccessRate float64
+		if catTotal > catSkipped {
+			catSuccessRate = float64(catSuccessful) / float64(catTotal-catSkipped) * 100
+		}
+
+		categoryStats[category] = CategoryStats{
+			TotalTests:      catTotal,
+			SuccessfulTests: catSuccessful,
+			FailedTests:     catFailed,
+			UncachedTests:   catUncached,
+			SkippedTests:    catSkipped,
+			SuccessRate:     catSuccessRate,
+		}
+	}
+
+	failedTests := totalTests - successfulTests - uncachedTests - skippedTests
+	var successRate float64
+	if totalTests > skippedTests {
+		successRate = float64(successfulTests) / float64(totalTests-skippedTests) * 100
+	}
+
+	var avgResponseTime time.Duration
+	if successfulTests > 0 {
+		avgResponseTime = totalResponseTime / time.Duration(successfulTests)
+	}
+	retriedTests, recoveredByRetry := retriedResults(results)
+
+	return Summary{
+		TotalTests:          totalTests,
+		SuccessfulTests:     successfulTests,
+		FailedTests:         failedTests,
+		UncachedTests:       uncachedTests,
+		SkippedTests:        skippedTests,
+		NameMismatches:      nameMismatches,
+		RetriedTests:        retriedTests,
+		RecoveredByRetry:    recoveredByRetry,
+		AnswerSources:       answerSourceCounts(results),
+		ReducedSamples:      reducedSamples,
+		SuccessRate:         successRate,
+		AverageResponseTime: avgResponseTime,
+		CategoryStats:       categoryStats,
+		SerialMismatches:    findSerialMismatches(results),
+		FamilyStats:         familyStats(results),
+		ProtocolStats:       protocolStats(results),
+		TypeStats:           typeStats(results),
+		TXTMismatches:       findTXTMismatches(results),
+		ColdWarm:            coldWarmStats(results),
+		CategoryWinners:     categoryWinners(results),
+		Confidence:          latencyConfidence(results),
+		AnyBehavior:         anyBehavior(results),
+		Critical:            criticalReport(results),
+		BogusServers:        bogusServers(results),
+		TruncatingServers:   truncatingServers(results),
+		Ports:               portReachability(results),
+	}
+}
+
+func outputResults(results TestResults, opts OutputOptions) error {
+	var output strings.Builder
+
+	if opts.Template != nil {
+		if err := opts.Template.Execute(&output, results); err != nil {
+			return err
+		}
+		return writeOutput(output.String(), opts)
+	}
+
+	switch opts.Format {
+	case "json":
+		// The flat layout is written as it is encoded, so the output never
+		// resides in memory as a whole
+		if !opts.Append && opts.Layout != JSONLayoutNested {
+			return writeOutputFunc(opts, func(w io.Writer) error {
+				return writeJSON(w, results)
+			})
+		}
+		var jsonData []byte
+		var err error
+		if opts.Append {
+			jsonData, err = appendJSONRun(results, opts)
+		} else {
+			jsonData, err = marshalJSONLayout(results, opts.Layout, opts.SortBy)
+		}
+		if err != nil {
+			return err
+		}
+		output.Write(jsonData)
+	case "text":
+		writeTextOutput(&output, results, opts)
+	case "loki":
+		lokiData, err := formatLoki(results)
+		if err != nil {
+			return err
+		}
+		output.Write(lokiData)
+	case "ndjson":
+		// Written as it is produced, so spilled results never all reside in memory
+		return writeOutputFunc(opts, func(w io.Writer) error {
+			return writeNDJSON(w, results)
+		})
+	case "server-csv":
+		csvData, err := formatServerCSV(results, opts.SortBy)
+		if err != nil {
+			return err
+		}
+		output.Write(csvData)
+	case "scoreboard":
+		output.WriteString(formatScoreboard(results, opts.Color))
+	case "iplist":
+		output.WriteString(formatIPList(results, opts.SortBy, opts.MinSuccessRate))
+	default:
+		return fmt.Errorf("unsupported format: %s", opts.Format)
+	}
+
+	return writeOutput(output.String(), opts)
+}
+
+// appendJSONRun reads the JSON array of previous runs from the output file,
+// appends the current run and returns the marshaled array. A missing or empty
+// file starts a fresh array.
+func appendJSONRun(results TestResults, opts OutputOptions) ([]byte, error) {
+	existing, err := readOutputFile(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []TestResults
+	if len(strings.TrimSpace(string(existing))) > 0 {
+		if err := json.Unmarshal(existing, &runs); err != nil {
+			return nil, fmt.Errorf("cannot append to %s: existing content is not a JSON array of runs: %v", opts.File, err)
+		}
+	}
+
+	runs = append(runs, results)
+	return json.MarshalIndent(runs, "", "  ")
+}
+
+// readOutputFile returns the current content of the output file, transparently
+// decompressing it when gzip is in effect. A missing file yields no content.
+func readOutputFile(opts OutputOptions) ([]byte, error) {
+	file, err := os.Open(opts.File)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if !opts.compressed() {
+		return io.ReadAll(file)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return nil, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	return io.ReadAll(gz)
+}
+
+// writeOutput writes the rendered output to the configured file, or to stdout
+// when no file is set
+func writeOutput(output string, opts OutputOptions) error {
+	return writeOutputFunc(opts, func(w io.Writer) error {
+		_, err := io.WriteString(w, output)
+		return err
+	})
+}
+
+// prepareOutputDir creates the parent directory of the output file and checks
+// that it is writable, so a bad path fails before the tests run rather than
+// after them
+func prepareOutputDir(opts OutputOptions) error {
+	if opts.File == "" {
+		return nil
+	}
+
+	dir := filepath.Dir(opts.File)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	probe, err := os.CreateTemp(dir, ".dns-check-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// writeOutputFunc lets write produce the output directly into the configured
+// file, or stdout when no file is set
+func writeOutputFunc(opts OutputOptions, write func(io.Writer) error) error {
+	if opts.File == "" {
+		buffered := bufio.NewWriter(os.Stdout)
+		if err := write(buffered); err != nil {
+			return err
+		}
+		return buffered.Flush()
+	}
+
+	file, err := os.Create(opts.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if !opts.compressed() {
+		buffered := bufio.NewWriter(file)
+		if err := write(buffered); err != nil {
+			return err
+		}
+		if err := buffered.Flush(); err != nil {
+			return err
+		}
+		return file.Close()
+	}
+
+	gz := gzip.NewWriter(file)
+	if err := write(gz); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Summary positions selectable with --summary-position
+const (
+	SummaryTop    = "top"
+	SummaryBottom = "bottom"
+	SummaryBoth   = "both"
+)
+
+// Server orderings selectable with --sort-by
+const (
+	SortByIP      = "ip"
+	SortByLatency = "latency"
+	SortBySuccess = "success"
+)
+
+// serverBefore reports whether the server with results a sorts before the one
+// with results b: fastest average latency first for SortByLatency, highest
+// success rate first for SortBySuccess, the smoothed one when smoothed is set.
+// Servers without a successful answer sort last by latency; ties and SortByIP
+// keep the existing order.
+func serverBefore(a, b []TestResult, sortBy string, smoothed map[DNSServer]float64) bool {
+	statsA, statsB := groupStats(a), groupStats(b)
+	if smoothed != nil {
+		statsA.SuccessRate, statsB.SuccessRate = smoothed[a[0].Server], smoothed[b[0].Server]
+	}
+
+	switch sortBy {
+	case SortByLatency:
+		avgA, avgB := averageResponseTime(a), averageResponseTime(b)
+		if (avgA == 0) != (avgB == 0) {
+			return avgB == 0
+		}
+		return avgA < avgB
+	case SortBySuccess:
+		if statsA.SuccessRate != statsB.SuccessRate {
+			return statsA.SuccessRate > statsB.SuccessRate
+		}
+		return averageResponseTime(a) < averageResponseTime(b)
+	}
+	return false
+}
+
+// averageResponseTime returns the mean response time of the successful results
+func averageResponseTime(results []TestResult) time.Duration {
+	var total time.Duration
+	successful := 0
+	for _, result := range results {
+		if result.Success {
+			total += result.ResponseTime
+			successful++
+		}
+	}
+	if successful == 0 {
+		return 0
+	}
+	return total / time.Duration(successful)
+}
+
+// formatGeo renders the country and ASN annotations of a result, if any
+func formatGeo(result TestResult) string {
+	var parts []string
+	if result.Country != "" {
+		parts = append(parts, result.Country)
 	}
+	if result.ASN != 0 {
+		as := fmt.Sprintf("AS%d", result.ASN)
+		if result.ASOrg != "" {
+			as += " " + result.ASOrg
+		}
+		parts = append(parts, as)
+	}
+	return strings.Join(parts, " ")
+}
+
+func writeTextOutput(output *strings.Builder, results TestResults, opts OutputOptions) {
+	bold := func(s string) string { return colorize(opts.Color, colorBold, s) }
+
+	output.WriteString(bold("DNS Check Results") + "\n")
+	output.WriteString("=================\n")
+	output.WriteString(fmt.Sprintf("Timestamp: %s\n\n", results.Timestamp.Format("2006-01-02 15:04:05")))
+
+	// Function to write summary
+	writeSummary := func() {
+		output.WriteString(bold("Summary:") + "\n")
+		output.WriteString(fmt.Sprintf("  Total Tests: %d\n", results.Summary.TotalTests))
+		output.WriteString(fmt.Sprintf("  Successful: %d\n", results.Summary.SuccessfulTests))
+		output.WriteString(fmt.Sprintf("  Failed: %d\n", results.Summary.FailedTests))
+		if results.Summary.UncachedTests > 0 {
+			output.WriteString(fmt.Sprintf("  Not Cached: %d\n", results.Summary.UncachedTests))
+		}
+		if results.Summary.Interrupted {
+			output.WriteString(fmt.Sprintf("  Interrupted: partial results, %d in-flight queries skipped\n", results.Summary.SkippedTests))
+		}
+		if report := results.Summary.Interception; report != nil {
+			if report.Intercepted {
+				output.WriteString(fmt.Sprintf("  Warning: port 53 appears intercepted on this network (%s); the answers may come from the interceptor rather than the listed servers\n",
+					strings.Join(report.Reasons, "; ")))
+			} else {
+				output.WriteString(fmt.Sprintf("  Port 53 Interception: none detected (%d of %d public resolvers answered the control query)\n",
+					len(report.ResolverEgress), len(report.ResolverEgress)+report.UnansweredControls))
+			}
+		}
+		output.WriteString(fmt.Sprintf("  Overall Success Rate: %.2f%%\n", results.Summary.SuccessRate))
+		if critical := results.Summary.Critical; critical != nil {
+			output.WriteString(fmt.Sprintf("  Critical Success Rate: %.2f%% (%d/%d)\n", critical.SuccessRate, critical.SuccessfulTests, critical.TotalTests))
+		}
+		output.WriteString(fmt.Sprintf("  Average Response Time: %v\n", results.Summary.AverageResponseTime))
+		output.WriteString(fmt.Sprintf("  Queries Sent: %d (%d bytes received)\n", results.Summary.TotalQueries, results.Summary.TotalBytesReceived))
+		if sources := formatAnswerSources(results.Summary.AnswerSources); sources != "" {
+			output.WriteString(fmt.Sprintf("  Answer Sources (heuristic): %s\n", sources))
+		}
+		if results.Summary.RetriedTests > 0 {
+			output.WriteString(fmt.Sprintf("  Retries: %d of %d retried tests succeeded\n",
+				results.Summary.RecoveredByRetry, results.Summary.RetriedTests))
+		}
+		if results.Summary.SecondPassRetries > 0 {
+			output.WriteString(fmt.Sprintf("  Second Pass: %d of %d failures recovered on retry\n",
+				results.Summary.RecoveredFailures, results.Summary.SecondPassRetries))
+		}
+		if p := results.Summary.Percentiles; p != nil {
+			output.WriteString(fmt.Sprintf("  Response Time Percentiles (%s): p50 %v, p90 %v, p99 %v\n", p.Method, p.P50, p.P90, p.P99))
+		}
+		if results.Summary.Sampled {
+			output.WriteString(fmt.Sprintf("  Sampled Run: %.2f%% of %d pairs (seed %d), rates are estimates\n",
+				results.Summary.SamplePercent, results.Summary.MatrixSize, results.Summary.SampleSeed))
+		}
+		if results.Summary.ReducedSamples > 0 {
+			output.WriteString(fmt.Sprintf("  Reduced Samples: %d tests cut short by --deadline\n", results.Summary.ReducedSamples))
+		}
 
-	// Stop progress bar
-	done <- true
-	fmt.Fprintf(os.Stderr, "\n\n")
+		if results.Summary.Cycle > 0 {
+			output.WriteString(fmt.Sprintf("\n  Monitoring Cycle: %d\n", results.Summary.Cycle))
+			if len(results.Summary.FlakyServers) > 0 {
+				output.WriteString(fmt.Sprintf("  Flaky Servers (%d):\n", len(results.Summary.FlakyServers)))
+				for _, flaky := range results.Summary.FlakyServers {
+					output.WriteString(fmt.Sprintf("    %-16s %d flips in %d cycles (%s)\n",
+						flaky.Server.IP, flaky.Flips, flaky.Cycles, flaky.Pattern))
+				}
+			}
+		}
 
-	// Sort results by server IP then domain
-	sort.Slice(allResults, func(i, j int) bool {
-		if allResults[i].Server.IP != allResults[j].Server.IP {
-			return allResults[i].Server.IP < allResults[j].Server.IP
+		if filter := results.Summary.Filter; filter != nil {
+			output.WriteString(fmt.Sprintf("\n  Server Filter (%s): %d of %d servers passed\n", filter.Expression, len(filter.Matched), filter.Tested))
+			for _, ip := range filter.Matched {
+				output.WriteString(fmt.Sprintf("    %s\n", ip))
+			}
 		}
-		return allResults[i].Domain < allResults[j].Domain
-	})
 
-	// Calculate summary
-	summary := calculateSummary(allResults)
+		if quorum := results.Summary.Quorum; quorum != nil {
+			output.WriteString(fmt.Sprintf("\n  Quorum (%s): consensus on %d domains, %d disagreeing answers\n",
+				strings.Join(quorum.Members, ", "), quorum.Domains, len(quorum.Disagreements)))
+			for _, disagreement := range quorum.Disagreements {
+				output.WriteString(fmt.Sprintf("    %-16s %-30s %s (consensus: %s)\n", disagreement.Server.label(), disagreement.Domain,
+					disagreement.Answer, strings.Join(disagreement.Consensus, ", ")))
+			}
+		}
 
-	return TestResults{
-		Timestamp: time.Now(),
-		Results:   allResults,
-		Summary:   summary,
-	}
-}
+		if expected := results.Summary.ExpectedZone; expected != nil {
+			output.WriteString(fmt.Sprintf("\n  Expected Zone (%s): %d domains checked, %d unexpected answers\n",
+				expected.File, expected.Domains, len(expected.Mismatches)))
+			for _, mismatch := range expected.Mismatches {
+				output.WriteString(fmt.Sprintf("    %-16s %-30s %s (expected: %s)\n", mismatch.Server.label(), mismatch.Domain,
+					mismatch.Answer, strings.Join(mismatch.Expected, ", ")))
+			}
+		}
 
-func showProgress(completed *int64, total int, startTime time.Time, done chan bool) {
-	ticker := time.NewTicker(ProgressUpdateRate)
-	defer ticker.Stop()
+		if len(results.Summary.IPv6) > 0 {
+			output.WriteString("\n  IPv6 (AAAA) Answers:\n")
+			for _, coverage := range results.Summary.IPv6 {
+				output.WriteString(fmt.Sprintf("    %-16s %d of %d resolved names (%.2f%%)\n",
+					coverage.Server.label(), coverage.IPv6Answers, coverage.Resolved, coverage.IPv6Coverage))
+			}
+		}
 
-	for {
-		select {
-		case <-done:
-			return
-		case <-ticker.C:
-			current := atomic.LoadInt64(completed)
-			elapsed := time.Since(startTime)
+		if len(results.Summary.IPDistribution) > 0 {
+			output.WriteString("\n  Resolved IP Distribution:\n")
+			for _, dist := range results.Summary.IPDistribution {
+				output.WriteString(fmt.Sprintf("    %s (%d servers)\n", dist.Domain, dist.Servers))
+				for _, share := range dist.Answers {
+					sinkhole := ""
+					if share.Sinkhole {
+						sinkhole = " [sinkhole]"
+					}
+					output.WriteString(fmt.Sprintf("      %-39s %6.2f%%  %s%s\n", share.IP, share.Percent, strings.Join(share.Servers, ", "), sinkhole))
+				}
+				if len(dist.Failed) > 0 {
+					output.WriteString(fmt.Sprintf("      %-39s %6.2f%%  %s\n", "(no address)",
+						float64(len(dist.Failed))/float64(dist.Servers)*100, strings.Join(dist.Failed, ", ")))
+				}
+			}
+		}
 
-			// Calculate progress
-			progress := float64(current) / float64(total)
-			percentage := progress * 100
+		if critical := results.Summary.Critical; critical != nil && len(critical.Failing) > 0 {
+			output.WriteString(fmt.Sprintf("\n  Failing Critical Domains (%d):\n", len(critical.Failing)))
+			for _, failure := range critical.Failing {
+				output.WriteString(fmt.Sprintf("    %-30s failed on %d of %d servers\n", failure.Domain, failure.FailedServers, failure.Servers))
+			}
+		}
 
-			// Calculate ETA
-			var eta time.Duration
-			if current > 0 {
-				avgTimePerJob := elapsed / time.Duration(current)
-				remaining := int64(total) - current
-				eta = avgTimePerJob * time.Duration(remaining)
+		if regression := results.Summary.Regression; regression != nil {
+			output.WriteString(fmt.Sprintf("\n  Regressions vs Baseline (%d of %d servers, threshold %.0f%%):\n",
+				regression.Regressed, len(regression.Servers), regression.Threshold))
+			for _, server := range regression.Servers {
+				if !server.Regressed {
+					continue
+				}
+				if server.Failing {
+					output.WriteString(fmt.Sprintf("    %-16s now failing (avg was %v)\n", server.Server.label(), server.BaselineAverage))
+					continue
+				}
+				output.WriteString(fmt.Sprintf("    %-16s %v -> %v (%+.1f%%)\n",
+					server.Server.label(), server.BaselineAverage, server.Average, server.Change))
 			}
+		}
 
-			// Create progress bar
-			filled := int(progress * float64(ProgressBarWidth))
-			bar := strings.Repeat("█", filled) + strings.Repeat("░", ProgressBarWidth-filled)
+		if len(results.Summary.Alerts) > 0 {
+			output.WriteString(fmt.Sprintf("\n  Alerts (%d):\n", len(results.Summary.Alerts)))
+			for _, alert := range results.Summary.Alerts {
+				output.WriteString(fmt.Sprintf("    %-16s %.2f%% success, below %.2f%% for %d cycles\n",
+					alert.Server.IP, alert.SuccessRate, alert.Threshold, alert.Cycles))
+			}
+		}
 
-			// Format time durations
-			elapsedStr := formatDuration(elapsed)
-			etaStr := formatDuration(eta)
+		if len(results.Summary.Reliability) > 0 {
+			output.WriteString("\n  Smoothed Success Rates (EMA):\n")
+			for _, entry := range results.Summary.Reliability {
+				output.WriteString(fmt.Sprintf("    %-16s %6.2f%% smoothed, %6.2f%% this cycle\n",
+					entry.Server.label(), entry.SmoothedSuccessRate, entry.SuccessRate))
+			}
+		}
 
-			// Print progress
-			fmt.Fprintf(os.Stderr, "\r[%s] %d/%d (%.1f%%) | Elapsed: %s | ETA: %s",
-				bar, current, total, percentage, elapsedStr, etaStr)
+		if len(results.Summary.AnyBehavior) > 0 {
+			output.WriteString("\n  ANY Query Behavior:\n")
+			for _, server := range results.Summary.AnyBehavior {
+				var counts []string
+				for _, behavior := range anyBehaviors {
+					if count := server.Behaviors[behavior]; count > 0 {
+						counts = append(counts, fmt.Sprintf("%s %d", behavior, count))
+					}
+				}
+				output.WriteString(fmt.Sprintf("    %-16s %-8s (%s)\n", server.Server.IP, server.Behavior, strings.Join(counts, ", ")))
+			}
 		}
-	}
-}
 
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%.1fs", d.Seconds())
-	}
-	minutes := int(d.Minutes())
-	seconds := int(d.Seconds()) % 60
-	return fmt.Sprintf("%dm%ds", minutes, seconds)
-}
+		var noisy []ServerConfidence
+		for _, confidence := range results.Summary.Confidence {
+			// Too few measurements also mean low confidence, but aren't noise
+			if confidence.Confidence == ConfidenceLow && confidence.Measurements >= ConfidenceMinMeasurements {
+				noisy = append(noisy, confidence)
+			}
+		}
+		if len(noisy) > 0 {
+			output.WriteString(fmt.Sprintf("\n  Warning: %d servers have measurements too noisy to rank reliably:\n", len(noisy)))
+			for _, confidence := range noisy {
+				output.WriteString(fmt.Sprintf("    %-16s mean %v, stddev %v (cv %.2f, %d measurements)\n",
+					confidence.Server.IP, confidence.Mean, confidence.StdDev, confidence.CV, confidence.Measurements))
+			}
+		}
 
-func testDNS(server DNSServer, domain string, timeout time.Duration) TestResult {
-	client := &dns.Client{
-		Timeout: timeout,
-	}
+		if len(results.Summary.AdaptiveTimeouts) > 0 {
+			output.WriteString("\n  Adaptive Timeouts:\n")
+			for _, timeout := range results.Summary.AdaptiveTimeouts {
+				if timeout.Median > 0 {
+					output.WriteString(fmt.Sprintf("    %-16s %v (median %v)\n", timeout.Server.IP, timeout.Timeout, timeout.Median))
+				} else {
+					output.WriteString(fmt.Sprintf("    %-16s %v (calibration failed)\n", timeout.Server.IP, timeout.Timeout))
+				}
+			}
+		}
 
-	msg := new(dns.Msg)
-	msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+		if len(results.Summary.ColdWarm) > 0 {
+			output.WriteString("\n  Cold vs Warm Latency:\n")
+			for _, server := range results.Summary.ColdWarm {
+				output.WriteString(fmt.Sprintf("    %-16s cold %v, warm %v, delta %v (%d pairs)\n",
+					server.Server.IP, server.Cold, server.Warm, server.Delta, server.Pairs))
+			}
+		}
 
-	start := time.Now()
-	response, _, err := client.Exchange(msg, net.JoinHostPort(server.IP, "53"))
-	responseTime := time.Since(start)
+		if sla := results.Summary.SLA; sla != nil {
+			output.WriteString(fmt.Sprintf("\n  Latency SLA (%s <= %v): %d/%d servers met\n",
+				sla.Metric, sla.Threshold, sla.Met, len(sla.Servers)))
+			for _, server := range sla.Servers {
+				switch {
+				case server.Met:
+				case server.Latency == 0:
+					output.WriteString(fmt.Sprintf("    %-16s missed, no successful responses\n", server.Server.IP))
+				default:
+					output.WriteString(fmt.Sprintf("    %-16s missed, %s %v (over by %v)\n",
+						server.Server.IP, sla.Metric, server.Latency.Truncate(time.Microsecond), server.Over.Truncate(time.Microsecond)))
+				}
+			}
+		}
 
-	result := TestResult{
-		Server:       server,
-		Domain:       domain,
-		ResponseTime: responseTime,
-	}
+		if len(results.Summary.SerialMismatches) > 0 {
+			output.WriteString(fmt.Sprintf("\n  SOA Serial Mismatches (%d):\n", len(results.Summary.SerialMismatches)))
+			for _, mismatch := range results.Summary.SerialMismatches {
+				var serials []uint32
+				for serial := range mismatch.Serials {
+					serials = append(serials, serial)
+				}
+				sort.Slice(serials, func(i, j int) bool { return serials[i] < serials[j] })
 
-	if err != nil {
-		result.Success = false
-		result.Error = err.Error()
-		return result
-	}
+				var parts []string
+				for _, serial := range serials {
+					parts = append(parts, fmt.Sprintf("%d (%s)", serial, strings.Join(mismatch.Serials[serial], ", ")))
+				}
+				output.WriteString(fmt.Sprintf("    %-22s %s\n", mismatch.Domain, strings.Join(parts, "; ")))
+			}
+		}
 
-	if response == nil || len(response.Answer) == 0 {
-		result.Success = false
-		result.Error = "No answer received"
-		return result
-	}
+		if len(results.Summary.TXTMismatches) > 0 {
+			output.WriteString(fmt.Sprintf("\n  TXT Mismatches (%d):\n", len(results.Summary.TXTMismatches)))
+			for _, mismatch := range results.Summary.TXTMismatches {
+				var values []string
+				for value := range mismatch.Values {
+					values = append(values, value)
+				}
+				sort.Strings(values)
 
-	// Get the first A record
-	for _, answer := range response.Answer {
-		if a, ok := answer.(*dns.A); ok {
-			result.Success = true
-			result.IP = a.A.String()
-			break
+				output.WriteString(fmt.Sprintf("    %s\n", mismatch.Domain))
+				for _, value := range values {
+					output.WriteString(fmt.Sprintf("      %q (%s)\n", value, strings.Join(mismatch.Values[value], ", ")))
+				}
+			}
 		}
-	}
-
-	if !result.Success {
-		result.Error = "No A record found in response"
-	}
 
-	return result
-}
+		if results.Summary.NameMismatches > 0 {
+			output.WriteString(fmt.Sprintf("\n  Suspicious Responses, question name mismatch (%d):\n", results.Summary.NameMismatches))
+			for _, result := range results.Results {
+				if result.NameMismatch {
+					output.WriteString(fmt.Sprintf("    %-16s %-22s answered for %s\n", result.Server.IP, result.Domain, result.ResponseName))
+				}
+			}
+		}
 
-func calculateSummary(results []TestResult) Summary {
-	totalTests := len(results)
-	successfulTests := 0
-	var totalResponseTime time.Duration
+		if results.Summary.NonRecursiveServers > 0 {
+			output.WriteString(fmt.Sprintf("\n  Non-recursive Servers (%d):\n", results.Summary.NonRecursiveServers))
+			for _, profile := range results.Servers {
+				if profile.Recursive != nil && !*profile.Recursive {
+					output.WriteString(fmt.Sprintf("    %-16s %s\n", profile.Server.IP, profile.RecursionRcode))
+				}
+			}
+		}
 
-	// Category-based statistics
-	categoryStats := make(map[string]CategoryStats)
-	categoryResults := make(map[string][]TestResult)
+		if results.Summary.WildcardResponders > 0 {
+			output.WriteString(fmt.Sprintf("\n  Wildcard Responders (%d):\n", results.Summary.WildcardResponders))
+			for _, profile := range results.Servers {
+				if profile.WildcardResponder != nil && *profile.WildcardResponder {
+					output.WriteString(fmt.Sprintf("    %-16s resolves random nonexistent names\n", profile.Server.IP))
+				}
+			}
+		}
 
-	// Group results by category
-	for _, result := range results {
-		categoryResults[result.Category] = append(categoryResults[result.Category], result)
+		if results.Summary.HighLossServers > 0 {
+			output.WriteString(fmt.Sprintf("\n  High Packet Loss Servers (%d):\n", results.Summary.HighLossServers))
+			for _, profile := range results.Servers {
+				if profile.PacketLoss != nil && *profile.PacketLoss > HighPacketLossThreshold {
+					output.WriteString(fmt.Sprintf("    %-16s %.2f%% of %d probes lost\n", profile.Server.IP, *profile.PacketLoss, profile.LossProbes))
+				}
+			}
+		}
 
-		if result.Success {
-			successfulTests++
-			totalResponseTime += result.ResponseTime
+		var protocols []string
+		for protocol := range results.Summary.ProtocolStats {
+			protocols = append(protocols, protocol)
 		}
-	}
+		sort.Strings(protocols)
 
-	// Calculate category statistics
-	for category, catResults := range categoryResults {
-		catTotal := len(catResults)
-		catSuccessful := 0
+		output.WriteString("\n  Protocol Breakdown:\n")
+		for _, protocol := range protocols {
+			stats := results.Summary.ProtocolStats[protocol]
+			output.WriteString(fmt.Sprintf("    %-12s: %.2f%% (%d/%d), avg %v\n",
+				protocol, stats.SuccessRate, stats.SuccessfulTests, stats.TotalTests, stats.AverageResponseTime))
+		}
 
-		for _, result := range catResults {
-			if result.Success {
-				catSuccessful++
+		if len(results.Summary.Ports) > 0 {
+			output.WriteString("\n  Ports Answered:\n")
+			for _, server := range results.Summary.Ports {
+				answered, silent := strings.Join(server.Answered, ", "), strings.Join(server.Silent, ", ")
+				if answered == "" {
+					answered = "none"
+				}
+				if silent == "" {
+					silent = "none"
+				}
+				output.WriteString(fmt.Sprintf("    %-16s answered: %s; silent: %s\n", server.IP, answered, silent))
 			}
 		}
 
-		catFailed := catTotal - catSuccessful
-		catSuccessRate := float64(catSuccessful) / float64(catTotal) * 100
-
-		categoryStats[category] = CategoryStats{
-			TotalTests:      catTotal,
-			SuccessfulTests: catSuccessful,
-			FailedTests:     catFailed,
-			SuccessRate:     catSuccessRate,
+		if len(results.Summary.TransportDelta) > 0 {
+			output.WriteString("\n  UDP vs TCP Latency:\n")
+			for _, delta := range results.Summary.TransportDelta {
+				if !delta.TCPAvailable {
+					output.WriteString(fmt.Sprintf("    %-16s: UDP %v (%d/%d), TCP unavailable\n",
+						delta.Server.label(), delta.UDPAverage.Round(time.Microsecond), delta.UDPSuccesses, delta.Queries))
+					continue
+				}
+				output.WriteString(fmt.Sprintf("    %-16s: UDP %v (%d/%d), TCP %v (%d/%d), delta %v\n",
+					delta.Server.label(), delta.UDPAverage.Round(time.Microsecond), delta.UDPSuccesses, delta.Queries,
+					delta.TCPAverage.Round(time.Microsecond), delta.TCPSuccesses, delta.Queries, delta.Delta.Round(time.Microsecond)))
+			}
 		}
-	}
-
-	failedTests := totalTests - successfulTests
-	successRate := float64(successfulTests) / float64(totalTests) * 100
 
-	var avgResponseTime time.Duration
-	if successfulTests > 0 {
-		avgResponseTime = totalResponseTime / time.Duration(successfulTests)
-	}
+		if len(results.Summary.ConnectionReuse) > 0 {
+			output.WriteString("\n  Connection Reuse:\n")
+			for _, reuse := range results.Summary.ConnectionReuse {
+				output.WriteString(fmt.Sprintf("    %-16s: %d established, %d reused (%.2f%%)\n",
+					reuse.Server, reuse.Dialed, reuse.Reused, reuse.ReuseRate))
+			}
+		}
 
-	return Summary{
-		TotalTests:          totalTests,
-		SuccessfulTests:     successfulTests,
-		FailedTests:         failedTests,
-		SuccessRate:         successRate,
-		AverageResponseTime: avgResponseTime,
-		CategoryStats:       categoryStats,
-	}
-}
+		if len(results.Summary.FamilyStats) > 0 {
+			output.WriteString("\n  Address Family Success Rates (dual-stack servers):\n")
+			for _, family := range []string{FamilyIPv4, FamilyIPv6} {
+				if stats, exists := results.Summary.FamilyStats[family]; exists {
+					output.WriteString(fmt.Sprintf("    %-12s: %.2f%% (%d/%d)\n",
+						family, stats.SuccessRate, stats.SuccessfulTests, stats.TotalTests))
+				}
+			}
+		}
 
-func outputResults(results TestResults, outputFile, format string) error {
-	var output strings.Builder
+		if results.Summary.CookieCheckedServers > 0 {
+			output.WriteString(fmt.Sprintf("\n  DNS Cookie Support (%d/%d servers):\n",
+				results.Summary.CookieServers, results.Summary.CookieCheckedServers))
+			for _, profile := range results.Servers {
+				if profile.CookieSupported != nil && !*profile.CookieSupported {
+					output.WriteString(fmt.Sprintf("    %-16s no server cookie\n", profile.Server.IP))
+				}
+			}
+		}
 
-	switch format {
-	case "json":
-		jsonData, err := json.MarshalIndent(results, "", "  ")
-		if err != nil {
-			return err
+		if results.Summary.QNAMEMinChecked > 0 {
+			output.WriteString(fmt.Sprintf("\n  QNAME Minimization (%d/%d servers):\n",
+				results.Summary.QNAMEMinServers, results.Summary.QNAMEMinChecked))
+			for _, profile := range results.Servers {
+				if profile.QNAMEMinimization != nil && !*profile.QNAMEMinimization {
+					output.WriteString(fmt.Sprintf("    %-16s sends the full query name upstream\n", profile.Server.IP))
+				}
+			}
 		}
-		output.Write(jsonData)
-	case "text":
-		writeTextOutput(&output, results)
-	default:
-		return fmt.Errorf("unsupported format: %s", format)
-	}
 
-	if outputFile != "" {
-		return os.WriteFile(outputFile, []byte(output.String()), 0644)
-	}
+		if results.Summary.TTLRaisingServers > 0 {
+			output.WriteString(fmt.Sprintf("\n  Minimum TTL Enforced (%d):\n", results.Summary.TTLRaisingServers))
+			for _, profile := range results.Servers {
+				if profile.TTLRaised != nil && *profile.TTLRaised {
+					output.WriteString(fmt.Sprintf("    %-16s raised TTL %ds to %ds (minimum TTL >= %ds)\n",
+						profile.Server.IP, profile.AuthoritativeTTL, profile.ObservedTTL, profile.ObservedTTL))
+				}
+			}
+		}
 
-	fmt.Print(output.String())
-	return nil
-}
+		if len(results.Summary.BogusServers) > 0 {
+			output.WriteString(fmt.Sprintf("\n  Non-Routable Answers (%d servers):\n", len(results.Summary.BogusServers)))
+			for _, bogus := range results.Summary.BogusServers {
+				output.WriteString(fmt.Sprintf("    %-16s %d answers\n", bogus.Server.label(), bogus.Answers))
+			}
+		}
 
-func writeTextOutput(output *strings.Builder, results TestResults) {
-	output.WriteString("DNS Check Results\n")
-	output.WriteString("=================\n")
-	output.WriteString(fmt.Sprintf("Timestamp: %s\n\n", results.Timestamp.Format("2006-01-02 15:04:05")))
+		if len(results.Summary.TruncatingServers) > 0 {
+			output.WriteString(fmt.Sprintf("\n  Truncated UDP Answers, retried over TCP (%d servers):\n", len(results.Summary.TruncatingServers)))
+			for _, truncating := range results.Summary.TruncatingServers {
+				output.WriteString(fmt.Sprintf("    %-16s %d answers\n", truncating.Server.label(), truncating.Fallbacks))
+			}
+		}
 
-	// Function to write summary
-	writeSummary := func() {
-		output.WriteString("Summary:\n")
-		output.WriteString(fmt.Sprintf("  Total Tests: %d\n", results.Summary.TotalTests))
-		output.WriteString(fmt.Sprintf("  Successful: %d\n", results.Summary.SuccessfulTests))
-		output.WriteString(fmt.Sprintf("  Failed: %d\n", results.Summary.FailedTests))
-		output.WriteString(fmt.Sprintf("  Overall Success Rate: %.2f%%\n", results.Summary.SuccessRate))
-		output.WriteString(fmt.Sprintf("  Average Response Time: %v\n", results.Summary.AverageResponseTime))
+		if results.Summary.RateLimitedServers > 0 {
+			output.WriteString(fmt.Sprintf("\n  Rate Limited Servers (%d):\n", results.Summary.RateLimitedServers))
+			for _, profile := range results.Servers {
+				if profile.RateLimitQPS > 0 {
+					output.WriteString(fmt.Sprintf("    %-16s degraded at ~%d QPS\n", profile.Server.IP, profile.RateLimitQPS))
+				}
+			}
+		}
 
 		// Category-based summary
 		output.WriteString("\n  Category Success Rates:\n")
@@ -1185,16 +3708,74 @@ func writeTextOutput(output *strings.Builder, results TestResults) {
 					category, stats.SuccessRate, stats.SuccessfulTests, stats.TotalTests))
 			}
 		}
+
+		if len(results.Summary.TypeStats) > 0 {
+			output.WriteString("\n  Record Type Success Rates:\n")
+			for _, qtype := range sortedQueryTypes(results.Summary.TypeStats) {
+				stats := results.Summary.TypeStats[qtype]
+				output.WriteString(fmt.Sprintf("    %-12s: %.2f%% (%d/%d)\n",
+					qtype, stats.SuccessRate, stats.SuccessfulTests, stats.TotalTests))
+			}
+		}
+
+		if len(results.Summary.CategoryWinners) > 0 {
+			output.WriteString("\n  Category Winners:\n")
+			output.WriteString(fmt.Sprintf("    %-12s  %-16s %-8s %7s %8s  %s\n", "Category", "Server", "Goal", "Score", "Rate", "Avg"))
+			for _, winner := range results.Summary.CategoryWinners {
+				goal := "resolve"
+				if winner.Blocking {
+					goal = "block"
+				}
+				output.WriteString(fmt.Sprintf("    %-12s  %-16s %-8s %7.2f %7.2f%%  %v\n",
+					winner.Category, winner.Server.IP, goal, winner.Score, winner.Rate, winner.AverageResponseTime))
+			}
+		}
+
+		if len(results.Summary.Countries) > 0 {
+			output.WriteString("\n  Country Breakdown:\n")
+			for _, country := range results.Summary.Countries {
+				output.WriteString(fmt.Sprintf("    %-8s: %.2f%% (%d/%d), avg %v, %d servers\n",
+					country.Country, country.Stats.SuccessRate, country.Stats.SuccessfulTests, country.Stats.TotalTests,
+					country.AverageResponseTime, len(country.Servers)))
+			}
+		}
+
+		if len(results.Summary.PairConsistency) > 0 {
+			diverging := 0
+			for _, pair := range results.Summary.PairConsistency {
+				if pair.Diverges {
+					diverging++
+				}
+			}
+			output.WriteString(fmt.Sprintf("\n  Primary/Secondary Consistency (%d of %d pairs diverge):\n", diverging, len(results.Summary.PairConsistency)))
+			for _, pair := range results.Summary.PairConsistency {
+				status := "OK"
+				if pair.Diverges {
+					status = "DIVERGES"
+				}
+				output.WriteString(fmt.Sprintf("    %-16s / %-16s %-8s %d/%d answers match, avg %v / %v\n",
+					pair.Primary.IP, pair.Secondary.IP, status, pair.Domains-len(pair.Mismatches), pair.Domains,
+					pair.PrimaryAverage, pair.SecondaryAverage))
+				if len(pair.Mismatches) > 0 {
+					output.WriteString(fmt.Sprintf("      Differing outcomes: %s\n", strings.Join(pair.Mismatches, ", ")))
+				}
+			}
+		}
 		output.WriteString("\n")
 	}
 
 	// Summary at the beginning
-	writeSummary()
+	if opts.Summary != SummaryBottom {
+		writeSummary()
+	}
 
 	// Group results by server
 	serverResults := make(map[string][]TestResult)
 	for _, result := range results.Results {
-		key := result.Server.IP
+		key := result.Server.label()
+		if result.Server.IPv6 != "" {
+			key += ", " + result.Server.IPv6
+		}
 		if result.Server.Description != "" {
 			key += " (" + result.Server.Description + ")"
 		}
@@ -1202,18 +3783,45 @@ func writeTextOutput(output *strings.Builder, results TestResults) {
 	}
 
 	// Sort servers
+	smoothed := results.Summary.smoothedRates()
 	var servers []string
 	for server := range serverResults {
 		servers = append(servers, server)
 	}
 	sort.Strings(servers)
+	sort.SliceStable(servers, func(i, j int) bool {
+		return serverBefore(serverResults[servers[i]], serverResults[servers[j]], opts.SortBy, smoothed)
+	})
 
-	// Output results by server
-	output.WriteString("Detailed Results:\n")
+	// With --group-by country, servers are listed under their country,
+	// keeping the order above within each country
+	var countries map[string]CountryGroup
+	if opts.GroupBy == GroupByCountry && len(results.Summary.Countries) > 0 {
+		countries = make(map[string]CountryGroup)
+		for _, country := range results.Summary.Countries {
+			countries[country.Country] = country
+		}
+		rank := countryRank(results.Summary.Countries)
+		sort.SliceStable(servers, func(i, j int) bool {
+			return rank[serverCountry(serverResults[servers[i]][0])] < rank[serverCountry(serverResults[servers[j]][0])]
+		})
+	}
+
+	// Output results by server, naming the record type when several were queried
+	multipleTypes := len(results.Summary.TypeStats) > 0
+	output.WriteString(bold("Detailed Results:") + "\n")
 	output.WriteString("-----------------\n")
 
+	currentCountry := ""
 	for _, server := range servers {
-		output.WriteString(fmt.Sprintf("\nDNS Server: %s\n", server))
+		if countries != nil {
+			if country := countries[serverCountry(serverResults[server][0])]; country.Country != currentCountry {
+				currentCountry = country.Country
+				output.WriteString(fmt.Sprintf("\n%s\n", bold(fmt.Sprintf("Country: %s (%d servers, %.2f%% success, avg %v)",
+					country.Country, len(country.Servers), country.Stats.SuccessRate, country.AverageResponseTime))))
+			}
+		}
+		output.WriteString("\n" + bold("DNS Server: "+server) + "\n")
 
 		// Group by category
 		categoryResults := make(map[string][]TestResult)
@@ -1228,17 +3836,45 @@ func writeTextOutput(output *strings.Builder, results TestResults) {
 
 				categorySuccessful := 0
 				for _, result := range results {
-					status := "FAIL"
+					status := resultStatus(result, opts.Color)
 					details := result.Error
 					if result.Success {
-						status = "OK"
 						details = result.IP
+						if result.ResolvedIPv6 != "" {
+							details = strings.TrimPrefix(details+", "+result.ResolvedIPv6, ", ")
+						}
+						if len(result.ResolvedIPs) > 1 {
+							details += fmt.Sprintf(" (%d addresses)", len(result.ResolvedIPs))
+						}
+						if result.SOA != nil {
+							details = fmt.Sprintf("serial=%d refresh=%d expire=%d",
+								result.SOA.Serial, result.SOA.Refresh, result.SOA.Expire)
+						}
+						if result.TXT != "" {
+							details = fmt.Sprintf("%q", result.TXT)
+						}
+						if details == "" && result.Rcode != "" {
+							details = result.Rcode
+						}
+						if result.TTL != nil {
+							details += fmt.Sprintf(" ttl=%d", *result.TTL)
+						}
+						if geo := formatGeo(result); geo != "" {
+							details += " [" + geo + "]"
+						}
 						categorySuccessful++
 						totalSuccessful++
 					}
 
-					output.WriteString(fmt.Sprintf("    %-22s [%4s] %8v %s\n",
-						result.Domain, status, result.ResponseTime.Truncate(time.Millisecond), details))
+					domain := result.Domain
+					if result.Family != "" {
+						domain += " (" + result.Family + ")"
+					}
+					if multipleTypes {
+						domain += " " + result.QueryType
+					}
+					output.WriteString(fmt.Sprintf("    %-22s [%s] %8v %s\n",
+						domain, status, result.ResponseTime.Truncate(time.Millisecond), details))
 				}
 
 				categoryRate := float64(categorySuccessful) / float64(len(results)) * 100
@@ -1250,10 +3886,19 @@ func writeTextOutput(output *strings.Builder, results TestResults) {
 		overallRate := float64(totalSuccessful) / float64(len(serverResults[server])) * 100
 		output.WriteString(fmt.Sprintf("  Overall Success Rate: %.2f%% (%d/%d)\n",
 			overallRate, totalSuccessful, len(serverResults[server])))
+		serverFamilies := familyStats(serverResults[server])
+		for _, family := range []string{FamilyIPv4, FamilyIPv6} {
+			if stats, exists := serverFamilies[family]; exists {
+				output.WriteString(fmt.Sprintf("  %s Success Rate: %.2f%% (%d/%d)\n",
+					strings.ToUpper(family[:2])+family[2:], stats.SuccessRate, stats.SuccessfulTests, stats.TotalTests))
+			}
+		}
 	}
 
 	// Summary at the end
-	output.WriteString("\n")
-	output.WriteString("=================\n")
-	writeSummary()
+	if opts.Summary != SummaryTop {
+		output.WriteString("\n")
+		output.WriteString("=================\n")
+		writeSummary()
+	}
 }
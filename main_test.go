@@ -0,0 +1,408 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestWriteOutputCompression(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		file     string
+		compress bool
+		gzipped  bool
+	}{
+		{"plain.json", false, false},
+		{"named.json.gz", false, true},
+		{"flagged.json", true, true},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.file)
+		if err := writeOutput("payload\n", OutputOptions{File: path, Compress: tt.compress}); err != nil {
+			t.Fatalf("writeOutput(%s) error = %v", tt.file, err)
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+
+		var reader io.Reader = file
+		if tt.gzipped {
+			gz, err := gzip.NewReader(file)
+			if err != nil {
+				t.Errorf("%s is not gzip-compressed: %v", tt.file, err)
+				continue
+			}
+			reader = gz
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil || string(data) != "payload\n" {
+			t.Errorf("%s holds %q, %v, want the payload", tt.file, data, err)
+		}
+	}
+}
+
+func TestPrepareOutputDir(t *testing.T) {
+	if err := prepareOutputDir(OutputOptions{}); err != nil {
+		t.Errorf("prepareOutputDir without a file error = %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "reports", "daily")
+	if err := prepareOutputDir(OutputOptions{File: filepath.Join(dir, "results.json")}); err != nil {
+		t.Fatalf("prepareOutputDir error = %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("output directory holds %d entries (%v), want it created and left empty", len(entries), err)
+	}
+
+	// A regular file can't be the parent directory
+	parent := writeTestFile(t, "file", "")
+	if err := prepareOutputDir(OutputOptions{File: filepath.Join(parent, "results.json")}); err == nil {
+		t.Error("prepareOutputDir under a regular file succeeded, want an error")
+	}
+}
+
+func TestAppendRuns(t *testing.T) {
+	for _, file := range []string{"runs.json", "runs.json.gz"} {
+		opts := OutputOptions{File: filepath.Join(t.TempDir(), file), Format: "json", Append: true}
+		for i := 0; i < 2; i++ {
+			results := TestResults{RunID: newRunID(), Results: []TestResult{{Domain: "example.com"}}}
+			if err := outputResults(results, opts); err != nil {
+				t.Fatalf("%s: run %d: outputResults error = %v", file, i+1, err)
+			}
+		}
+
+		data, err := readOutputFile(opts)
+		if err != nil {
+			t.Fatalf("%s: readOutputFile error = %v", file, err)
+		}
+		var runs []TestResults
+		if err := json.Unmarshal(data, &runs); err != nil {
+			t.Fatalf("%s does not hold a JSON array of runs: %v", file, err)
+		}
+		if len(runs) != 2 || runs[0].RunID == "" || runs[0].RunID == runs[1].RunID {
+			t.Errorf("%s holds %d runs, want 2 with distinct run IDs", file, len(runs))
+		}
+	}
+}
+
+func TestAppendRejectsOtherContent(t *testing.T) {
+	opts := OutputOptions{File: filepath.Join(t.TempDir(), "results.json"), Format: "json", Append: true}
+	if err := os.WriteFile(opts.File, []byte(`{"results": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := outputResults(TestResults{RunID: newRunID()}, opts); err == nil {
+		t.Error("appending to a file holding a single run succeeded, want an error")
+	}
+}
+
+func TestRunDNSTestsParallelOver(t *testing.T) {
+	// Nothing listens on these addresses, so every query fails right away
+	servers := []DNSServer{{IP: "127.0.0.253"}, {IP: "127.0.0.254"}}
+	domains := []DomainCategory{{Domain: "a.example"}, {Domain: "b.example"}, {Domain: "c.example"}}
+
+	for _, mode := range []string{ParallelOverAll, ParallelOverServers, ParallelOverDomains} {
+		results := runDNSTests(servers, domains, TestOptions{Timeout: time.Second, Workers: 2, ParallelOver: mode})
+
+		pairs := make(map[string]int)
+		for _, result := range results.Results {
+			pairs[result.Server.IP+" "+result.Domain]++
+		}
+		if len(results.Results) != len(servers)*len(domains) || len(pairs) != len(servers)*len(domains) {
+			t.Errorf("--parallel-over %s: %d results over %d pairs, want each of the %d pairs once",
+				mode, len(results.Results), len(pairs), len(servers)*len(domains))
+		}
+	}
+}
+
+// writeTestFile writes content to a file named name in a temporary directory
+// and returns its path
+func TestNoRecurse(t *testing.T) {
+	// The server only answers queries with RD set, like a cache that has
+	// nothing for the name
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.53")
+		if !r.RecursionDesired {
+			m.Answer = nil
+		}
+		w.WriteMsg(m)
+	}))
+	server := DNSServer{IP: ip}
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA}
+	if result := testDNS(newDNSClient(opts), nil, server, "example.com", opts); !result.Success || result.Uncached {
+		t.Errorf("recursive query = success %v, uncached %v, want a success", result.Success, result.Uncached)
+	}
+
+	opts.NoRecurse = true
+	results := []TestResult{
+		testDNS(newDNSClient(opts), nil, server, "example.com", opts),
+		{Server: server, Domain: "down.example", Error: "timeout"},
+	}
+	if results[0].Success || !results[0].Uncached {
+		t.Errorf("non-recursive query = success %v, uncached %v, want it not cached", results[0].Success, results[0].Uncached)
+	}
+	summary := calculateSummary(results)
+	if summary.UncachedTests != 1 || summary.FailedTests != 1 {
+		t.Errorf("summary counted %d uncached and %d failed, want 1 and 1", summary.UncachedTests, summary.FailedTests)
+	}
+}
+
+func TestNameMismatch(t *testing.T) {
+	// The server answers for another name under spoofed.example, and echoes
+	// the question in upper case for everything else
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.53")
+		if r.Question[0].Name == "spoofed.example." {
+			m.Question[0].Name = "other.example."
+			m.Answer[0].Header().Name = "other.example."
+		} else {
+			m.Question[0].Name = strings.ToUpper(m.Question[0].Name)
+		}
+		w.WriteMsg(m)
+	}))
+	server := DNSServer{IP: ip}
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA}
+
+	results := []TestResult{
+		testDNS(newDNSClient(opts), nil, server, "spoofed.example", opts),
+		testDNS(newDNSClient(opts), nil, server, "example.com", opts),
+	}
+	if !results[0].NameMismatch || results[0].ResponseName != "other.example." {
+		t.Errorf("spoofed answer = mismatch %v for %q, want it flagged for other.example.", results[0].NameMismatch, results[0].ResponseName)
+	}
+	if results[1].NameMismatch {
+		t.Error("answer echoing the name in another case flagged as a mismatch")
+	}
+	if summary := calculateSummary(results); summary.NameMismatches != 1 {
+		t.Errorf("summary counted %d mismatches, want 1", summary.NameMismatches)
+	}
+}
+
+func TestSharedDNSClient(t *testing.T) {
+	// Names under slow.example never get an answer
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if strings.HasSuffix(r.Question[0].Name, "slow.example.") {
+			return
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	server := DNSServer{IP: ip}
+	opts := TestOptions{Timeout: 200 * time.Millisecond, QueryType: dns.TypeA}
+	client := newDNSClient(opts)
+
+	// One client serves concurrent queries, and a query that times out
+	// doesn't hold up the others
+	var wg sync.WaitGroup
+	results := make([]TestResult, 20)
+	for i := range results {
+		domain := fmt.Sprintf("name%d.example", i)
+		if i == 0 {
+			domain = "slow.example"
+		}
+		wg.Add(1)
+		go func(i int, domain string) {
+			defer wg.Done()
+			results[i] = testDNS(client, nil, server, domain, opts)
+		}(i, domain)
+	}
+	wg.Wait()
+
+	if results[0].Success || results[0].ResponseTime > time.Second {
+		t.Errorf("unanswered query = success %v after %v, want a failure after the 200ms timeout",
+			results[0].Success, results[0].ResponseTime)
+	}
+	for i, result := range results[1:] {
+		if !result.Success || result.Domain != fmt.Sprintf("name%d.example", i+1) {
+			t.Errorf("query %d = %+v, want a success for its own domain", i+1, result)
+		}
+	}
+}
+
+func TestParseSuccessRcodes(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    []int
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"  ", nil, false},
+		{"NOERROR", []int{dns.RcodeSuccess}, false},
+		{"noerror, nxdomain", []int{dns.RcodeSuccess, dns.RcodeNameError}, false},
+		{"NOERROR,BOGUS", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSuccessRcodes(tt.list)
+		if (err != nil) != tt.wantErr || len(got) != len(tt.want) {
+			t.Errorf("parseSuccessRcodes(%q) = %v, %v, want %v, error %v", tt.list, got, err, tt.want, tt.wantErr)
+			continue
+		}
+		for _, rcode := range tt.want {
+			if !got[rcode] {
+				t.Errorf("parseSuccessRcodes(%q) lacks %s", tt.list, dns.RcodeToString[rcode])
+			}
+		}
+	}
+}
+
+func TestSuccessRcodes(t *testing.T) {
+	// Names under gone.example don't exist
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if strings.HasSuffix(r.Question[0].Name, "gone.example.") {
+			m := new(dns.Msg)
+			m.SetRcode(r, dns.RcodeNameError)
+			w.WriteMsg(m)
+			return
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	server := DNSServer{IP: ip}
+
+	tests := []struct {
+		list    string
+		domain  string
+		success bool
+		rcode   string
+	}{
+		{"", "gone.example", false, ""},
+		{"NXDOMAIN", "gone.example", true, "NXDOMAIN"},
+		{"NXDOMAIN", "example.com", false, "NOERROR"},
+		{"NOERROR,NXDOMAIN", "example.com", true, "NOERROR"},
+		{"NOERROR,NXDOMAIN", "gone.example", true, "NXDOMAIN"},
+	}
+	for _, tt := range tests {
+		rcodes, err := parseSuccessRcodes(tt.list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, SuccessRcodes: rcodes}
+		result := testDNS(newDNSClient(opts), nil, server, tt.domain, opts)
+		if result.Success != tt.success || result.Rcode != tt.rcode {
+			t.Errorf("--success-rcodes %q for %s = success %v, rcode %q, want %v, %q",
+				tt.list, tt.domain, result.Success, result.Rcode, tt.success, tt.rcode)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTextOutputSummaryPosition(t *testing.T) {
+	results := TestResults{Results: []TestResult{
+		{Server: DNSServer{IP: "192.0.2.1"}, Domain: "example.com", Category: CategoryGeneral, Success: true},
+	}}
+	results.Summary = calculateSummary(results.Results)
+
+	tests := []struct {
+		position  string
+		summaries int
+		first     bool // Whether the summary precedes the results
+	}{
+		{SummaryTop, 1, true},
+		{SummaryBottom, 1, false},
+		{SummaryBoth, 2, true},
+	}
+	for _, tt := range tests {
+		var output strings.Builder
+		writeTextOutput(&output, results, OutputOptions{Summary: tt.position})
+		text := output.String()
+		if got := strings.Count(text, "Summary:"); got != tt.summaries {
+			t.Errorf("%s: %d summaries, want %d", tt.position, got, tt.summaries)
+		}
+		if first := strings.Index(text, "Summary:") < strings.Index(text, "example.com"); first != tt.first {
+			t.Errorf("%s: summary before the results = %v, want %v", tt.position, first, tt.first)
+		}
+	}
+}
+
+func TestCountAnswers(t *testing.T) {
+	answers := []dns.RR{
+		&dns.CNAME{Hdr: dns.RR_Header{Name: "www.example.", Rrtype: dns.TypeCNAME}, Target: "example."},
+		&dns.A{Hdr: dns.RR_Header{Name: "example.", Rrtype: dns.TypeA}},
+		&dns.A{Hdr: dns.RR_Header{Name: "example.", Rrtype: dns.TypeA}},
+	}
+	tests := []struct {
+		qtype uint16
+		want  int
+	}{
+		{dns.TypeA, 2},
+		{dns.TypeAAAA, 0},
+		{dns.TypeANY, 3},
+	}
+	for _, tt := range tests {
+		if got := countAnswers(answers, tt.qtype); got != tt.want {
+			t.Errorf("countAnswers(%s) = %d, want %d", dns.TypeToString[tt.qtype], got, tt.want)
+		}
+	}
+}
+
+func TestMinAnswers(t *testing.T) {
+	// Every answer holds two A records
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.1")
+		m.Answer = append(m.Answer, answerA(r, "192.0.2.2").Answer...)
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	tests := []struct {
+		minAnswers  int
+		wantSuccess bool
+		wantCount   int
+	}{
+		{1, true, 0},
+		{2, true, 2},
+		{3, false, 2},
+	}
+	for _, tt := range tests {
+		opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP, MinAnswers: tt.minAnswers}
+		result := testDNS(newDNSClient(opts), nil, server, "example.com", opts)
+		if result.Success != tt.wantSuccess || result.AnswerCount != tt.wantCount {
+			t.Errorf("--min-answers %d: success %v with %d answers (%s), want %v with %d",
+				tt.minAnswers, result.Success, result.AnswerCount, result.Error, tt.wantSuccess, tt.wantCount)
+		}
+	}
+}
+
+func TestResolvedIPs(t *testing.T) {
+	// A round-robin answer of three A records
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.1")
+		m.Answer = append(m.Answer, answerA(r, "192.0.2.2").Answer...)
+		m.Answer = append(m.Answer, answerA(r, "192.0.2.3").Answer...)
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP}
+	result := testDNS(newDNSClient(opts), nil, server, "example.com", opts)
+	want := []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}
+	if !result.Success || result.IP != want[0] || strings.Join(result.ResolvedIPs, ",") != strings.Join(want, ",") {
+		t.Errorf("testDNS = %q and %v, want %q and %v", result.IP, result.ResolvedIPs, want[0], want)
+	}
+	if compact := compactResult(result); compact.ResolvedIPs != nil {
+		t.Errorf("compactResult kept %v", compact.ResolvedIPs)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import "strings"
+
+// FlakyWindow is the number of recent monitoring cycles the flaky detector
+// looks at
+const FlakyWindow = 10
+
+// FlakyServer represents a server whose outcome keeps changing between
+// monitoring cycles
+type FlakyServer struct {
+	Server  DNSServer `json:"server"`
+	Flips   int       `json:"flips"`   // Up/down changes within the window
+	Cycles  int       `json:"cycles"`  // Cycles in the window
+	Pattern string    `json:"pattern"` // Outcome per cycle, oldest first: U up, D down
+}
+
+// flakyTracker keeps the recent up/down history of every server across
+// monitoring cycles. A server is up in a cycle when at least half of its
+// tests succeeded.
+type flakyTracker struct {
+	minFlips int
+	servers  []DNSServer
+	history  map[DNSServer][]bool
+}
+
+func newFlakyTracker(minFlips int) *flakyTracker {
+	return &flakyTracker{
+		minFlips: minFlips,
+		history:  make(map[DNSServer][]bool),
+	}
+}
+
+// record adds the outcome of one cycle to the history of every tested server
+func (t *flakyTracker) record(results []TestResult) {
+	total := make(map[DNSServer]int)
+	successful := make(map[DNSServer]int)
+	for _, result := range results {
+		if _, seen := total[result.Server]; !seen {
+			if _, known := t.history[result.Server]; !known {
+				t.servers = append(t.servers, result.Server)
+			}
+		}
+		total[result.Server]++
+		if result.Success {
+			successful[result.Server]++
+		}
+	}
+
+	for server, count := range total {
+		history := append(t.history[server], successful[server]*2 >= count)
+		if len(history) > FlakyWindow {
+			history = history[len(history)-FlakyWindow:]
+		}
+		t.history[server] = history
+	}
+}
+
+// flaky returns the servers whose outcome flipped at least minFlips times
+// within the window, in the order they were first seen
+func (t *flakyTracker) flaky() []FlakyServer {
+	var flaky []FlakyServer
+	for _, server := range t.servers {
+		history := t.history[server]
+
+		flips := 0
+		var pattern strings.Builder
+		for i, up := range history {
+			if i > 0 && up != history[i-1] {
+				flips++
+			}
+			if up {
+				pattern.WriteString("U")
+			} else {
+				pattern.WriteString("D")
+			}
+		}
+
+		if flips >= t.minFlips {
+			flaky = append(flaky, FlakyServer{
+				Server:  server,
+				Flips:   flips,
+				Cycles:  len(history),
+				Pattern: pattern.String(),
+			})
+		}
+	}
+	return flaky
+}
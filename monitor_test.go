@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestFlakyTracker(t *testing.T) {
+	steady := DNSServer{IP: "1.1.1.1"}
+	flaky := DNSServer{IP: "8.8.8.8"}
+	tracker := newFlakyTracker(3)
+
+	// The flaky server alternates between up and down; two of its three
+	// tests must fail for a cycle to count as down
+	for cycle := 0; cycle < 12; cycle++ {
+		up := cycle%2 == 0
+		tracker.record([]TestResult{
+			{Server: steady, Success: true},
+			{Server: flaky, Success: true},
+			{Server: flaky, Success: up},
+			{Server: flaky, Success: up},
+		})
+	}
+
+	got := tracker.flaky()
+	if len(got) != 1 || got[0].Server != flaky {
+		t.Fatalf("flaky() = %+v, want only %s", got, flaky.IP)
+	}
+	// Only the last 10 cycles are kept
+	if got[0].Cycles != FlakyWindow || got[0].Flips != 9 || got[0].Pattern != "UDUDUDUDUD" {
+		t.Errorf("flaky server = %+v, want 9 flips over 10 cycles, pattern UDUDUDUDUD", got[0])
+	}
+}
+
+func TestFlakyTrackerMinFlips(t *testing.T) {
+	server := DNSServer{IP: "1.1.1.1"}
+	tracker := newFlakyTracker(3)
+	for _, up := range []bool{true, false, true} {
+		tracker.record([]TestResult{{Server: server, Success: up}})
+	}
+	if got := tracker.flaky(); got != nil {
+		t.Errorf("flaky() after 2 flips = %+v, want none", got)
+	}
+	tracker.record([]TestResult{{Server: server}})
+	if got := tracker.flaky(); len(got) != 1 || got[0].Pattern != "UDUD" {
+		t.Errorf("flaky() after 3 flips = %+v, want the server with pattern UDUD", got)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// NDJSON record type discriminators
+const (
+	NDJSONResult  = "result"
+	NDJSONSummary = "summary"
+)
+
+type ndjsonResult struct {
+	Type string `json:"type"`
+	TestResult
+}
+
+type ndjsonSummary struct {
+	Type      string    `json:"type"`
+	RunID     string    `json:"run_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Summary
+}
+
+// writeNDJSON writes the results as newline-delimited JSON: one line per
+// result followed by a single summary line, each a standalone JSON object
+// tagged with a "type" field
+func writeNDJSON(w io.Writer, results TestResults) error {
+	encoder := json.NewEncoder(w)
+
+	err := results.eachResult(func(result TestResult) error {
+		return encoder.Encode(ndjsonResult{Type: NDJSONResult, TestResult: result})
+	})
+	if err != nil {
+		return err
+	}
+
+	return encoder.Encode(ndjsonSummary{
+		Type:      NDJSONSummary,
+		RunID:     results.RunID,
+		Timestamp: results.Timestamp,
+		Summary:   results.Summary,
+	})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	results := TestResults{
+		RunID:     "run-1",
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Results: []TestResult{
+			{Server: DNSServer{IP: "1.1.1.1"}, Domain: "one.com", Success: true},
+			{Server: DNSServer{IP: "8.8.8.8"}, Domain: "two.com", Error: "timeout"},
+		},
+		Summary: Summary{TotalTests: 2, SuccessfulTests: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := writeNDJSON(&buf, results); err != nil {
+		t.Fatalf("writeNDJSON error = %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("writeNDJSON wrote %d lines, want 2 results and a summary", len(lines))
+	}
+
+	for i, want := range []string{"one.com", "two.com"} {
+		var record struct {
+			Type   string `json:"type"`
+			Domain string `json:"domain"`
+		}
+		if err := json.Unmarshal(lines[i], &record); err != nil || record.Type != NDJSONResult || record.Domain != want {
+			t.Errorf("line %d = %s, want the %s result", i+1, lines[i], want)
+		}
+	}
+
+	var summary struct {
+		Type       string `json:"type"`
+		RunID      string `json:"run_id"`
+		TotalTests int    `json:"total_tests"`
+	}
+	if err := json.Unmarshal(lines[2], &summary); err != nil || summary.Type != NDJSONSummary || summary.RunID != "run-1" || summary.TotalTests != 2 {
+		t.Errorf("summary line = %s, want the run's summary", lines[2])
+	}
+}
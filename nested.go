@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// JSON layouts selectable with --json-layout
+const (
+	JSONLayoutFlat   = "flat"
+	JSONLayoutNested = "nested"
+)
+
+// NestedResults is the nested JSON layout: results grouped by server, then by
+// category, mirroring the text report
+type NestedResults struct {
+	RunID     string        `json:"run_id"`
+	Timestamp time.Time     `json:"timestamp"`
+	Servers   []ServerGroup `json:"servers"`
+	Summary   Summary       `json:"summary"`
+}
+
+// ServerGroup holds the results of a single server
+type ServerGroup struct {
+	Server     DNSServer       `json:"server"`
+	Profile    *ServerProfile  `json:"profile,omitempty"`
+	Stats      CategoryStats   `json:"stats"`
+	Categories []CategoryGroup `json:"categories"`
+}
+
+// CategoryGroup holds the results of a single server for one category
+type CategoryGroup struct {
+	Category string        `json:"category"`
+	Stats    CategoryStats `json:"stats"`
+	Results  []TestResult  `json:"results"`
+}
+
+// groupStats counts the outcomes of a group of results
+func groupStats(results []TestResult) CategoryStats {
+	stats := CategoryStats{TotalTests: len(results)}
+	for _, result := range results {
+		if result.Success {
+			stats.SuccessfulTests++
+		}
+		if result.Uncached {
+			stats.UncachedTests++
+		}
+		if result.Skipped {
+			stats.SkippedTests++
+		}
+	}
+
+	stats.FailedTests = stats.TotalTests - stats.SuccessfulTests - stats.UncachedTests - stats.SkippedTests
+	if tested := stats.TotalTests - stats.SkippedTests; tested > 0 {
+		stats.SuccessRate = float64(stats.SuccessfulTests) / float64(tested) * 100
+	}
+	return stats
+}
+
+// nestResults regroups the flat results as server -> category -> results.
+// Servers keep the order of the flat results unless sortBy says otherwise;
+// categories follow CategoryOrder, with any other categories after them in
+// alphabetical order.
+func nestResults(results TestResults, sortBy string) NestedResults {
+	nested := NestedResults{
+		RunID:     results.RunID,
+		Timestamp: results.Timestamp,
+		Summary:   results.Summary,
+	}
+
+	profiles := make(map[DNSServer]*ServerProfile)
+	for i := range results.Servers {
+		profiles[results.Servers[i].Server] = &results.Servers[i]
+	}
+
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results.Results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	smoothed := results.Summary.smoothedRates()
+	sort.SliceStable(servers, func(i, j int) bool {
+		return serverBefore(byServer[servers[i]], byServer[servers[j]], sortBy, smoothed)
+	})
+
+	for _, server := range servers {
+		group := ServerGroup{
+			Server:  server,
+			Profile: profiles[server],
+			Stats:   groupStats(byServer[server]),
+		}
+
+		var categories []string
+		byCategory := make(map[string][]TestResult)
+		for _, result := range byServer[server] {
+			if _, seen := byCategory[result.Category]; !seen {
+				categories = append(categories, result.Category)
+			}
+			byCategory[result.Category] = append(byCategory[result.Category], result)
+		}
+
+		sortCategories(categories)
+
+		for _, category := range categories {
+			group.Categories = append(group.Categories, CategoryGroup{
+				Category: category,
+				Stats:    groupStats(byCategory[category]),
+				Results:  byCategory[category],
+			})
+		}
+
+		nested.Servers = append(nested.Servers, group)
+	}
+
+	return nested
+}
+
+// marshalJSONLayout marshals the results in the requested JSON layout. The
+// servers of the nested layout are ordered according to sortBy.
+func marshalJSONLayout(results TestResults, layout, sortBy string) ([]byte, error) {
+	if layout == JSONLayoutNested {
+		return json.MarshalIndent(nestResults(results, sortBy), "", "  ")
+	}
+	return json.MarshalIndent(results, "", "  ")
+}
+
+// sortCategories orders categories as CategoryOrder, with any other
+// categories after them in alphabetical order
+func sortCategories(categories []string) {
+	rank := make(map[string]int)
+	for i, category := range CategoryOrder {
+		rank[category] = i
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		ri, knownI := rank[categories[i]]
+		rj, knownJ := rank[categories[j]]
+		if knownI != knownJ {
+			return knownI
+		}
+		if knownI {
+			return ri < rj
+		}
+		return categories[i] < categories[j]
+	})
+}
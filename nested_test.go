@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNestResults(t *testing.T) {
+	a := DNSServer{IP: "192.0.2.2"}
+	b := DNSServer{IP: "192.0.2.1"}
+	recursive := true
+	results := TestResults{
+		RunID: "run",
+		Results: []TestResult{
+			{Server: a, Domain: "custom.com", Category: "Zeta", Success: true},
+			{Server: a, Domain: "ads.com", Category: CategoryAdServer, Error: "timeout"},
+			{Server: a, Domain: "one.com", Category: CategoryGeneral, Success: true},
+			{Server: b, Domain: "one.com", Category: CategoryGeneral, Success: true},
+			{Server: a, Domain: "beta.com", Category: "Beta", Success: true},
+			{Server: a, Domain: "two.com", Category: CategoryGeneral, Success: true},
+		},
+		Servers: []ServerProfile{{Server: b, Recursive: &recursive}},
+	}
+
+	nested := nestResults(results, SortByIP)
+	if nested.RunID != "run" || len(nested.Servers) != 2 {
+		t.Fatalf("nested %d servers for run %q, want 2 for run", len(nested.Servers), nested.RunID)
+	}
+	first, second := nested.Servers[0], nested.Servers[1]
+	if first.Server != a || second.Server != b {
+		t.Errorf("servers = %s, %s, want the order of the flat results", first.Server.IP, second.Server.IP)
+	}
+	if first.Profile != nil || second.Profile == nil || *second.Profile.Recursive != true {
+		t.Errorf("profiles = %v, %v, want only the probed server's", first.Profile, second.Profile)
+	}
+	if first.Stats.TotalTests != 5 || first.Stats.SuccessfulTests != 4 || first.Stats.FailedTests != 1 || first.Stats.SuccessRate != 80 {
+		t.Errorf("first server stats = %+v, want 4 of 5", first.Stats)
+	}
+
+	var categories []string
+	for _, group := range first.Categories {
+		categories = append(categories, group.Category)
+	}
+	want := []string{CategoryGeneral, CategoryAdServer, "Beta", "Zeta"}
+	if len(categories) != len(want) {
+		t.Fatalf("categories = %v, want %v", categories, want)
+	}
+	for i := range want {
+		if categories[i] != want[i] {
+			t.Fatalf("categories = %v, want %v", categories, want)
+		}
+	}
+	if general := first.Categories[0]; len(general.Results) != 2 || general.Results[0].Domain != "one.com" {
+		t.Errorf("General results = %+v, want one.com and two.com in order", general.Results)
+	}
+}
+
+func TestMarshalJSONLayout(t *testing.T) {
+	results := TestResults{Results: []TestResult{{Server: DNSServer{IP: "192.0.2.1"}, Category: CategoryGeneral, Success: true}}}
+
+	tests := []struct {
+		layout string
+		key    string
+	}{
+		{JSONLayoutFlat, "results"},
+		{JSONLayoutNested, "servers"},
+	}
+	for _, tt := range tests {
+		data, err := marshalJSONLayout(results, tt.layout, SortByIP)
+		if err != nil {
+			t.Fatalf("marshalJSONLayout(%s) error = %v", tt.layout, err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatalf("marshalJSONLayout(%s) output isn't JSON: %v", tt.layout, err)
+		}
+		if _, ok := fields[tt.key]; !ok {
+			t.Errorf("marshalJSONLayout(%s) lacks the %q key", tt.layout, tt.key)
+		}
+	}
+}
+
+func TestNestResultsSortBy(t *testing.T) {
+	ms := time.Millisecond
+	slow := DNSServer{IP: "192.0.2.1"}
+	fast := DNSServer{IP: "192.0.2.2"}
+	flaky := DNSServer{IP: "192.0.2.3"}
+	down := DNSServer{IP: "192.0.2.4"}
+	results := TestResults{Results: []TestResult{
+		{Server: slow, Domain: "a.com", Success: true, ResponseTime: 80 * ms},
+		{Server: slow, Domain: "b.com", Success: true, ResponseTime: 60 * ms},
+		{Server: fast, Domain: "a.com", Success: true, ResponseTime: 10 * ms},
+		{Server: fast, Domain: "b.com", Success: true, ResponseTime: 20 * ms},
+		{Server: flaky, Domain: "a.com", Success: true, ResponseTime: 5 * ms},
+		{Server: flaky, Domain: "b.com", Error: "timeout"},
+		{Server: down, Domain: "a.com", Error: "timeout"},
+		{Server: down, Domain: "b.com", Error: "timeout"},
+	}}
+
+	tests := []struct {
+		sortBy string
+		want   []DNSServer
+	}{
+		{SortByIP, []DNSServer{slow, fast, flaky, down}},
+		{SortByLatency, []DNSServer{flaky, fast, slow, down}},
+		{SortBySuccess, []DNSServer{fast, slow, flaky, down}},
+	}
+	for _, tt := range tests {
+		nested := nestResults(results, tt.sortBy)
+		for i, group := range nested.Servers {
+			if group.Server != tt.want[i] {
+				t.Errorf("--sort-by %s: server %d = %s, want %s", tt.sortBy, i, group.Server.IP, tt.want[i].IP)
+			}
+		}
+	}
+}
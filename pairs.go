@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// SecondarySuffix marks the description of a provider's secondary server; its
+// primary has the same description without the suffix
+const SecondarySuffix = " Secondary"
+
+// A pair's latency diverges when the slower server's average is both
+// PairLatencyRatio times and PairLatencyMinDelta above the faster one's
+const (
+	PairLatencyRatio    = 2.0
+	PairLatencyMinDelta = 20 * time.Millisecond
+)
+
+// ServerPair represents the consistency of a primary/secondary server pair
+type ServerPair struct {
+	Primary          DNSServer     `json:"primary"`
+	Secondary        DNSServer     `json:"secondary"`
+	Domains          int           `json:"domains"`            // Domains tested on both servers
+	Mismatches       []string      `json:"mismatches"`         // Domains with a different outcome (resolved, blocked or failed)
+	DifferentIPs     int           `json:"different_ips"`      // Domains resolved by both to different addresses, normal for CDNs
+	PrimaryAverage   time.Duration `json:"primary_average_ms"` // Average of the successful responses
+	SecondaryAverage time.Duration `json:"secondary_average_ms"`
+	LatencyDiverges  bool          `json:"latency_diverges,omitempty"`
+	Diverges         bool          `json:"diverges"`
+}
+
+// pairServers matches every server whose description ends with
+// SecondarySuffix to the server described without it, in the order the
+// secondaries were tested
+func pairServers(servers []DNSServer) [][2]DNSServer {
+	primaries := make(map[string]DNSServer)
+	for _, server := range servers {
+		if !strings.HasSuffix(server.Description, SecondarySuffix) {
+			if _, seen := primaries[server.Description]; !seen {
+				primaries[server.Description] = server
+			}
+		}
+	}
+
+	var pairs [][2]DNSServer
+	for _, server := range servers {
+		if !strings.HasSuffix(server.Description, SecondarySuffix) {
+			continue
+		}
+		if primary, ok := primaries[strings.TrimSuffix(server.Description, SecondarySuffix)]; ok {
+			pairs = append(pairs, [2]DNSServer{primary, server})
+		}
+	}
+	return pairs
+}
+
+// resultOutcome reduces a result to what a pair is expected to agree on
+func resultOutcome(result TestResult) string {
+	switch {
+	case result.Blocked:
+		return "blocked"
+	case result.Success:
+		return "resolved"
+	case result.Uncached:
+		return "uncached"
+	}
+	return "failed"
+}
+
+// pairConsistency compares the answers and latency of every primary/secondary
+// pair among the tested servers. Domains are compared on their first result
+// on each server, and resolved IPs at prefixBits (see sameNetwork).
+func pairConsistency(results []TestResult, prefixBits int) []ServerPair {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	var pairs []ServerPair
+	for _, servers := range pairServers(servers) {
+		pair := ServerPair{
+			Primary:          servers[0],
+			Secondary:        servers[1],
+			Mismatches:       []string{},
+			PrimaryAverage:   averageResponseTime(byServer[servers[0]]),
+			SecondaryAverage: averageResponseTime(byServer[servers[1]]),
+		}
+
+		primary := make(map[string]TestResult)
+		for _, result := range byServer[servers[0]] {
+			if _, seen := primary[result.Domain]; !seen {
+				primary[result.Domain] = result
+			}
+		}
+		compared := make(map[string]bool)
+		for _, result := range byServer[servers[1]] {
+			other, ok := primary[result.Domain]
+			if !ok || compared[result.Domain] {
+				continue
+			}
+			compared[result.Domain] = true
+			pair.Domains++
+
+			if resultOutcome(result) != resultOutcome(other) {
+				pair.Mismatches = append(pair.Mismatches, result.Domain)
+			} else if result.Success && !sameNetwork(result.IP, other.IP, prefixBits) {
+				pair.DifferentIPs++
+			}
+		}
+
+		fast, slow := pair.PrimaryAverage, pair.SecondaryAverage
+		if fast > slow {
+			fast, slow = slow, fast
+		}
+		pair.LatencyDiverges = fast > 0 && float64(slow) > float64(fast)*PairLatencyRatio && slow-fast > PairLatencyMinDelta
+		pair.Diverges = len(pair.Mismatches) > 0 || pair.LatencyDiverges
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPairServers(t *testing.T) {
+	primary := DNSServer{IP: "1.1.1.1", Description: "Cloudflare"}
+	secondary := DNSServer{IP: "1.0.0.1", Description: "Cloudflare Secondary"}
+	orphan := DNSServer{IP: "8.8.4.4", Description: "Google Secondary"}
+	other := DNSServer{IP: "9.9.9.9", Description: "Quad9"}
+
+	pairs := pairServers([]DNSServer{secondary, other, orphan, primary})
+	if len(pairs) != 1 || pairs[0] != [2]DNSServer{primary, secondary} {
+		t.Errorf("pairServers = %+v, want only the Cloudflare pair", pairs)
+	}
+}
+
+func TestPairConsistency(t *testing.T) {
+	primary := DNSServer{IP: "1.1.1.1", Description: "Cloudflare"}
+	secondary := DNSServer{IP: "1.0.0.1", Description: "Cloudflare Secondary"}
+	ms := time.Millisecond
+	results := []TestResult{
+		// Same answer
+		{Server: primary, Domain: "one.com", Success: true, IP: "192.0.2.1", ResponseTime: 10 * ms},
+		{Server: secondary, Domain: "one.com", Success: true, IP: "192.0.2.1", ResponseTime: 50 * ms},
+		// Both resolve, to different CDN addresses
+		{Server: primary, Domain: "cdn.com", Success: true, IP: "192.0.2.10", ResponseTime: 10 * ms},
+		{Server: secondary, Domain: "cdn.com", Success: true, IP: "192.0.2.20", ResponseTime: 50 * ms},
+		// Only the secondary blocks
+		{Server: primary, Domain: "ads.com", Success: true, IP: "192.0.2.3", ResponseTime: 10 * ms},
+		{Server: secondary, Domain: "ads.com", Success: true, Blocked: true, IP: "0.0.0.0", ResponseTime: 50 * ms},
+		// Only tested on the primary
+		{Server: primary, Domain: "extra.com", Error: "timeout"},
+	}
+
+	pairs := pairConsistency(results, 0)
+	if len(pairs) != 1 {
+		t.Fatalf("pairConsistency = %+v, want one pair", pairs)
+	}
+	pair := pairs[0]
+	if pair.Domains != 3 || len(pair.Mismatches) != 1 || pair.Mismatches[0] != "ads.com" || pair.DifferentIPs != 1 {
+		t.Errorf("pair = %d domains, mismatches %v, %d different IPs, want 3, [ads.com] and 1", pair.Domains, pair.Mismatches, pair.DifferentIPs)
+	}
+	if pair.PrimaryAverage != 10*ms || pair.SecondaryAverage != 50*ms || !pair.LatencyDiverges || !pair.Diverges {
+		t.Errorf("latency %v / %v, diverges %v, want 10ms / 50ms diverging", pair.PrimaryAverage, pair.SecondaryAverage, pair.LatencyDiverges)
+	}
+
+	// A close latency on agreeing servers doesn't diverge
+	consistent := pairConsistency([]TestResult{
+		{Server: primary, Domain: "one.com", Success: true, IP: "192.0.2.1", ResponseTime: 10 * ms},
+		{Server: secondary, Domain: "one.com", Success: true, IP: "192.0.2.1", ResponseTime: 25 * ms},
+	}, 0)
+	if len(consistent) != 1 || consistent[0].Diverges {
+		t.Errorf("pairConsistency = %+v, want a consistent pair", consistent)
+	}
+}
+
+func TestPairConsistencyGranularity(t *testing.T) {
+	primary := DNSServer{IP: "1.1.1.1", Description: "Cloudflare"}
+	secondary := DNSServer{IP: "1.0.0.1", Description: "Cloudflare Secondary"}
+	results := []TestResult{
+		{Server: primary, Domain: "cdn.com", Success: true, IP: "192.0.2.10"},
+		{Server: secondary, Domain: "cdn.com", Success: true, IP: "192.0.2.20"},
+	}
+
+	if pairs := pairConsistency(results, 0); len(pairs) != 1 || pairs[0].DifferentIPs != 1 {
+		t.Errorf("exact pairConsistency = %+v, want 1 different IP", pairs)
+	}
+	if pairs := pairConsistency(results, 24); len(pairs) != 1 || pairs[0].DifferentIPs != 0 {
+		t.Errorf("/24 pairConsistency = %+v, want no different IPs", pairs)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Percentile interpolation methods selectable with --percentile-method
+const (
+	// PercentileLinear interpolates between the two closest ranks, like
+	// numpy's default and Excel's PERCENTILE.INC
+	PercentileLinear = "linear"
+	// PercentileNearestRank returns the smallest value with at least p% of the
+	// values at or below it, without interpolation
+	PercentileNearestRank = "nearest"
+)
+
+// Percentiles holds the response time percentiles of the successful tests
+type Percentiles struct {
+	Method string        `json:"method"`
+	P50    time.Duration `json:"p50_ms"`
+	P90    time.Duration `json:"p90_ms"`
+	P99    time.Duration `json:"p99_ms"`
+}
+
+// percentile returns the p-th percentile (0-100) of sorted using method
+func percentile(sorted []time.Duration, p float64, method string) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	if method == PercentileNearestRank {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	pos := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return sorted[lower] + time.Duration(frac*float64(sorted[upper]-sorted[lower]))
+}
+
+// responsePercentiles computes the percentiles of the successful results'
+// response times, returning nil when there are none
+func responsePercentiles(results []TestResult, method string) *Percentiles {
+	var times []time.Duration
+	for _, result := range results {
+		if result.Success {
+			times = append(times, result.ResponseTime)
+		}
+	}
+	if len(times) == 0 {
+		return nil
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	return &Percentiles{
+		Method: method,
+		P50:    percentile(times, 50, method),
+		P90:    percentile(times, 90, method),
+		P99:    percentile(times, 99, method),
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	ms := time.Millisecond
+	five := []time.Duration{10 * ms, 20 * ms, 30 * ms, 40 * ms, 50 * ms}
+	two := []time.Duration{10 * ms, 20 * ms}
+	one := []time.Duration{7 * ms}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		method string
+		p      float64
+		want   time.Duration
+	}{
+		{"linear five p50", five, PercentileLinear, 50, 30 * ms},
+		{"linear five p95", five, PercentileLinear, 95, 48 * ms},
+		{"linear five p99", five, PercentileLinear, 99, 49600 * time.Microsecond},
+		{"nearest five p50", five, PercentileNearestRank, 50, 30 * ms},
+		{"nearest five p95", five, PercentileNearestRank, 95, 50 * ms},
+		{"nearest five p99", five, PercentileNearestRank, 99, 50 * ms},
+
+		{"linear two p50", two, PercentileLinear, 50, 15 * ms},
+		{"linear two p95", two, PercentileLinear, 95, 19500 * time.Microsecond},
+		{"linear two p99", two, PercentileLinear, 99, 19900 * time.Microsecond},
+		{"nearest two p50", two, PercentileNearestRank, 50, 10 * ms},
+		{"nearest two p95", two, PercentileNearestRank, 95, 20 * ms},
+		{"nearest two p99", two, PercentileNearestRank, 99, 20 * ms},
+
+		{"linear one p50", one, PercentileLinear, 50, 7 * ms},
+		{"linear one p95", one, PercentileLinear, 95, 7 * ms},
+		{"linear one p99", one, PercentileLinear, 99, 7 * ms},
+		{"nearest one p50", one, PercentileNearestRank, 50, 7 * ms},
+		{"nearest one p95", one, PercentileNearestRank, 95, 7 * ms},
+		{"nearest one p99", one, PercentileNearestRank, 99, 7 * ms},
+	}
+	for _, tt := range tests {
+		got := percentile(tt.sorted, tt.p, tt.method)
+		// Interpolation goes through float64, so allow for rounding
+		if diff := got - tt.want; diff < -time.Microsecond || diff > time.Microsecond {
+			t.Errorf("%s: percentile = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResponsePercentiles(t *testing.T) {
+	ms := time.Millisecond
+	results := []TestResult{
+		{Success: true, ResponseTime: 30 * ms},
+		{Success: true, ResponseTime: 10 * ms},
+		{Error: "timeout", ResponseTime: 900 * ms}, // Failures don't count
+		{Success: true, ResponseTime: 20 * ms},
+	}
+
+	p := responsePercentiles(results, PercentileNearestRank)
+	if p == nil || p.Method != PercentileNearestRank || p.P50 != 20*ms || p.P90 != 30*ms || p.P99 != 30*ms {
+		t.Errorf("responsePercentiles = %+v, want p50 20ms and p90/p99 30ms", p)
+	}
+	if p := responsePercentiles(results[2:3], PercentileLinear); p != nil {
+		t.Errorf("responsePercentiles of failures only = %+v, want nil", p)
+	}
+}
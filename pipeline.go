@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// DoTPort is the port DNS-over-TLS resolvers listen on (RFC 7858)
+const DoTPort = "853"
+
+// errPipelineClosed is returned for queries still outstanding when the pool
+// is closed
+var errPipelineClosed = errors.New("connection closed")
+
+// pipelineReply carries the response, or the connection error, of one query
+type pipelineReply struct {
+	msg *dns.Msg
+	err error
+}
+
+// pipelineConn is a persistent TCP or TLS connection to one server carrying
+// any number of outstanding queries. Responses may come back in any order and
+// are matched to their query by message ID.
+type pipelineConn struct {
+	conn    *dns.Conn
+	timeout time.Duration // Write deadline for queries whose context has none
+
+	writeLock chan struct{} // Serializes the length-prefixed writes; waiting for it ends with the query's context
+
+	mu      sync.Mutex
+	pending map[uint16]chan pipelineReply
+	inUse   int   // Queries that got the connection from the pool and haven't finished
+	err     error // Set once the connection failed; it is then redialed
+}
+
+// pipelinePool holds one pipelined connection per server for the TCP and TLS
+// protocols. Connections are dialed on first use and redialed after a
+// failure, e.g. when the server closed an idle connection.
+type pipelinePool struct {
+	opts    TestOptions
+	limit   *connLimit // Open connections allowed under --max-concurrency
+	reclaim func()     // Frees idle connections while waiting for a slot, closeIdle unless set
+
+	mu    sync.Mutex
+	slots map[string]*pipelineSlot
+}
+
+// pipelineSlot holds the connection to one address, so that dialing one
+// server doesn't hold up the queries to the others
+type pipelineSlot struct {
+	mu     sync.Mutex
+	conn   *pipelineConn
+	server string // Server label, for --connection-stats
+	dialed int    // Connections established
+	reused int    // Queries sent over an already established connection
+}
+
+// ConnectionReuse represents how often queries to a server could use the
+// pipelined connection instead of establishing a new one
+type ConnectionReuse struct {
+	Server    string  `json:"server"`
+	Dialed    int     `json:"connections_established"`
+	Reused    int     `json:"connections_reused"`
+	ReuseRate float64 `json:"reuse_rate"` // Percentage of queries sent over an existing connection
+}
+
+func newPipelinePool(opts TestOptions) *pipelinePool {
+	return &pipelinePool{
+		opts:  opts,
+		limit: opts.sockets,
+		slots: make(map[string]*pipelineSlot),
+	}
+}
+
+// newPipelineConn starts reading the responses arriving on conn
+func newPipelineConn(conn *dns.Conn, timeout time.Duration) *pipelineConn {
+	pc := &pipelineConn{
+		conn:      conn,
+		timeout:   timeout,
+		writeLock: make(chan struct{}, 1),
+		pending:   make(map[uint16]chan pipelineReply),
+	}
+	go pc.readLoop()
+	return pc
+}
+
+// dial opens a connection to server at addr, over TLS for ProtocolTLS. The
+// server certificate is verified against the hostname the list named the
+// server by, or else its IP, unless --insecure-skip-verify is set. Under
+// --max-concurrency it first closes an idle connection when the limit is
+// reached.
+func (p *pipelinePool) dial(ctx context.Context, server DNSServer, addr string) (*pipelineConn, error) {
+	reclaim := p.reclaim
+	if reclaim == nil {
+		reclaim = p.closeIdle
+	}
+	if err := p.limit.acquire(ctx, reclaim); err != nil {
+		return nil, err
+	}
+	pc, err := p.connect(ctx, server, addr)
+	if err != nil {
+		p.limit.release()
+	}
+	return pc, err
+}
+
+func (p *pipelinePool) connect(ctx context.Context, server DNSServer, addr string) (*pipelineConn, error) {
+	dialer := &net.Dialer{}
+	if p.opts.SourceIP != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: p.opts.SourceIP}
+	}
+
+	var conn net.Conn
+	var err error
+	if p.opts.Protocol == ProtocolTLS {
+		config := &tls.Config{ServerName: server.Hostname, InsecureSkipVerify: p.opts.InsecureTLS}
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: config}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("TLS handshake failed: %v", err)
+		}
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newPipelineConn(&dns.Conn{Conn: p.limit.wrap(conn)}, p.opts.Timeout), nil
+}
+
+// get returns the live connection to addr, dialing a new one if there is
+// none or the previous one failed. The caller marks it unused with done.
+func (p *pipelinePool) get(ctx context.Context, server DNSServer) (*pipelineConn, error) {
+	addr := server.address(protocolPort(p.opts.Protocol))
+
+	p.mu.Lock()
+	slot, ok := p.slots[addr]
+	if !ok {
+		slot = &pipelineSlot{server: server.label()}
+		p.slots[addr] = slot
+	}
+	p.mu.Unlock()
+
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+
+	if slot.conn != nil && slot.conn.use() {
+		slot.reused++
+		return slot.conn, nil
+	}
+	pc, err := p.dial(ctx, server, addr)
+	if err != nil {
+		return nil, err
+	}
+	pc.inUse = 1
+	slot.conn = pc
+	slot.dialed++
+	return pc, nil
+}
+
+// reuse returns the connection counters of every server, sorted by server
+func (p *pipelinePool) reuse() []ConnectionReuse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var stats []ConnectionReuse
+	for _, slot := range p.slots {
+		slot.mu.Lock()
+		entry := ConnectionReuse{Server: slot.server, Dialed: slot.dialed, Reused: slot.reused}
+		slot.mu.Unlock()
+		if queries := entry.Dialed + entry.Reused; queries > 0 {
+			entry.ReuseRate = float64(entry.Reused) / float64(queries) * 100
+		}
+		stats = append(stats, entry)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Server < stats[j].Server })
+	return stats
+}
+
+// exchange sends msg to server over its pipelined connection and waits for
+// the response with the same ID
+func (p *pipelinePool) exchange(ctx context.Context, msg *dns.Msg, server DNSServer) (*dns.Msg, error) {
+	pc, err := p.get(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	defer pc.done()
+	return pc.exchange(ctx, msg)
+}
+
+// closeIdle closes one connection no query is using, making room for a new
+// one under --max-concurrency. Slots being dialed are skipped.
+func (p *pipelinePool) closeIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, slot := range p.slots {
+		if !slot.mu.TryLock() {
+			continue
+		}
+		pc := slot.conn
+		slot.mu.Unlock()
+		if pc != nil && pc.closeIfIdle() {
+			return
+		}
+	}
+}
+
+// close closes every connection, failing any query still outstanding
+func (p *pipelinePool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, slot := range p.slots {
+		slot.mu.Lock()
+		if slot.conn != nil {
+			slot.conn.fail(errPipelineClosed)
+		}
+		slot.mu.Unlock()
+	}
+	p.slots = make(map[string]*pipelineSlot)
+}
+
+// use marks the connection as used by one more query, unless it failed
+func (pc *pipelineConn) use() bool {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if pc.err != nil {
+		return false
+	}
+	pc.inUse++
+	return true
+}
+
+// done marks the connection as no longer used by a query
+func (pc *pipelineConn) done() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.inUse--
+}
+
+// closeIfIdle closes the connection if no query is using it
+func (pc *pipelineConn) closeIfIdle() bool {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if pc.err != nil || pc.inUse > 0 {
+		return false
+	}
+	pc.failLocked(errPipelineClosed)
+	return true
+}
+
+// fail marks the connection as failed, closes it and hands err to every
+// outstanding query
+func (pc *pipelineConn) fail(err error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.failLocked(err)
+}
+
+func (pc *pipelineConn) failLocked(err error) {
+	if pc.err != nil {
+		return
+	}
+	pc.err = err
+	pc.conn.Close()
+	for id, reply := range pc.pending {
+		reply <- pipelineReply{err: err}
+		delete(pc.pending, id)
+	}
+}
+
+// readLoop delivers every response to the query waiting for its ID.
+// Responses nobody waits for any more, e.g. after a timeout, are dropped.
+func (pc *pipelineConn) readLoop() {
+	for {
+		msg, err := pc.conn.ReadMsg()
+		if err != nil {
+			pc.fail(err)
+			return
+		}
+
+		pc.mu.Lock()
+		// The ID is read before handing msg over, as the query restores its own
+		if reply, ok := pc.pending[msg.Id]; ok {
+			delete(pc.pending, msg.Id)
+			reply <- pipelineReply{msg: msg}
+		}
+		pc.mu.Unlock()
+	}
+}
+
+func (pc *pipelineConn) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	// Outstanding queries on a connection need distinct IDs
+	query := msg.Copy()
+	reply := make(chan pipelineReply, 1)
+
+	pc.mu.Lock()
+	if pc.err != nil {
+		pc.mu.Unlock()
+		return nil, pc.err
+	}
+	for {
+		query.Id = dns.Id()
+		if _, taken := pc.pending[query.Id]; !taken {
+			break
+		}
+	}
+	pc.pending[query.Id] = reply
+	pc.mu.Unlock()
+
+	if err := pc.write(ctx, query); err != nil {
+		pc.mu.Lock()
+		delete(pc.pending, query.Id)
+		pc.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case r := <-reply:
+		if r.err != nil {
+			return nil, r.err
+		}
+		r.msg.Id = msg.Id
+		return r.msg, nil
+	case <-ctx.Done():
+		pc.mu.Lock()
+		delete(pc.pending, query.Id)
+		pc.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// write sends query once the writes queued before it are done. A write that
+// doesn't finish by the query's deadline, or the pipeline timeout, fails the
+// connection, as a partial message leaves the stream unusable.
+func (pc *pipelineConn) write(ctx context.Context, query *dns.Msg) error {
+	select {
+	case pc.writeLock <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-pc.writeLock }()
+
+	deadline, ok := ctx.Deadline()
+	if !ok && pc.timeout > 0 {
+		deadline = time.Now().Add(pc.timeout)
+	}
+	pc.conn.SetWriteDeadline(deadline)
+	if err := pc.conn.WriteMsg(query); err != nil {
+		pc.fail(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestPipelineMatchesResponsesByID(t *testing.T) {
+	client, server := net.Pipe()
+	pc := newPipelineConn(&dns.Conn{Conn: client}, 0)
+	t.Cleanup(func() { pc.fail(errPipelineClosed) })
+
+	// The server reads every query before answering them in reverse order,
+	// each with a TXT record naming the domain it was asked for
+	domains := []string{"a.example.", "b.example.", "c.example."}
+	serverConn := &dns.Conn{Conn: server}
+	go func() {
+		var queries []*dns.Msg
+		for range domains {
+			query, err := serverConn.ReadMsg()
+			if err != nil {
+				return
+			}
+			queries = append(queries, query)
+		}
+		for i := len(queries) - 1; i >= 0; i-- {
+			m := new(dns.Msg)
+			m.SetReply(queries[i])
+			name := queries[i].Question[0].Name
+			m.Answer = append(m.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+				Txt: []string{name},
+			})
+			serverConn.WriteMsg(m)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type outcome struct {
+		domain string
+		msg    *dns.Msg
+		err    error
+		id     uint16
+	}
+	outcomes := make(chan outcome, len(domains))
+	for i, domain := range domains {
+		msg := new(dns.Msg)
+		msg.SetQuestion(domain, dns.TypeTXT)
+		msg.Id = uint16(1000 + i)
+		go func(domain string, msg *dns.Msg) {
+			response, err := pc.exchange(ctx, msg)
+			outcomes <- outcome{domain: domain, msg: response, err: err, id: msg.Id}
+		}(domain, msg)
+	}
+
+	for range domains {
+		o := <-outcomes
+		if o.err != nil {
+			t.Errorf("exchange(%s) error = %v", o.domain, o.err)
+			continue
+		}
+		txt, ok := o.msg.Answer[0].(*dns.TXT)
+		if !ok || txt.Txt[0] != o.domain {
+			t.Errorf("exchange(%s) got the answer %v", o.domain, o.msg.Answer[0])
+		}
+		if o.msg.Id != o.id {
+			t.Errorf("exchange(%s) response ID = %d, want the query's %d", o.domain, o.msg.Id, o.id)
+		}
+	}
+}
+
+func TestPipelineFailsPendingQueries(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	pc := newPipelineConn(&dns.Conn{Conn: client}, 0)
+
+	// Drain the query so the write completes, then drop the connection
+	go func() {
+		(&dns.Conn{Conn: server}).ReadMsg()
+		server.Close()
+	}()
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeA)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := pc.exchange(ctx, msg); err == nil || ctx.Err() != nil {
+		t.Errorf("exchange on a closed connection = %v, want the connection error before the timeout", err)
+	}
+	if pc.use() {
+		t.Error("use() on a failed connection = true, want false")
+	}
+}
+
+func TestPipelineWriteDeadline(t *testing.T) {
+	// The server never reads, so the write blocks
+	client, server := net.Pipe()
+	defer server.Close()
+	pc := newPipelineConn(&dns.Conn{Conn: client}, 0)
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeA)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pc.exchange(ctx, msg)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("exchange with a blocked write succeeded, want an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("exchange with a blocked write didn't return after its deadline")
+	}
+	if pc.use() {
+		t.Error("use() after a failed write = true, want the connection failed")
+	}
+}
+
+func TestPipelineWriteLockWaitEnds(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	pc := newPipelineConn(&dns.Conn{Conn: client}, 0)
+	t.Cleanup(func() { pc.fail(errPipelineClosed) })
+
+	// Another query holds the write lock
+	pc.writeLock <- struct{}{}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeA)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := pc.exchange(ctx, msg); err != context.DeadlineExceeded {
+		t.Errorf("exchange waiting for the write lock error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if len(pc.pending) != 0 || pc.err != nil {
+		t.Errorf("after giving up on the write lock: %d pending, error %v, want none and a usable connection", len(pc.pending), pc.err)
+	}
+}
+
+func TestPipelineTLSServerName(t *testing.T) {
+	// The server records the name the client asked for (SNI)
+	serverNames := make(chan string, 1)
+	config := &tls.Config{
+		Certificates: []tls.Certificate{selfSignedCert(t, "127.0.0.1")},
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverNames <- hello.ServerName
+			return nil, nil
+		},
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Skipf("cannot listen on TCP: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{Listener: listener, Net: "tcp-tls", NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	target := DNSServer{IP: "127.0.0.1", Port: port, Hostname: "dns.example"}
+	pool := newPipelinePool(TestOptions{Protocol: ProtocolTLS, Timeout: 2 * time.Second, InsecureTLS: true})
+	defer pool.close()
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeA)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := pool.exchange(ctx, msg, target); err != nil {
+		t.Fatalf("exchange error = %v", err)
+	}
+	if got := <-serverNames; got != target.Hostname {
+		t.Errorf("TLS server name = %q, want the hostname %q", got, target.Hostname)
+	}
+}
+
+func TestPipelineConnectionReuse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on TCP: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{Listener: listener, NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	target := DNSServer{IP: "127.0.0.1", Port: port}
+	pool := newPipelinePool(TestOptions{Protocol: ProtocolTCP, Timeout: 2 * time.Second})
+	defer pool.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < 4; i++ {
+		msg := new(dns.Msg)
+		msg.SetQuestion("example.com.", dns.TypeA)
+		if _, err := pool.exchange(ctx, msg, target); err != nil {
+			t.Fatalf("exchange %d error = %v", i, err)
+		}
+	}
+
+	want := ConnectionReuse{Server: target.label(), Dialed: 1, Reused: 3, ReuseRate: 75}
+	if got := pool.reuse(); len(got) != 1 || got[0] != want {
+		t.Errorf("reuse() = %+v, want [%+v]", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Server list tokens setting the port of an entry, as an alternative to
+// IP:PORT, or listing ports it is tested on separately
+const (
+	portToken  = "port="
+	portsToken = "ports="
+)
+
+// parsePortList parses the comma-separated ports of a ports= token
+func parsePortList(value string) ([]string, error) {
+	var ports []string
+	seen := make(map[string]bool)
+	for _, port := range strings.Split(value, ",") {
+		port = strings.TrimSpace(port)
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port '%s' in ports=%s", port, value)
+		}
+		if !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+	return ports, nil
+}
+
+// ServerPorts represents which of the ports a multi-port server was tested on
+// answered at least one query
+type ServerPorts struct {
+	IP          string   `json:"ip"`
+	Description string   `json:"description,omitempty"`
+	Answered    []string `json:"answered,omitempty"`
+	Silent      []string `json:"silent,omitempty"`
+}
+
+// portReachability lists, for every address tested on more than one port,
+// the ports that answered and those that didn't, in the order they were
+// listed
+func portReachability(results []TestResult) []ServerPorts {
+	var ips []string
+	ports := make(map[string][]string)
+	descriptions := make(map[string]string)
+	answered := make(map[string]bool)
+	for _, result := range results {
+		server := result.Server
+		if server.Port == "" {
+			continue
+		}
+		key := server.IP + " " + server.Port
+		if _, seen := ports[server.IP]; !seen {
+			ips = append(ips, server.IP)
+			descriptions[server.IP] = server.Description
+		}
+		known := false
+		for _, port := range ports[server.IP] {
+			known = known || port == server.Port
+		}
+		if !known {
+			ports[server.IP] = append(ports[server.IP], server.Port)
+		}
+		answered[key] = answered[key] || result.Success
+	}
+
+	var report []ServerPorts
+	for _, ip := range ips {
+		if len(ports[ip]) < 2 {
+			continue
+		}
+		entry := ServerPorts{IP: ip, Description: descriptions[ip]}
+		for _, port := range ports[ip] {
+			if answered[ip+" "+port] {
+				entry.Answered = append(entry.Answered, port)
+			} else {
+				entry.Silent = append(entry.Silent, port)
+			}
+		}
+		report = append(report, entry)
+	}
+	return report
+}
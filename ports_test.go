@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePortList(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"53", []string{"53"}, false},
+		{"53,5353", []string{"53", "5353"}, false},
+		{"5353, 53", []string{"5353", "53"}, false},
+		{"53,53,853", []string{"53", "853"}, false},
+		{"1,65535", []string{"1", "65535"}, false},
+		{"", nil, true},
+		{"53,", nil, true},
+		{"0", nil, true},
+		{"65536", nil, true},
+		{"53,dns", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parsePortList(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePortList(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parsePortList(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPortReachability(t *testing.T) {
+	a := DNSServer{IP: "1.1.1.1", Description: "Cloudflare"}
+	b := DNSServer{IP: "8.8.8.8"}
+	onPort := func(server DNSServer, port string) DNSServer {
+		server.Port = port
+		return server
+	}
+
+	results := []TestResult{
+		{Server: onPort(a, "53"), Success: false},
+		{Server: onPort(a, "53"), Success: true},
+		{Server: onPort(a, "5353"), Success: false},
+		{Server: onPort(a, "853"), Success: true},
+		{Server: onPort(b, "53"), Success: true},          // A single port isn't reported
+		{Server: DNSServer{IP: "9.9.9.9"}, Success: true}, // Nor a server without one
+	}
+	want := []ServerPorts{
+		{IP: "1.1.1.1", Description: "Cloudflare", Answered: []string{"53", "853"}, Silent: []string{"5353"}},
+	}
+	if got := portReachability(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("portReachability = %+v, want %+v", got, want)
+	}
+
+	if got := portReachability(nil); got != nil {
+		t.Errorf("portReachability(nil) = %+v, want nil", got)
+	}
+}
+
+func TestParsePortsToken(t *testing.T) {
+	list := "192.0.2.1 ports=53,5353 Example\n" +
+		"192.0.2.2:53 ports=5353\n" +
+		"192.0.2.3 ports=53,0\n"
+
+	servers, err := parseDNSServers(strings.NewReader(list), "servers.txt", false)
+	if err != nil {
+		t.Fatalf("parseDNSServers error = %v", err)
+	}
+	want := []DNSServer{
+		{IP: "192.0.2.1", Port: "53", Description: "Example"},
+		{IP: "192.0.2.1", Port: "5353", Description: "Example"},
+	}
+	if !reflect.DeepEqual(servers, want) {
+		t.Errorf("parseDNSServers = %+v, want %+v", servers, want)
+	}
+
+	if _, err := parseDNSServers(strings.NewReader(list), "servers.txt", true); err == nil || !strings.Contains(err.Error(), "servers.txt:2:") {
+		t.Errorf("strict parseDNSServers error = %v, want one for line 2", err)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "github.com/miekg/dns"
+
+// Address preferences selectable with --prefer
+const (
+	PreferIPv4 = "ipv4" // Only A records count as an answer
+	PreferDual = "dual" // AAAA records count when there is no A record
+)
+
+// hasRecord reports whether answers contain a record of type qtype
+func hasRecord(answers []dns.RR, qtype uint16) bool {
+	for _, answer := range answers {
+		if answer.Header().Rrtype == qtype {
+			return true
+		}
+	}
+	return false
+}
+
+// needsAAAAFallback reports whether an A query should be retried as AAAA
+// under --prefer dual: the name exists but has no A record
+func needsAAAAFallback(response *dns.Msg, opts TestOptions) bool {
+	return opts.Prefer == PreferDual && opts.QueryType == dns.TypeA &&
+		response != nil && response.Rcode == dns.RcodeSuccess && !hasRecord(response.Answer, dns.TypeA)
+}
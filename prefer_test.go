@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestPreferDual(t *testing.T) {
+	// v6only.example has only an AAAA record, both.example has both
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		q := r.Question[0]
+		switch {
+		case q.Qtype == dns.TypeAAAA:
+			m.Answer = append(m.Answer, &dns.AAAA{
+				Hdr:  dns.RR_Header{Name: q.Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: 120},
+				AAAA: net.ParseIP("2001:db8::53"),
+			})
+		case q.Name == "both.example.":
+			m = answerA(r, "192.0.2.53")
+		}
+		w.WriteMsg(m)
+	}))
+	server := DNSServer{IP: ip}
+
+	tests := []struct {
+		prefer  string
+		domain  string
+		success bool
+		ip      string
+		family  string
+		ttl     uint32
+	}{
+		{PreferIPv4, "v6only.example", false, "", "", 0},
+		{PreferDual, "v6only.example", true, "2001:db8::53", FamilyIPv6, 120},
+		{PreferDual, "both.example", true, "192.0.2.53", FamilyIPv4, 60},
+	}
+	for _, tt := range tests {
+		opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Prefer: tt.prefer}
+		result := testDNS(newDNSClient(opts), nil, server, tt.domain, opts)
+		var ttl uint32
+		if result.TTL != nil {
+			ttl = *result.TTL
+		}
+		if result.Success != tt.success || result.IP != tt.ip || result.AnswerFamily != tt.family || ttl != tt.ttl {
+			t.Errorf("%s with --prefer %s = success %v, ip %q, family %q, ttl %d, want %v, %q, %q, %d",
+				tt.domain, tt.prefer, result.Success, result.IP, result.AnswerFamily, ttl, tt.success, tt.ip, tt.family, tt.ttl)
+		}
+	}
+}
+
+func TestNeedsAAAAFallback(t *testing.T) {
+	a := &dns.A{Hdr: dns.RR_Header{Rrtype: dns.TypeA}}
+	cname := &dns.CNAME{Hdr: dns.RR_Header{Rrtype: dns.TypeCNAME}}
+	dual := TestOptions{QueryType: dns.TypeA, Prefer: PreferDual}
+
+	tests := []struct {
+		name     string
+		response *dns.Msg
+		opts     TestOptions
+		want     bool
+	}{
+		{"no A record", &dns.Msg{Answer: []dns.RR{cname}}, dual, true},
+		{"A record", &dns.Msg{Answer: []dns.RR{cname, a}}, dual, false},
+		{"NXDOMAIN", &dns.Msg{MsgHdr: dns.MsgHdr{Rcode: dns.RcodeNameError}}, dual, false},
+		{"no response", nil, dual, false},
+		{"ipv4 only", &dns.Msg{}, TestOptions{QueryType: dns.TypeA, Prefer: PreferIPv4}, false},
+		{"TXT query", &dns.Msg{}, TestOptions{QueryType: dns.TypeTXT, Prefer: PreferDual}, false},
+	}
+	for _, tt := range tests {
+		if got := needsAAAAFallback(tt.response, tt.opts); got != tt.want {
+			t.Errorf("%s: needsAAAAFallback = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
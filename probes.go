@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// RecursionProbeDomain is the parent of the random names used to test recursion
+const RecursionProbeDomain = "example.com"
+
+// WildcardProbeCount is the number of random nonexistent names queried to
+// detect wildcard responders
+const WildcardProbeCount = 3
+
+// Domains used to check DNSSEC validation: a signed one, which a validating
+// server answers with the AD bit set, and one with deliberately broken
+// signatures, which it must answer with SERVFAIL
+const (
+	DNSSECSignedDomain = "ietf.org"
+	DNSSECBrokenDomain = "dnssec-failed.org"
+)
+
+// QNAMEMinProbeDomain is the instrumented name of internet.nl whose
+// nameservers answer with a TXT record telling whether the query reached
+// them minimized
+const QNAMEMinProbeDomain = "qnamemintest.internet.nl"
+
+// HighPacketLossThreshold is the loss percentage above which a server is reported
+const HighPacketLossThreshold = 10.0
+
+// ServerProfile represents the behavioral checks run once per DNS server
+type ServerProfile struct {
+	Server            DNSServer `json:"server"`
+	Recursive         *bool     `json:"recursive,omitempty"`
+	RecursionRcode    string    `json:"recursion_rcode,omitempty"`
+	WildcardResponder *bool     `json:"wildcard_responder,omitempty"`
+	CookieSupported   *bool     `json:"cookie_supported,omitempty"`
+	DNSSECValidating  *bool     `json:"dnssec_validating,omitempty"`
+	QNAMEMinimization *bool     `json:"qname_minimization,omitempty"` // Unset when the test domain gave no verdict
+	TTLRaised         *bool     `json:"ttl_raised,omitempty"`         // Returned TTL above the authoritative one
+	ObservedTTL       uint32    `json:"observed_ttl,omitempty"`
+	AuthoritativeTTL  uint32    `json:"authoritative_ttl,omitempty"`
+	PacketLoss        *float64  `json:"packet_loss,omitempty"` // Percentage of loss probes that timed out
+	LossProbes        int       `json:"loss_probes,omitempty"`
+	RateLimitQPS      int       `json:"rate_limit_qps,omitempty"` // First rate at which the server degraded
+	MaxTestedQPS      int       `json:"max_tested_qps,omitempty"`
+	Error             string    `json:"error,omitempty"` // Errors of the failed probes, each after its probe's name, separated by "; "
+}
+
+// addError records the error of one probe without losing those of the
+// probes that failed before it
+func (p *ServerProfile) addError(probe string, err error) {
+	entry := probe + ": " + err.Error()
+	if p.Error != "" {
+		entry = p.Error + "; " + entry
+	}
+	p.Error = entry
+}
+
+// serverProbe runs a single check against a server and records it on the profile
+type serverProbe func(server DNSServer, opts TestOptions, profile *ServerProfile)
+
+// runServerProbes runs the probes against every server on opts.Workers
+// workers. Probe queries are sent with exchangeServer, the way the tests are.
+func runServerProbes(servers []DNSServer, opts TestOptions, probes []serverProbe) []ServerProfile {
+	profiles := make([]ServerProfile, len(servers))
+	jobs := make(chan int, len(servers))
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				profiles[idx].Server = servers[idx]
+				for _, probe := range probes {
+					probe(servers[idx], opts, &profiles[idx])
+				}
+			}
+		}()
+	}
+
+	for i := range servers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return profiles
+}
+
+// randomProbeName returns a name under domain that no resolver can have cached
+func randomProbeName(domain string) string {
+	return fmt.Sprintf("dnscheck-%x.%s", rand.Uint64(), domain)
+}
+
+// probeRecursion queries an uncached name with RD set. A recursive resolver
+// sets RA and comes back with a resolved outcome (an answer or NXDOMAIN);
+// forwarders that don't recurse return REFUSED or an empty referral.
+func probeRecursion(server DNSServer, opts TestOptions, profile *ServerProfile) {
+	client := newDNSClient(opts)
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(randomProbeName(RecursionProbeDomain)), dns.TypeA)
+
+	response, _, err := exchangeServer(client, msg, server, opts)
+	if err != nil {
+		profile.addError("recursion", err)
+		return
+	}
+
+	profile.RecursionRcode = dns.RcodeToString[response.Rcode]
+	resolved := (response.Rcode == dns.RcodeSuccess && len(response.Answer) > 0) ||
+		response.Rcode == dns.RcodeNameError
+	recursive := response.RecursionAvailable && resolved
+	profile.Recursive = &recursive
+}
+
+// probeWildcard queries several random nonexistent subdomains of domain. A
+// server that resolves all of them answers for any name, whether because of a
+// catch-all upstream or hijacking.
+func probeWildcard(domain string) serverProbe {
+	return func(server DNSServer, opts TestOptions, profile *ServerProfile) {
+		client := newDNSClient(opts)
+
+		resolved := 0
+		for i := 0; i < WildcardProbeCount; i++ {
+			msg := new(dns.Msg)
+			msg.SetQuestion(dns.Fqdn(randomProbeName(domain)), dns.TypeA)
+
+			response, _, err := exchangeServer(client, msg, server, opts)
+			if err != nil {
+				profile.addError("wildcard", err)
+				return
+			}
+			if response.Rcode == dns.RcodeSuccess && len(response.Answer) > 0 {
+				resolved++
+			}
+		}
+
+		wildcard := resolved == WildcardProbeCount
+		profile.WildcardResponder = &wildcard
+	}
+}
+
+// probeCookies sends a query carrying an EDNS client cookie (RFC 7873). A
+// server supporting cookies echoes the client cookie followed by its own
+// server cookie.
+func probeCookies(server DNSServer, opts TestOptions, profile *ServerProfile) {
+	client := newDNSClient(opts)
+
+	clientCookie := fmt.Sprintf("%016x", rand.Uint64())
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(RecursionProbeDomain), dns.TypeA)
+	msg.SetEdns0(dns.DefaultMsgSize, false)
+	opt := msg.IsEdns0()
+	opt.Option = append(opt.Option, &dns.EDNS0_COOKIE{Code: dns.EDNS0COOKIE, Cookie: clientCookie})
+
+	response, _, err := exchangeServer(client, msg, server, opts)
+	if err != nil {
+		profile.addError("cookies", err)
+		return
+	}
+
+	supported := false
+	if responseOpt := response.IsEdns0(); responseOpt != nil {
+		for _, option := range responseOpt.Option {
+			if cookie, ok := option.(*dns.EDNS0_COOKIE); ok {
+				// Server cookies are 8 to 32 bytes, i.e. 16 to 64 hex digits
+				supported = strings.HasPrefix(cookie.Cookie, clientCookie) && len(cookie.Cookie) >= len(clientCookie)+16
+			}
+		}
+	}
+	profile.CookieSupported = &supported
+}
+
+// probeDNSSEC checks whether the server validates DNSSEC: it must
+// authenticate a signed domain and refuse to answer for a domain whose
+// signatures are broken
+func probeDNSSEC(server DNSServer, opts TestOptions, profile *ServerProfile) {
+	client := newDNSClient(opts)
+
+	query := func(domain string) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+		msg.SetEdns0(dns.DefaultMsgSize, true)
+		response, _, err := exchangeServer(client, msg, server, opts)
+		return response, err
+	}
+
+	signed, err := query(DNSSECSignedDomain)
+	if err != nil {
+		profile.addError("dnssec", err)
+		return
+	}
+	broken, err := query(DNSSECBrokenDomain)
+	if err != nil {
+		profile.addError("dnssec", err)
+		return
+	}
+
+	validating := signed.AuthenticatedData && broken.Rcode == dns.RcodeServerFailure
+	profile.DNSSECValidating = &validating
+}
+
+// probeQNAMEMinimization queries the TXT record of QNAMEMinProbeDomain. Its
+// nameservers can only tell the full name was asked if the resolver didn't
+// minimize, and answer "HOORAY - ..." or "NO - ..." accordingly.
+func probeQNAMEMinimization(server DNSServer, opts TestOptions, profile *ServerProfile) {
+	client := newDNSClient(opts)
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(QNAMEMinProbeDomain), dns.TypeTXT)
+
+	response, _, err := exchangeServer(client, msg, server, opts)
+	if err != nil {
+		profile.addError("qname minimization", err)
+		return
+	}
+
+	txt, ok := extractTXT(response.Answer)
+	if !ok {
+		return
+	}
+	switch {
+	case strings.HasPrefix(txt, "HOORAY"):
+		minimized := true
+		profile.QNAMEMinimization = &minimized
+	case strings.HasPrefix(txt, "NO"):
+		minimized := false
+		profile.QNAMEMinimization = &minimized
+	}
+}
+
+// probePacketLoss sends count identical queries for domain, one at a time, and
+// records the percentage that timed out. Other errors (e.g. connection refused)
+// are not loss and don't count.
+func probePacketLoss(count int, domain string) serverProbe {
+	return func(server DNSServer, opts TestOptions, profile *ServerProfile) {
+		client := newDNSClient(opts)
+
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+
+		lost := 0
+		for i := 0; i < count; i++ {
+			_, _, err := exchangeServer(client, msg, server, opts)
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				lost++
+			}
+		}
+
+		loss := float64(lost) / float64(count) * 100
+		profile.PacketLoss = &loss
+		profile.LossProbes = count
+	}
+}
+
+// applyServerProfiles attaches the probe results to the test results and
+// updates the summary counters derived from them
+func applyServerProfiles(results *TestResults, profiles []ServerProfile) {
+	results.Servers = profiles
+
+	results.Summary.NonRecursiveServers = 0
+	results.Summary.WildcardResponders = 0
+	results.Summary.HighLossServers = 0
+	results.Summary.CookieServers = 0
+	results.Summary.TTLRaisingServers = 0
+	results.Summary.CookieCheckedServers = 0
+	results.Summary.QNAMEMinServers = 0
+	results.Summary.QNAMEMinChecked = 0
+	results.Summary.RateLimitedServers = 0
+	for _, profile := range profiles {
+		if profile.Recursive != nil && !*profile.Recursive {
+			results.Summary.NonRecursiveServers++
+		}
+		if profile.WildcardResponder != nil && *profile.WildcardResponder {
+			results.Summary.WildcardResponders++
+		}
+		if profile.PacketLoss != nil && *profile.PacketLoss > HighPacketLossThreshold {
+			results.Summary.HighLossServers++
+		}
+		if profile.CookieSupported != nil {
+			results.Summary.CookieCheckedServers++
+			if *profile.CookieSupported {
+				results.Summary.CookieServers++
+			}
+		}
+		if profile.QNAMEMinimization != nil {
+			results.Summary.QNAMEMinChecked++
+			if *profile.QNAMEMinimization {
+				results.Summary.QNAMEMinServers++
+			}
+		}
+		if profile.TTLRaised != nil && *profile.TTLRaised {
+			results.Summary.TTLRaisingServers++
+		}
+		if profile.RateLimitQPS > 0 {
+			results.Summary.RateLimitedServers++
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestRunServerProbes(t *testing.T) {
+	servers := []DNSServer{{IP: "192.0.2.1"}, {IP: "192.0.2.2"}, {IP: "192.0.2.3"}}
+	var calls []string
+	first := func(server DNSServer, opts TestOptions, profile *ServerProfile) {
+		recursive := server.IP != "192.0.2.2"
+		profile.Recursive = &recursive
+	}
+	second := func(server DNSServer, opts TestOptions, profile *ServerProfile) {
+		calls = append(calls, server.IP)
+		if profile.Recursive == nil {
+			t.Errorf("probes on %s ran out of order", server.IP)
+		}
+	}
+
+	// One worker, so the probes run in order
+	profiles := runServerProbes(servers, TestOptions{Timeout: time.Second, Workers: 1}, []serverProbe{first, second})
+	if len(profiles) != len(servers) || len(calls) != len(servers) {
+		t.Fatalf("got %d profiles and %d probe calls, want %d", len(profiles), len(calls), len(servers))
+	}
+	for i, profile := range profiles {
+		if profile.Server != servers[i] {
+			t.Errorf("profile %d is for %+v, want %+v", i, profile.Server, servers[i])
+		}
+	}
+
+	var results TestResults
+	applyServerProfiles(&results, profiles)
+	if results.Summary.NonRecursiveServers != 1 || len(results.Servers) != len(servers) {
+		t.Errorf("NonRecursiveServers = %d with %d profiles, want 1 with %d",
+			results.Summary.NonRecursiveServers, len(results.Servers), len(servers))
+	}
+}
+
+func TestRandomProbeName(t *testing.T) {
+	a, b := randomProbeName("example.com"), randomProbeName("example.com")
+	if a == b {
+		t.Errorf("randomProbeName returned %q twice", a)
+	}
+	if !strings.HasPrefix(a, "dnscheck-") || !strings.HasSuffix(a, ".example.com") {
+		t.Errorf("randomProbeName = %q, want dnscheck-<hex>.example.com", a)
+	}
+}
+
+func TestProbePacketLoss(t *testing.T) {
+	// The server drops every other query
+	var queries int64
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if atomic.AddInt64(&queries, 1)%2 == 0 {
+			return
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+
+	var profile ServerProfile
+	probePacketLoss(4, "example.com")(DNSServer{IP: ip}, TestOptions{Timeout: 200 * time.Millisecond}, &profile)
+	if profile.PacketLoss == nil || *profile.PacketLoss != 50 || profile.LossProbes != 4 {
+		t.Fatalf("loss = %v of %d probes, want 50%% of 4", profile.PacketLoss, profile.LossProbes)
+	}
+
+	results := TestResults{}
+	applyServerProfiles(&results, []ServerProfile{profile})
+	if results.Summary.HighLossServers != 1 {
+		t.Errorf("HighLossServers = %d, want 1", results.Summary.HighLossServers)
+	}
+}
+
+func TestProbeWildcard(t *testing.T) {
+	// One server resolves every name, the other only example.com itself
+	catchAll := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	honest := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if r.Question[0].Name == "example.com." {
+			w.WriteMsg(answerA(r, "192.0.2.53"))
+			return
+		}
+		m := new(dns.Msg)
+		m.SetRcode(r, dns.RcodeNameError)
+		w.WriteMsg(m)
+	}))
+
+	var profiles []ServerProfile
+	for _, ip := range []string{catchAll, honest} {
+		profile := ServerProfile{Server: DNSServer{IP: ip}}
+		probeWildcard("example.com")(profile.Server, TestOptions{Timeout: 2 * time.Second}, &profile)
+		if profile.WildcardResponder == nil || profile.Error != "" {
+			t.Fatalf("probe of %s = %+v, want a verdict", ip, profile)
+		}
+		profiles = append(profiles, profile)
+	}
+	if !*profiles[0].WildcardResponder || *profiles[1].WildcardResponder {
+		t.Errorf("wildcard verdicts = %v, %v, want true for the catch-all server only",
+			*profiles[0].WildcardResponder, *profiles[1].WildcardResponder)
+	}
+
+	var results TestResults
+	applyServerProfiles(&results, profiles)
+	if results.Summary.WildcardResponders != 1 {
+		t.Errorf("WildcardResponders = %d, want 1", results.Summary.WildcardResponders)
+	}
+}
+
+func TestProbeCookies(t *testing.T) {
+	// A cookie-aware server echoes the client cookie with a server cookie
+	// appended; the other one ignores EDNS options
+	aware := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.53")
+		if opt := r.IsEdns0(); opt != nil {
+			for _, option := range opt.Option {
+				if cookie, ok := option.(*dns.EDNS0_COOKIE); ok {
+					m.SetEdns0(dns.DefaultMsgSize, false)
+					m.IsEdns0().Option = append(m.IsEdns0().Option,
+						&dns.EDNS0_COOKIE{Code: dns.EDNS0COOKIE, Cookie: cookie.Cookie + "0123456789abcdef"})
+				}
+			}
+		}
+		w.WriteMsg(m)
+	}))
+	unaware := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+
+	profiles := runServerProbes([]DNSServer{{IP: aware}, {IP: unaware}}, TestOptions{Timeout: 2 * time.Second, Workers: 2}, []serverProbe{probeCookies})
+	for i, want := range []bool{true, false} {
+		if got := profiles[i].CookieSupported; got == nil || *got != want {
+			t.Errorf("server %d cookie support = %v, want %v", i, got, want)
+		}
+	}
+
+	var results TestResults
+	applyServerProfiles(&results, profiles)
+	if results.Summary.CookieServers != 1 || results.Summary.CookieCheckedServers != 2 {
+		t.Errorf("cookie support on %d of %d servers, want 1 of 2", results.Summary.CookieServers, results.Summary.CookieCheckedServers)
+	}
+}
+
+func TestProbeDNSSEC(t *testing.T) {
+	// A validating server authenticates the signed domain and fails the
+	// broken one; the other one answers both without validating
+	validating := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.53")
+		switch r.Question[0].Name {
+		case dns.Fqdn(DNSSECSignedDomain):
+			m.AuthenticatedData = true
+		case dns.Fqdn(DNSSECBrokenDomain):
+			m = new(dns.Msg)
+			m.SetRcode(r, dns.RcodeServerFailure)
+		}
+		w.WriteMsg(m)
+	}))
+	plain := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+
+	profiles := runServerProbes([]DNSServer{{IP: validating}, {IP: plain}}, TestOptions{Timeout: 2 * time.Second, Workers: 2}, []serverProbe{probeDNSSEC})
+	for i, want := range []bool{true, false} {
+		if got := profiles[i].DNSSECValidating; got == nil || *got != want {
+			t.Errorf("server %d DNSSEC validation = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestProbeQNAMEMinimization(t *testing.T) {
+	// Each server relays what the instrumented nameservers would say about
+	// it, or nothing at all
+	var servers []DNSServer
+	for _, verdict := range []string{"HOORAY - QNAME minimisation is enabled on your resolver :)!", "NO - QNAME minimisation is NOT enabled on your resolver :(.", ""} {
+		verdict := verdict
+		addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(r)
+			if verdict != "" {
+				m.Answer = append(m.Answer, &dns.TXT{
+					Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+					Txt: []string{verdict},
+				})
+			}
+			w.WriteMsg(m)
+		}))
+		_, port, _ := net.SplitHostPort(addr)
+		servers = append(servers, DNSServer{IP: "127.0.0.1", Port: port})
+	}
+
+	profiles := runServerProbes(servers, TestOptions{Timeout: 2 * time.Second, Workers: 3}, []serverProbe{probeQNAMEMinimization})
+	if got := profiles[0].QNAMEMinimization; got == nil || !*got {
+		t.Errorf("HOORAY verdict = %v, want true", got)
+	}
+	if got := profiles[1].QNAMEMinimization; got == nil || *got {
+		t.Errorf("NO verdict = %v, want false", got)
+	}
+	if got := profiles[2].QNAMEMinimization; got != nil {
+		t.Errorf("no verdict = %v, want unset", *got)
+	}
+
+	var results TestResults
+	applyServerProfiles(&results, profiles)
+	if results.Summary.QNAMEMinServers != 1 || results.Summary.QNAMEMinChecked != 2 {
+		t.Errorf("summary counts %d of %d servers minimizing, want 1 of 2",
+			results.Summary.QNAMEMinServers, results.Summary.QNAMEMinChecked)
+	}
+}
+
+func TestProbeErrorsAreKept(t *testing.T) {
+	// The server never answers, so every probe fails
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {}))
+	_, port, _ := net.SplitHostPort(addr)
+	servers := []DNSServer{{IP: "127.0.0.1", Port: port}}
+
+	profiles := runServerProbes(servers, TestOptions{Timeout: 100 * time.Millisecond, Workers: 1}, []serverProbe{probeRecursion, probeCookies})
+	parts := strings.Split(profiles[0].Error, "; ")
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "recursion: ") || !strings.HasPrefix(parts[1], "cookies: ") {
+		t.Errorf("profile error = %q, want the recursion and the cookies probe errors", profiles[0].Error)
+	}
+}
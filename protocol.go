@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// Transport protocols recorded on each result
+const (
+	ProtocolUDP   = "udp"
+	ProtocolTCP   = "tcp"   // Pipelined over one connection per server
+	ProtocolTLS   = "tls"   // DNS-over-TLS on port 853, pipelined like TCP
+	ProtocolQUIC  = "quic"  // DNS-over-QUIC on port 853
+	ProtocolHTTPS = "https" // DNS-over-HTTPS on port 443
+)
+
+// protocolPort returns the server port of a transport
+func protocolPort(protocol string) string {
+	switch protocol {
+	case ProtocolTLS:
+		return DoTPort
+	case ProtocolQUIC:
+		return DoQPort
+	case ProtocolHTTPS:
+		return DoHPort
+	}
+	return "53"
+}
+
+// exchangeServer sends one query that isn't a test, such as a probe's, to
+// server the way its tests are sent: over the transport the entry requires or
+// --protocol, at its configured port, from --source-ip. Like an unpooled test
+// query it holds a --max-concurrency slot while in flight; DoH connections
+// take theirs when dialed. The round trip time leaves out the wait for a slot.
+func exchangeServer(client *dns.Client, msg *dns.Msg, server DNSServer, opts TestOptions) (*dns.Msg, time.Duration, error) {
+	if server.Protocol != "" {
+		opts.Protocol = server.Protocol
+	}
+
+	ctx, cancel := context.WithTimeout(opts.context(), opts.Timeout)
+	defer cancel()
+
+	if opts.Protocol != ProtocolHTTPS {
+		if err := opts.sockets.acquire(ctx, opts.reclaimIdle); err != nil {
+			return nil, 0, err
+		}
+		defer opts.sockets.release()
+	}
+
+	start := time.Now()
+	var response *dns.Msg
+	var err error
+	switch opts.Protocol {
+	case ProtocolHTTPS:
+		pool := opts.doh
+		if pool == nil {
+			pool = newDoHPool(opts)
+			defer pool.close()
+		}
+		response, err = pool.exchange(ctx, msg, server)
+	case ProtocolQUIC:
+		response, err = exchangeQUIC(ctx, msg, server, opts)
+	default:
+		response, _, err = client.ExchangeContext(ctx, msg, server.address(protocolPort(opts.Protocol)))
+	}
+	return response, time.Since(start), err
+}
+
+// hasProtocol reports whether any server is listed with the given transport,
+// such as a DoH URL among plain resolvers
+func hasProtocol(servers []DNSServer, protocol string) bool {
+	for _, server := range servers {
+		if server.Protocol == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtocolStats represents the outcome of the tests sent over one transport
+type ProtocolStats struct {
+	CategoryStats
+	AverageResponseTime time.Duration `json:"average_response_time_ms"`
+}
+
+// protocolStats breaks the results down by the transport used to query them
+func protocolStats(results []TestResult) map[string]ProtocolStats {
+	byProtocol := make(map[string][]TestResult)
+	for _, result := range results {
+		byProtocol[result.Protocol] = append(byProtocol[result.Protocol], result)
+	}
+
+	stats := make(map[string]ProtocolStats)
+	for protocol, protocolResults := range byProtocol {
+		stats[protocol] = ProtocolStats{
+			CategoryStats:       groupStats(protocolResults),
+			AverageResponseTime: averageResponseTime(protocolResults),
+		}
+	}
+	return stats
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestProtocolStats(t *testing.T) {
+	ms := time.Millisecond
+	results := []TestResult{
+		{Protocol: ProtocolUDP, Success: true, ResponseTime: 10 * ms},
+		{Protocol: ProtocolUDP, Success: true, ResponseTime: 30 * ms},
+		{Protocol: ProtocolUDP, Error: "timeout", ResponseTime: time.Second},
+		{Protocol: "tcp", Success: true, ResponseTime: 50 * ms},
+	}
+
+	stats := protocolStats(results)
+	if len(stats) != 2 {
+		t.Fatalf("got stats for %d protocols, want 2", len(stats))
+	}
+	udp := stats[ProtocolUDP]
+	if udp.TotalTests != 3 || udp.SuccessfulTests != 2 || udp.AverageResponseTime != 20*ms {
+		t.Errorf("udp stats = %+v, want 2 of 3 averaging 20ms", udp)
+	}
+	if tcp := stats["tcp"]; tcp.TotalTests != 1 || tcp.SuccessRate != 100 || tcp.AverageResponseTime != 50*ms {
+		t.Errorf("tcp stats = %+v, want 1 of 1 at 50ms", tcp)
+	}
+
+	if summary := calculateSummary(results); len(summary.ProtocolStats) != 2 {
+		t.Errorf("summary has stats for %d protocols, want 2", len(summary.ProtocolStats))
+	}
+}
+
+func TestProbeAndQuorumUseServerTransport(t *testing.T) {
+	// A TCP-only server at a non-default port: queries sent to port 53 or
+	// over UDP go unanswered
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on TCP: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{Listener: listener, NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	target := DNSServer{IP: "127.0.0.1", Port: port}
+	opts := TestOptions{Timeout: 2 * time.Second, Workers: 1, Protocol: ProtocolTCP}
+
+	var profile ServerProfile
+	probeRecursion(target, opts, &profile)
+	if profile.Recursive == nil {
+		t.Errorf("recursion probe over TCP = %+v, want a verdict", profile)
+	}
+
+	consensus := resolveQuorum([]DNSServer{target, target, target}, []DomainCategory{{Domain: "example.com"}}, opts)
+	if got := consensus["example.com"].list(); len(got) != 1 || got[0] != "192.0.2.53" {
+		t.Errorf("quorum over TCP at port %s = %v, want 192.0.2.53", port, got)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// parseQueryTypes parses the comma-separated record types of --type, in the
+// order given and without duplicates
+func parseQueryTypes(list string) ([]uint16, error) {
+	var types []uint16
+	seen := make(map[uint16]bool)
+	for _, name := range strings.Split(list, ",") {
+		qtype, err := parseQueryType(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[qtype] {
+			seen[qtype] = true
+			types = append(types, qtype)
+		}
+	}
+	return types, nil
+}
+
+// queryTypes returns the record types every server/domain pair is queried for
+func (o TestOptions) queryTypes() []uint16 {
+	if len(o.QueryTypes) > 0 {
+		return o.QueryTypes
+	}
+	return []uint16{o.QueryType}
+}
+
+// typeStats computes the success rates per record type. It returns nil when
+// the run queried a single type.
+func typeStats(results []TestResult) map[string]CategoryStats {
+	byType := make(map[string][]TestResult)
+	for _, result := range results {
+		byType[result.QueryType] = append(byType[result.QueryType], result)
+	}
+	if len(byType) < 2 {
+		return nil
+	}
+
+	stats := make(map[string]CategoryStats)
+	for qtype, typeResults := range byType {
+		stats[qtype] = groupStats(typeResults)
+	}
+	return stats
+}
+
+// sortedQueryTypes returns the record types of stats in the order of
+// supportedQueryTypes
+func sortedQueryTypes(stats map[string]CategoryStats) []string {
+	var names []string
+	for _, qtype := range supportedQueryTypes {
+		if _, ok := stats[dns.TypeToString[qtype]]; ok {
+			names = append(names, dns.TypeToString[qtype])
+		}
+	}
+	return names
+}
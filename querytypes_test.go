@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestParseQueryTypes(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    []uint16
+		wantErr bool
+	}{
+		{"A", []uint16{dns.TypeA}, false},
+		{"A,AAAA,MX", []uint16{dns.TypeA, dns.TypeAAAA, dns.TypeMX}, false},
+		{"mx, txt", []uint16{dns.TypeMX, dns.TypeTXT}, false},
+		{"A,MX,A", []uint16{dns.TypeA, dns.TypeMX}, false},
+		{"ANY", []uint16{dns.TypeANY}, false},
+		{"", nil, true},
+		{"A,", nil, true},
+		{"A,SRV", nil, true},
+		{"BOGUS", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseQueryTypes(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseQueryTypes(%q) error = %v, want error %v", tt.list, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseQueryTypes(%q) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestTypeStats(t *testing.T) {
+	if stats := typeStats([]TestResult{{QueryType: "A", Success: true}}); stats != nil {
+		t.Errorf("typeStats of a single type = %+v, want nil", stats)
+	}
+
+	results := []TestResult{
+		{QueryType: "MX", Success: true},
+		{QueryType: "MX", Error: "NXDOMAIN"},
+		{QueryType: "A", Success: true},
+		{QueryType: "A", Success: true},
+	}
+	stats := typeStats(results)
+	if stats["A"].SuccessRate != 100 || stats["MX"].SuccessRate != 50 {
+		t.Errorf("typeStats = %+v, want 100%% for A and 50%% for MX", stats)
+	}
+	if got := sortedQueryTypes(stats); !reflect.DeepEqual(got, []string{"A", "MX"}) {
+		t.Errorf("sortedQueryTypes = %v, want [A MX]", got)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// QuorumMinMembers is the smallest --quorum that can outvote a single
+// poisoned member
+const QuorumMinMembers = 3
+
+// QuorumReport represents the consensus of the --quorum resolvers and the
+// tested servers that disagreed with it
+type QuorumReport struct {
+	Members       []string             `json:"members"`
+	Domains       int                  `json:"domains"`                // Domains with a consensus
+	NoConsensus   []string             `json:"no_consensus,omitempty"` // Domains the members didn't agree on, e.g. CDN-steered ones
+	Disagreements []QuorumDisagreement `json:"disagreements,omitempty"`
+}
+
+// QuorumDisagreement represents a tested server answering a domain outside
+// the consensus
+type QuorumDisagreement struct {
+	Server    DNSServer `json:"server"`
+	Domain    string    `json:"domain"`
+	Answer    string    `json:"answer"`
+	Consensus []string  `json:"consensus"` // Agreed addresses, or NXDOMAIN
+}
+
+// quorumConsensus is the agreed answer for one domain: a set of addresses or
+// the name's nonexistence
+type quorumConsensus struct {
+	addresses map[string]bool
+	nxdomain  bool
+}
+
+// agrees reports whether ip is within the consensus, matching the agreed
+// addresses at prefixBits (see sameNetwork)
+func (c quorumConsensus) agrees(ip string, prefixBits int) bool {
+	for address := range c.addresses {
+		if sameNetwork(ip, address, prefixBits) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c quorumConsensus) list() []string {
+	if c.nxdomain {
+		return []string{"NXDOMAIN"}
+	}
+	var addresses []string
+	for address := range c.addresses {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// parseQuorum parses the comma-separated --quorum resolvers
+func parseQuorum(list string) ([]DNSServer, error) {
+	var members []DNSServer
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		ip, port, err := parseServerAddress(entry)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, DNSServer{IP: ip, Port: port})
+	}
+	if len(members) < QuorumMinMembers {
+		return nil, fmt.Errorf("a quorum needs at least %d resolvers, got %d", QuorumMinMembers, len(members))
+	}
+	return members, nil
+}
+
+// quorumAnswer is what one member answered for one domain
+type quorumAnswer struct {
+	addresses []string
+	nxdomain  bool
+	ok        bool // Whether the member answered at all
+}
+
+// resolveQuorum queries every domain on every member and derives the
+// consensus: the addresses returned by a majority of the members, or
+// NXDOMAIN when a majority returned it. Domains without a majority either
+// way are missing from the returned map.
+func resolveQuorum(members []DNSServer, domains []DomainCategory, opts TestOptions) map[string]quorumConsensus {
+	fmt.Fprintf(infoOutput, "Resolving %d domains on %d quorum resolvers...\n", len(domains), len(members))
+
+	client := newDNSClient(opts)
+	answers := make([][]quorumAnswer, len(domains))
+	for i := range answers {
+		answers[i] = make([]quorumAnswer, len(members))
+	}
+
+	type job struct{ domain, member int }
+	jobs := make(chan job, len(domains)*len(members))
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				msg := new(dns.Msg)
+				msg.SetQuestion(dns.Fqdn(domains[j.domain].Domain), dns.TypeA)
+
+				response, _, err := exchangeServer(client, msg, members[j.member], opts)
+				if err != nil {
+					continue
+				}
+
+				answer := quorumAnswer{ok: true, nxdomain: response.Rcode == dns.RcodeNameError}
+				for _, rr := range response.Answer {
+					if a, ok := rr.(*dns.A); ok {
+						answer.addresses = append(answer.addresses, a.A.String())
+					}
+				}
+				answers[j.domain][j.member] = answer
+			}
+		}()
+	}
+	for d := range domains {
+		for m := range members {
+			jobs <- job{d, m}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	majority := len(members)/2 + 1
+	consensus := make(map[string]quorumConsensus)
+	for d, domain := range domains {
+		votes := make(map[string]int)
+		nxVotes := 0
+		for _, answer := range answers[d] {
+			if answer.nxdomain {
+				nxVotes++
+			}
+			for _, address := range answer.addresses {
+				votes[address]++
+			}
+		}
+
+		if nxVotes >= majority {
+			consensus[domain.Domain] = quorumConsensus{nxdomain: true}
+			continue
+		}
+		agreed := make(map[string]bool)
+		for address, count := range votes {
+			if count >= majority {
+				agreed[address] = true
+			}
+		}
+		if len(agreed) > 0 {
+			consensus[domain.Domain] = quorumConsensus{addresses: agreed}
+		}
+	}
+	return consensus
+}
+
+// applyQuorum flags the resolved results that disagree with the consensus: an
+// address outside the agreed set, matched at prefixBits, or any address for a
+// name the quorum says doesn't exist. Failed results, domains without a
+// consensus and the AAAA answers of --prefer dual, which the A consensus can't
+// judge, are skipped.
+func applyQuorum(results *TestResults, members []DNSServer, domains []DomainCategory, consensus map[string]quorumConsensus, prefixBits int) {
+	report := &QuorumReport{Domains: len(consensus)}
+	for _, member := range members {
+		report.Members = append(report.Members, member.label())
+	}
+	for _, domain := range domains {
+		if _, ok := consensus[domain.Domain]; !ok {
+			report.NoConsensus = append(report.NoConsensus, domain.Domain)
+		}
+	}
+
+	for i := range results.Results {
+		result := &results.Results[i]
+		agreed, ok := consensus[result.Domain]
+		if !ok || !result.Success || result.IP == "" || result.AnswerFamily == FamilyIPv6 {
+			continue
+		}
+		if agreed.nxdomain || !agreed.agrees(result.IP, prefixBits) {
+			result.QuorumMismatch = true
+			report.Disagreements = append(report.Disagreements, QuorumDisagreement{
+				Server:    result.Server,
+				Domain:    result.Domain,
+				Answer:    result.IP,
+				Consensus: agreed.list(),
+			})
+		}
+	}
+	results.Summary.Quorum = report
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestParseQuorum(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    int
+		wantErr bool
+	}{
+		{"1.1.1.1,8.8.8.8,9.9.9.9", 3, false},
+		{" 1.1.1.1 , 8.8.8.8:5353 ,, 9.9.9.9 ", 3, false},
+		{"1.1.1.1,8.8.8.8", 0, true},
+		{"1.1.1.1,8.8.8.8,not-an-ip", 0, true},
+	}
+	for _, tt := range tests {
+		members, err := parseQuorum(tt.list)
+		if (err != nil) != tt.wantErr || len(members) != tt.want {
+			t.Errorf("parseQuorum(%q) = %v, %v, want %d members, error %v", tt.list, members, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveQuorum(t *testing.T) {
+	// Two of the three members agree on example.com; split.com gets a
+	// different address from each and missing.com doesn't exist
+	var members []DNSServer
+	for i, address := range []string{"192.0.2.1", "192.0.2.1", "192.0.2.99"} {
+		address, split := address, fmt.Sprintf("198.51.100.%d", i+1)
+		addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(r)
+			name := r.Question[0].Name
+			switch name {
+			case "example.com.":
+				m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60}, A: net.ParseIP(address)})
+			case "split.com.":
+				m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60}, A: net.ParseIP(split)})
+			default:
+				m.Rcode = dns.RcodeNameError
+			}
+			w.WriteMsg(m)
+		}))
+		_, port, _ := net.SplitHostPort(addr)
+		members = append(members, DNSServer{IP: "127.0.0.1", Port: port})
+	}
+
+	domains := []DomainCategory{{Domain: "example.com"}, {Domain: "split.com"}, {Domain: "missing.com"}}
+	consensus := resolveQuorum(members, domains, TestOptions{Timeout: 2 * time.Second, Workers: 2})
+
+	if got := consensus["example.com"].list(); len(got) != 1 || got[0] != "192.0.2.1" {
+		t.Errorf("example.com consensus = %v, want 192.0.2.1", got)
+	}
+	if !consensus["missing.com"].nxdomain {
+		t.Error("missing.com consensus isn't NXDOMAIN")
+	}
+	if _, ok := consensus["split.com"]; ok {
+		t.Errorf("split.com has a consensus %v, want none", consensus["split.com"].list())
+	}
+}
+
+func TestApplyQuorum(t *testing.T) {
+	consensus := map[string]quorumConsensus{
+		"example.com": {addresses: map[string]bool{"93.184.216.34": true}},
+		"missing.com": {nxdomain: true},
+	}
+	tests := []struct {
+		name     string
+		result   TestResult
+		mismatch bool
+	}{
+		{"agreed address", TestResult{Domain: "example.com", Success: true, IP: "93.184.216.34"}, false},
+		{"other address", TestResult{Domain: "example.com", Success: true, IP: "10.0.0.1"}, true},
+		{"address for nxdomain", TestResult{Domain: "missing.com", Success: true, IP: "10.0.0.1"}, true},
+		{"no consensus", TestResult{Domain: "other.com", Success: true, IP: "10.0.0.1"}, false},
+		{"failed", TestResult{Domain: "example.com", Error: "timeout"}, false},
+		{"dual AAAA fallback", TestResult{Domain: "example.com", Success: true, IP: "2606:2800::1", AnswerFamily: FamilyIPv6}, false},
+		{"dual A answer", TestResult{Domain: "example.com", Success: true, IP: "10.0.0.1", AnswerFamily: FamilyIPv4}, true},
+	}
+	for _, tt := range tests {
+		results := &TestResults{Results: []TestResult{tt.result}}
+		applyQuorum(results, nil, nil, consensus, 0)
+		if got := results.Results[0].QuorumMismatch; got != tt.mismatch {
+			t.Errorf("%s: QuorumMismatch = %v, want %v", tt.name, got, tt.mismatch)
+		}
+	}
+}
+
+func TestApplyQuorumGranularity(t *testing.T) {
+	consensus := map[string]quorumConsensus{
+		"cdn.com": {addresses: map[string]bool{"192.0.2.10": true}},
+	}
+	tests := []struct {
+		ip         string
+		prefixBits int
+		mismatch   bool
+	}{
+		{"192.0.2.10", 0, false},
+		{"192.0.2.20", 0, true},
+		{"192.0.2.20", 24, false},
+		{"198.51.100.20", 24, true},
+	}
+	for _, tt := range tests {
+		results := &TestResults{Results: []TestResult{{Domain: "cdn.com", Success: true, IP: tt.ip}}}
+		applyQuorum(results, nil, nil, consensus, tt.prefixBits)
+		if got := results.Results[0].QuorumMismatch; got != tt.mismatch {
+			t.Errorf("%s at /%d: QuorumMismatch = %v, want %v", tt.ip, tt.prefixBits, got, tt.mismatch)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// Rate limit probe settings: the rate starts at RateProbeStartQPS and doubles
+// every step until the server degrades or --rate-limit-max is reached
+const (
+	RateProbeStartQPS      = 5
+	RateProbeStepDuration  = 2 * time.Second
+	RateProbeMinSuccess    = 90.0 // Success rate (%) below which a step counts as degraded
+	RateProbeLatencyFactor = 3    // Median latency growth over the first step that counts as degraded
+	DefaultRateLimitMax    = 50
+)
+
+// rateStep sends qps queries per second for RateProbeStepDuration and returns
+// the success rate and the median latency of the answered queries. Queries
+// in flight take a --max-concurrency slot, so under that limit the rate falls
+// short of qps when answers take longer than the limit allows for.
+func rateStep(server DNSServer, domain string, qps int, opts TestOptions) (float64, time.Duration) {
+	client := newDNSClient(opts)
+
+	total := int(RateProbeStepDuration.Seconds()) * qps
+	ticker := time.NewTicker(time.Second / time.Duration(qps))
+	defer ticker.Stop()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var latencies []time.Duration
+
+	for i := 0; i < total; i++ {
+		<-ticker.C
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			msg := new(dns.Msg)
+			msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+
+			response, rtt, err := exchangeServer(client, msg, server, opts)
+			if err != nil || response.Rcode != dns.RcodeSuccess {
+				return
+			}
+
+			mu.Lock()
+			latencies = append(latencies, rtt)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(latencies) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return float64(len(latencies)) / float64(total) * 100, latencies[len(latencies)/2]
+}
+
+// probeRateLimit raises the query rate to the server step by step and records
+// the first rate at which answers start getting dropped or slow down, which
+// approximates the server's rate limit. The rate never exceeds maxQPS, and
+// the last step runs at exactly maxQPS. A server that answers nothing at the
+// first step is reported as unreachable rather than rate limited.
+func probeRateLimit(maxQPS int, domain string) serverProbe {
+	return func(server DNSServer, opts TestOptions, profile *ServerProfile) {
+		var baseline time.Duration
+
+		for _, qps := range rateSteps(maxQPS) {
+			successRate, median := rateStep(server, domain, qps, opts)
+			profile.MaxTestedQPS = qps
+
+			if baseline == 0 {
+				if successRate == 0 {
+					profile.addError("rate limit", errors.New("unreachable: no answer at the first step"))
+					return
+				}
+				baseline = median
+			}
+
+			if successRate < RateProbeMinSuccess || median > baseline*RateProbeLatencyFactor {
+				profile.RateLimitQPS = qps
+				return
+			}
+		}
+	}
+}
+
+// rateSteps returns the rates probeRateLimit steps through: RateProbeStartQPS
+// doubled every step, ending with a step at exactly maxQPS
+func rateSteps(maxQPS int) []int {
+	var steps []int
+	for qps := RateProbeStartQPS; qps <= maxQPS; qps = min(qps*2, maxQPS) {
+		steps = append(steps, qps)
+		if qps == maxQPS {
+			break
+		}
+	}
+	return steps
+}
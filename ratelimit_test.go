@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestProbeRateLimit(t *testing.T) {
+	healthy := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	// Drops every other query, as a server does once its rate limit kicks in
+	var queries int64
+	limited := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if atomic.AddInt64(&queries, 1)%2 == 0 {
+			return
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	servers := []DNSServer{{IP: healthy}, {IP: limited}}
+
+	// A maximum of RateProbeStartQPS runs a single step per server
+	profiles := runServerProbes(servers, TestOptions{Timeout: 100 * time.Millisecond, Workers: 2}, []serverProbe{probeRateLimit(RateProbeStartQPS, "example.com")})
+	if profiles[0].RateLimitQPS != 0 || profiles[0].MaxTestedQPS != RateProbeStartQPS {
+		t.Errorf("healthy server limited at %d QPS after testing %d, want no limit up to %d",
+			profiles[0].RateLimitQPS, profiles[0].MaxTestedQPS, RateProbeStartQPS)
+	}
+	if profiles[1].RateLimitQPS != RateProbeStartQPS {
+		t.Errorf("dropping server limited at %d QPS, want %d", profiles[1].RateLimitQPS, RateProbeStartQPS)
+	}
+
+	var results TestResults
+	applyServerProfiles(&results, profiles)
+	if results.Summary.RateLimitedServers != 1 {
+		t.Errorf("RateLimitedServers = %d, want 1", results.Summary.RateLimitedServers)
+	}
+}
+
+func TestRateSteps(t *testing.T) {
+	tests := []struct {
+		maxQPS int
+		want   []int
+	}{
+		{4, nil},
+		{5, []int{5}},
+		{7, []int{5, 7}},
+		{40, []int{5, 10, 20, 40}},
+		{45, []int{5, 10, 20, 40, 45}},
+		{50, []int{5, 10, 20, 40, 50}},
+	}
+	for _, tt := range tests {
+		if got := rateSteps(tt.maxQPS); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("rateSteps(%d) = %v, want %v", tt.maxQPS, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// rdataString renders the data of a record the way it is reported in
+// resolved_ip: the address of A and AAAA records, the target name of MX, NS,
+// CNAME and PTR records, the primary nameserver of SOA records and the
+// concatenated strings of TXT records
+func rdataString(rr dns.RR) string {
+	switch rr := rr.(type) {
+	case *dns.A:
+		return rr.A.String()
+	case *dns.AAAA:
+		return rr.AAAA.String()
+	case *dns.MX:
+		return rr.Mx
+	case *dns.NS:
+		return rr.Ns
+	case *dns.CNAME:
+		return rr.Target
+	case *dns.PTR:
+		return rr.Ptr
+	case *dns.SOA:
+		return rr.Ns
+	case *dns.TXT:
+		return strings.Join(rr.Txt, "")
+	}
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// firstRdata returns the rendered data of the first answer of type qtype
+func firstRdata(answers []dns.RR, qtype uint16) (string, bool) {
+	for _, answer := range answers {
+		if answer.Header().Rrtype == qtype {
+			return rdataString(answer), true
+		}
+	}
+	return "", false
+}
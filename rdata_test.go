@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestFirstRdata(t *testing.T) {
+	hdr := func(rrtype uint16) dns.RR_Header {
+		return dns.RR_Header{Name: "example.com.", Rrtype: rrtype, Class: dns.ClassINET, Ttl: 60}
+	}
+	answers := []dns.RR{
+		&dns.CNAME{Hdr: hdr(dns.TypeCNAME), Target: "alias.example.net."},
+		&dns.MX{Hdr: hdr(dns.TypeMX), Preference: 10, Mx: "mail.example.com."},
+		&dns.MX{Hdr: hdr(dns.TypeMX), Preference: 20, Mx: "backup.example.com."},
+		&dns.AAAA{Hdr: hdr(dns.TypeAAAA), AAAA: net.ParseIP("2001:db8::1")},
+		&dns.TXT{Hdr: hdr(dns.TypeTXT), Txt: []string{"v=spf1 ", "-all"}},
+		&dns.SRV{Hdr: hdr(dns.TypeSRV), Priority: 1, Weight: 2, Port: 443, Target: "srv.example.com."},
+	}
+	tests := []struct {
+		qtype  uint16
+		want   string
+		wantOK bool
+	}{
+		{dns.TypeCNAME, "alias.example.net.", true},
+		{dns.TypeMX, "mail.example.com.", true},
+		{dns.TypeAAAA, "2001:db8::1", true},
+		{dns.TypeTXT, "v=spf1 -all", true},
+		{dns.TypeSRV, "1 2 443 srv.example.com.", true},
+		{dns.TypePTR, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := firstRdata(answers, tt.qtype)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("firstRdata(%s) = %q, %v, want %q, %v", dns.TypeToString[tt.qtype], got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestTestDNSMX(t *testing.T) {
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		if r.Question[0].Qtype == dns.TypeMX {
+			m.Answer = append(m.Answer, &dns.MX{
+				Hdr:        dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeMX, Class: dns.ClassINET, Ttl: 60},
+				Preference: 10,
+				Mx:         "mail.example.com.",
+			})
+		}
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeMX, Protocol: ProtocolUDP}
+	result := testDNS(newDNSClient(opts), nil, server, "example.com", opts)
+	if !result.Success || result.IP != "mail.example.com." || result.QueryType != "MX" {
+		t.Errorf("MX query = success %v, resolved %q, type %q, want the MX target", result.Success, result.IP, result.QueryType)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DomainReachability represents whether any server could resolve a domain
+type DomainReachability struct {
+	Domain       string        `json:"domain"`
+	Category     string        `json:"category"`
+	Resolved     bool          `json:"resolved"`
+	ResolvedBy   *DNSServer    `json:"resolved_by,omitempty"`
+	IP           string        `json:"resolved_ip,omitempty"`
+	ResponseTime time.Duration `json:"response_time_ms,omitempty"`
+	Attempts     int           `json:"attempts"`        // Servers queried before stopping
+	Error        string        `json:"error,omitempty"` // Last error when no server resolved it
+}
+
+// ReachabilityReport represents the outcome of a --first-success run
+type ReachabilityReport struct {
+	Timestamp  time.Time            `json:"timestamp"`
+	Domains    []DomainReachability `json:"domains"`
+	Resolved   int                  `json:"resolved"`
+	Unresolved int                  `json:"unresolved"`
+}
+
+// checkReachability queries the servers for each domain in list order and
+// stops at the first successful answer. Domains are checked in parallel.
+func checkReachability(servers []DNSServer, domains []DomainCategory, opts TestOptions) ReachabilityReport {
+	report := ReachabilityReport{
+		Timestamp: time.Now(),
+		Domains:   make([]DomainReachability, len(domains)),
+	}
+
+	limiter := newThrottle(servers, opts)
+	defer limiter.stop()
+	client := newDNSClient(opts)
+	defer openDoHPool(&opts, servers)()
+
+	jobs := make(chan int, len(domains))
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				domain := domains[idx]
+				reach := DomainReachability{
+					Domain:   domain.Domain,
+					Category: domain.Category,
+				}
+
+				for _, server := range servers {
+					limiter.acquire(server)
+					result := testDNS(client, nil, server, domain.Domain, opts)
+					limiter.release(server)
+
+					reach.Attempts++
+					if result.Success {
+						resolvedBy := server
+						reach.Resolved = true
+						reach.ResolvedBy = &resolvedBy
+						reach.IP = result.IP
+						reach.ResponseTime = result.ResponseTime
+						reach.Error = ""
+						break
+					}
+					reach.Error = result.Error
+				}
+
+				report.Domains[idx] = reach
+			}
+		}()
+	}
+
+	for i := range domains {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, reach := range report.Domains {
+		if reach.Resolved {
+			report.Resolved++
+		} else {
+			report.Unresolved++
+		}
+	}
+
+	return report
+}
+
+func outputReachability(report ReachabilityReport, opts OutputOptions) error {
+	var output strings.Builder
+
+	switch opts.Format {
+	case "json":
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		output.Write(jsonData)
+	case "text":
+		writeReachabilityOutput(&output, report, opts)
+	default:
+		return fmt.Errorf("unsupported format: %s", opts.Format)
+	}
+
+	return writeOutput(output.String(), opts)
+}
+
+func writeReachabilityOutput(output *strings.Builder, r ReachabilityReport, opts OutputOptions) {
+	output.WriteString("DNS Reachability Check\n")
+	output.WriteString("======================\n")
+	output.WriteString(fmt.Sprintf("Timestamp: %s\n\n", r.Timestamp.Format("2006-01-02 15:04:05")))
+
+	for _, reach := range r.Domains {
+		if !reach.Resolved {
+			output.WriteString(fmt.Sprintf("  %-22s [%s] no server resolved it (%d tried): %s\n",
+				reach.Domain, colorize(opts.Color, colorRed, "FAIL"), reach.Attempts, reach.Error))
+			continue
+		}
+
+		server := reach.ResolvedBy.IP
+		if reach.ResolvedBy.Description != "" {
+			server += " (" + reach.ResolvedBy.Description + ")"
+		}
+		output.WriteString(fmt.Sprintf("  %-22s [%s] %s via %s, attempt %d\n",
+			reach.Domain, colorize(opts.Color, colorGreen, "  OK"), reach.IP, server, reach.Attempts))
+	}
+
+	output.WriteString(fmt.Sprintf("\nResolved: %d / %d domains\n", r.Resolved, len(r.Domains)))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestCheckReachability(t *testing.T) {
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.53")
+		if r.Question[0].Name != "good.example." {
+			m.Answer = nil
+		}
+		w.WriteMsg(m)
+	}))
+	// Nothing listens on the first server, so every query to it fails
+	servers := []DNSServer{{IP: "127.0.0.253"}, {IP: ip, Description: "Local"}}
+	domains := []DomainCategory{{Domain: "good.example", Category: CategoryGeneral}, {Domain: "bad.example", Category: CategoryGeneral}}
+	opts := TestOptions{Timeout: time.Second, Workers: 2, QueryType: dns.TypeA}
+
+	report := checkReachability(servers, domains, opts)
+	if report.Resolved != 1 || report.Unresolved != 1 || len(report.Domains) != 2 {
+		t.Fatalf("report = %d resolved, %d unresolved, want 1 and 1", report.Resolved, report.Unresolved)
+	}
+	good, bad := report.Domains[0], report.Domains[1]
+	if !good.Resolved || good.ResolvedBy == nil || good.ResolvedBy.IP != ip || good.Attempts != 2 || good.IP != "192.0.2.53" || good.Error != "" {
+		t.Errorf("good.example = %+v, want it resolved by the second server", good)
+	}
+	if bad.Resolved || bad.Attempts != 2 || bad.Error == "" {
+		t.Errorf("bad.example = %+v, want it unresolved after both servers", bad)
+	}
+
+	var output strings.Builder
+	writeReachabilityOutput(&output, report, OutputOptions{})
+	for _, want := range []string{"192.0.2.53 via " + ip + " (Local), attempt 2", "no server resolved it (2 tried)", "Resolved: 1 / 2 domains"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("text output lacks %q:\n%s", want, output.String())
+		}
+	}
+}
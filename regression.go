@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RegressionExitCode is the exit status when a server regressed against
+// --baseline
+const RegressionExitCode = 4
+
+// RegressionReport represents the comparison of this run with a baseline run
+type RegressionReport struct {
+	Baseline  string             `json:"baseline"`
+	Threshold float64            `json:"threshold"` // Latency increase in percent tolerated
+	Servers   []ServerRegression `json:"servers"`
+	Regressed int                `json:"regressed"`
+}
+
+// ServerRegression represents the change of one server since the baseline
+type ServerRegression struct {
+	Server          DNSServer     `json:"server"`
+	BaselineAverage time.Duration `json:"baseline_average_ms"`
+	Average         time.Duration `json:"average_ms"`
+	Change          float64       `json:"change"`            // Latency change in percent, positive when slower
+	Failing         bool          `json:"failing,omitempty"` // Up in the baseline, down now
+	Regressed       bool          `json:"regressed,omitempty"`
+}
+
+// parseRegressionThreshold parses a percentage such as "20%" or "20"
+func parseRegressionThreshold(value string) (float64, error) {
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("expected a non-negative percentage such as 20%%, got '%s'", value)
+	}
+	return threshold, nil
+}
+
+// loadBaseline reads a run written with --format json: a single run, or an
+// --append array of runs, of which the latest is used. Gzip-compressed files
+// ending in .gz are decompressed.
+func loadBaseline(path string) (TestResults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return TestResults{}, err
+	}
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return TestResults{}, err
+		}
+		defer gz.Close()
+		if data, err = io.ReadAll(gz); err != nil {
+			return TestResults{}, err
+		}
+	}
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var runs []TestResults
+		if err := json.Unmarshal(data, &runs); err != nil {
+			return TestResults{}, err
+		}
+		if len(runs) == 0 {
+			return TestResults{}, fmt.Errorf("%s holds no runs", path)
+		}
+		return runs[len(runs)-1], nil
+	}
+
+	var run TestResults
+	if err := json.Unmarshal(data, &run); err != nil {
+		return TestResults{}, err
+	}
+	if len(run.Results) == 0 {
+		return TestResults{}, fmt.Errorf("%s holds no results; it must be --format json output with the flat layout", path)
+	}
+	return run, nil
+}
+
+// compareBaseline compares every server found in both runs. A server
+// regressed when its average latency grew by more than threshold percent,
+// or when it was up in the baseline (at least half of its tests succeeded)
+// and is down now. Servers new in this run have nothing to regress from.
+func compareBaseline(results []TestResult, baseline TestResults, path string, threshold float64) *RegressionReport {
+	baselineResults := make(map[string][]TestResult)
+	for _, result := range baseline.Results {
+		baselineResults[result.Server.label()] = append(baselineResults[result.Server.label()], result)
+	}
+
+	var servers []DNSServer
+	current := make(map[string][]TestResult)
+	for _, result := range results {
+		label := result.Server.label()
+		if _, seen := current[label]; !seen {
+			servers = append(servers, result.Server)
+		}
+		current[label] = append(current[label], result)
+	}
+
+	report := &RegressionReport{Baseline: path, Threshold: threshold}
+	for _, server := range servers {
+		before, ok := baselineResults[server.label()]
+		if !ok {
+			continue
+		}
+		now := current[server.label()]
+
+		entry := ServerRegression{
+			Server:          server,
+			BaselineAverage: averageResponseTime(before),
+			Average:         averageResponseTime(now),
+		}
+		if entry.BaselineAverage > 0 && entry.Average > 0 {
+			entry.Change = float64(entry.Average-entry.BaselineAverage) / float64(entry.BaselineAverage) * 100
+		}
+		entry.Failing = groupStats(before).SuccessRate >= 50 && groupStats(now).SuccessRate < 50
+		entry.Regressed = entry.Failing || entry.Change > threshold
+		if entry.Regressed {
+			report.Regressed++
+		}
+		report.Servers = append(report.Servers, entry)
+	}
+	return report
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseRegressionThreshold(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"20%", 20, false},
+		{" 12.5 ", 12.5, false},
+		{"0%", 0, false},
+		{"-5%", 0, true},
+		{"fast", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseRegressionThreshold(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseRegressionThreshold(%q) = %v, %v, want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadBaseline(t *testing.T) {
+	older := TestResults{RunID: "older", Results: []TestResult{{Domain: "example.com"}}}
+	latest := TestResults{RunID: "latest", Results: []TestResult{{Domain: "example.com"}}}
+	single, _ := json.Marshal(latest)
+	appended, _ := json.Marshal([]TestResults{older, latest})
+	dir := t.TempDir()
+
+	compressed := filepath.Join(dir, "results.json.gz")
+	file, err := os.Create(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(file)
+	gz.Write(appended)
+	gz.Close()
+	file.Close()
+
+	for _, path := range []string{
+		writeTestFile(t, "single.json", string(single)),
+		writeTestFile(t, "appended.json", "\n"+string(appended)+"\n"),
+		compressed,
+	} {
+		run, err := loadBaseline(path)
+		if err != nil || run.RunID != "latest" {
+			t.Errorf("loadBaseline(%s) = run %q, %v, want the latest run", filepath.Base(path), run.RunID, err)
+		}
+	}
+
+	for _, content := range []string{"[]", `{"results": []}`, "not json"} {
+		if _, err := loadBaseline(writeTestFile(t, "bad.json", content)); err == nil {
+			t.Errorf("loadBaseline(%q) succeeded, want an error", content)
+		}
+	}
+}
+
+func TestCompareBaseline(t *testing.T) {
+	ms := time.Millisecond
+	steady := DNSServer{IP: "192.0.2.1"}
+	slower := DNSServer{IP: "192.0.2.2"}
+	down := DNSServer{IP: "192.0.2.3"}
+	added := DNSServer{IP: "192.0.2.4"}
+	baseline := TestResults{Results: []TestResult{
+		{Server: steady, Success: true, ResponseTime: 10 * ms},
+		{Server: slower, Success: true, ResponseTime: 10 * ms},
+		{Server: down, Success: true, ResponseTime: 10 * ms},
+	}}
+	results := []TestResult{
+		{Server: steady, Success: true, ResponseTime: 11 * ms},
+		{Server: slower, Success: true, ResponseTime: 15 * ms},
+		{Server: down, Error: "timeout"},
+		{Server: added, Success: true, ResponseTime: 100 * ms},
+	}
+
+	report := compareBaseline(results, baseline, "baseline.json", 20)
+	if report.Regressed != 2 || len(report.Servers) != 3 {
+		t.Fatalf("report = %+v, want 2 of 3 servers regressed", report)
+	}
+	for i, want := range []ServerRegression{
+		{Server: steady, Change: 10},
+		{Server: slower, Change: 50, Regressed: true},
+		{Server: down, Failing: true, Regressed: true},
+	} {
+		got := report.Servers[i]
+		if got.Server != want.Server || int(got.Change+0.5) != int(want.Change) || got.Failing != want.Failing || got.Regressed != want.Regressed {
+			t.Errorf("server %s = %+v, want %+v", want.Server.IP, got, want)
+		}
+	}
+}
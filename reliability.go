@@ -0,0 +1,89 @@
+package main
+
+// ServerReliability represents a server's success rate in the latest cycle
+// and its exponential moving average across the monitoring cycles
+type ServerReliability struct {
+	Server              DNSServer `json:"server"`
+	SuccessRate         float64   `json:"success_rate"`          // In the latest cycle
+	SmoothedSuccessRate float64   `json:"smoothed_success_rate"` // EMA over all cycles so far
+}
+
+// reliabilityTracker keeps an exponential moving average of each server's
+// success rate across monitoring cycles, so one bad cycle only moves a
+// long-reliable server by alpha of the drop. The first cycle of a server
+// seeds its average.
+type reliabilityTracker struct {
+	alpha   float64
+	servers []DNSServer
+	ema     map[DNSServer]float64
+	latest  map[DNSServer]float64
+}
+
+func newReliabilityTracker(alpha float64) *reliabilityTracker {
+	return &reliabilityTracker{
+		alpha:  alpha,
+		ema:    make(map[DNSServer]float64),
+		latest: make(map[DNSServer]float64),
+	}
+}
+
+// record folds the success rates of one cycle into the averages
+func (t *reliabilityTracker) record(results []TestResult) {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	for _, server := range servers {
+		rate := groupStats(byServer[server]).SuccessRate
+		t.latest[server] = rate
+
+		previous, known := t.ema[server]
+		if !known {
+			t.servers = append(t.servers, server)
+			t.ema[server] = rate
+			continue
+		}
+		t.ema[server] = t.alpha*rate + (1-t.alpha)*previous
+	}
+}
+
+// smoothed returns the averages by server
+func (t *reliabilityTracker) smoothed() map[DNSServer]float64 {
+	rates := make(map[DNSServer]float64, len(t.ema))
+	for server, rate := range t.ema {
+		rates[server] = rate
+	}
+	return rates
+}
+
+// report returns the latest and smoothed rate of every server, in the order
+// the servers were first seen
+func (t *reliabilityTracker) report() []ServerReliability {
+	var report []ServerReliability
+	for _, server := range t.servers {
+		report = append(report, ServerReliability{
+			Server:              server,
+			SuccessRate:         t.latest[server],
+			SmoothedSuccessRate: t.ema[server],
+		})
+	}
+	return report
+}
+
+// smoothedRates returns the smoothed success rate of every server when
+// --ema-alpha is in effect, or nil to rank by the rates of the cycle itself
+func (s Summary) smoothedRates() map[DNSServer]float64 {
+	if len(s.Reliability) == 0 {
+		return nil
+	}
+	rates := make(map[DNSServer]float64, len(s.Reliability))
+	for _, entry := range s.Reliability {
+		rates[entry.Server] = entry.SmoothedSuccessRate
+	}
+	return rates
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReliabilityTracker(t *testing.T) {
+	steady := DNSServer{IP: "1.1.1.1"}
+	flaky := DNSServer{IP: "8.8.8.8"}
+	tracker := newReliabilityTracker(0.5)
+
+	// steady: 100, 100, 0; flaky joins in the second cycle with 50, then 100
+	cycles := [][]TestResult{
+		{{Server: steady, Success: true}},
+		{{Server: steady, Success: true}, {Server: flaky, Success: true}, {Server: flaky}},
+		{{Server: steady}, {Server: flaky, Success: true}},
+	}
+	for _, results := range cycles {
+		tracker.record(results)
+	}
+
+	report := tracker.report()
+	want := []ServerReliability{
+		{Server: steady, SuccessRate: 0, SmoothedSuccessRate: 50},
+		{Server: flaky, SuccessRate: 100, SmoothedSuccessRate: 75},
+	}
+	if len(report) != len(want) {
+		t.Fatalf("report() = %+v, want %+v", report, want)
+	}
+	for i := range want {
+		if report[i].Server != want[i].Server || report[i].SuccessRate != want[i].SuccessRate ||
+			math.Abs(report[i].SmoothedSuccessRate-want[i].SmoothedSuccessRate) > 1e-9 {
+			t.Errorf("report()[%d] = %+v, want %+v", i, report[i], want[i])
+		}
+	}
+
+	smoothed := Summary{Reliability: report}.smoothedRates()
+	if smoothed[steady] != 50 || smoothed[flaky] != 75 {
+		t.Errorf("smoothedRates() = %v, want 50 and 75", smoothed)
+	}
+	if rates := (Summary{}).smoothedRates(); rates != nil {
+		t.Errorf("smoothedRates() without --ema-alpha = %v, want nil", rates)
+	}
+}
+
+func TestSmoothedRanking(t *testing.T) {
+	// a did better in this cycle, b over the whole run
+	a, b := DNSServer{IP: "1.1.1.1"}, DNSServer{IP: "8.8.8.8"}
+	resultsA := []TestResult{{Server: a, Success: true}}
+	resultsB := []TestResult{{Server: b}}
+	smoothed := map[DNSServer]float64{a: 60, b: 90}
+
+	if !serverBefore(resultsA, resultsB, SortBySuccess, nil) {
+		t.Error("without smoothing, a doesn't sort before b")
+	}
+	if serverBefore(resultsA, resultsB, SortBySuccess, smoothed) {
+		t.Error("with smoothing, a sorts before b")
+	}
+
+	tracker := newAlertTracker(80, 1)
+	changed := tracker.record(append(resultsA, resultsB...), smoothed)
+	if len(changed) != 1 || changed[0].Server != a || changed[0].SuccessRate != 60 {
+		t.Errorf("alerts on smoothed rates = %+v, want a firing at 60%%", changed)
+	}
+}
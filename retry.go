@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultRetries is how many times a query failing with a timeout or network
+// error is sent again, so one dropped packet doesn't fail a server
+const DefaultRetries = 1
+
+// RetryBackoff is the wait before the first retry, doubled before each
+// further one up to MaxRetryBackoff
+const RetryBackoff = 100 * time.Millisecond
+
+// MaxRetryBackoff caps the wait between retries, so a large --retries
+// neither stalls a query for minutes nor overflows the doubling
+const MaxRetryBackoff = 5 * time.Second
+
+// retryBackoff returns the wait before retry n, counted from 1
+func retryBackoff(n int) time.Duration {
+	backoff := RetryBackoff
+	for i := 1; i < n && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, MaxRetryBackoff)
+}
+
+// waitBackoff waits d, returning early with false when ctx ends
+func waitBackoff(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// retriedResults counts the results that needed retries, and how many of
+// them succeeded in the end
+func retriedResults(results []TestResult) (retried, recovered int) {
+	for _, result := range results {
+		if result.Retries > 0 {
+			retried++
+			if result.Success {
+				recovered++
+			}
+		}
+	}
+	return retried, recovered
+}
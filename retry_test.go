@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{1, RetryBackoff},
+		{2, 2 * RetryBackoff},
+		{3, 4 * RetryBackoff},
+		{5, 16 * RetryBackoff},
+		// Capped instead of doubling on, or overflowing
+		{8, MaxRetryBackoff},
+		{100, MaxRetryBackoff},
+	}
+	for _, tt := range tests {
+		if got := retryBackoff(tt.n); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRetriedResults(t *testing.T) {
+	results := []TestResult{
+		{Success: true},
+		{Success: true, Retries: 1},
+		{Error: "timeout", Retries: 2},
+		{Error: "NXDOMAIN"},
+	}
+	if retried, recovered := retriedResults(results); retried != 2 || recovered != 1 {
+		t.Errorf("retriedResults = %d, %d, want 2 retried and 1 recovered", retried, recovered)
+	}
+}
+
+func TestRetryRecoversDroppedQuery(t *testing.T) {
+	// The first query is dropped, the retry is answered
+	var queries atomic.Int32
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if queries.Add(1) == 1 {
+			return
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+
+	opts := TestOptions{Timeout: 300 * time.Millisecond, QueryType: dns.TypeA, Protocol: ProtocolUDP, Retries: 1}
+	result := testDNS(newDNSClient(opts), nil, DNSServer{IP: "127.0.0.1", Port: port}, "example.com", opts)
+	if !result.Success || result.Retries != 1 || result.IP != "192.0.2.53" {
+		t.Fatalf("query with a dropped first attempt = %+v, want a success after 1 retry", result)
+	}
+
+	summary := calculateSummary([]TestResult{result})
+	if summary.SuccessfulTests != 1 || summary.FailedTests != 0 {
+		t.Errorf("summary = %d successful, %d failed, want the retried query counted as a success",
+			summary.SuccessfulTests, summary.FailedTests)
+	}
+}
+
+func TestInterruptedBackoffIsSkipped(t *testing.T) {
+	// The server never answers; the run is interrupted during the backoff
+	// after the first attempt
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {}))
+	_, port, _ := net.SplitHostPort(addr)
+
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	opts := TestOptions{Timeout: 200 * time.Millisecond, QueryType: dns.TypeA, Protocol: ProtocolUDP, Retries: 3, runCtx: runCtx}
+	time.AfterFunc(250*time.Millisecond, cancelRun)
+
+	start := time.Now()
+	result := testDNS(newDNSClient(opts), nil, DNSServer{IP: "127.0.0.1", Port: port}, "example.com", opts)
+	if !result.Skipped || result.Success || result.Retries != 0 {
+		t.Errorf("query interrupted during the backoff = %+v, want it skipped without another attempt", result)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("query interrupted during the backoff took %v, want it to stop right away", elapsed)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSamplePairs(t *testing.T) {
+	tests := []struct {
+		total     int
+		percent   float64
+		wantCount int // -1 when the whole matrix is tested
+	}{
+		{100, 0, -1},
+		{100, 100, -1},
+		{0, 50, -1},
+		{100, 25, 25},
+		{10, 33, 3},
+		{10, 1, 1}, // At least one pair
+	}
+	for _, tt := range tests {
+		selected := samplePairs(tt.total, TestOptions{SamplePercent: tt.percent, SampleSeed: 42})
+		if tt.wantCount < 0 {
+			if selected != nil {
+				t.Errorf("samplePairs(%d, %v%%) = %d pairs, want nil", tt.total, tt.percent, len(selected))
+			}
+			continue
+		}
+
+		count := 0
+		for _, ok := range selected {
+			if ok {
+				count++
+			}
+		}
+		if len(selected) != tt.total || count != tt.wantCount {
+			t.Errorf("samplePairs(%d, %v%%) selected %d of %d, want %d", tt.total, tt.percent, count, len(selected), tt.wantCount)
+		}
+	}
+}
+
+func TestSamplePairsSeed(t *testing.T) {
+	opts := TestOptions{SamplePercent: 30, SampleSeed: 7}
+	if a, b := samplePairs(50, opts), samplePairs(50, opts); !reflect.DeepEqual(a, b) {
+		t.Error("samplePairs with the same seed selected different pairs")
+	}
+	opts.SampleSeed = 8
+	if a, b := samplePairs(50, TestOptions{SamplePercent: 30, SampleSeed: 7}), samplePairs(50, opts); reflect.DeepEqual(a, b) {
+		t.Error("samplePairs with different seeds selected the same pairs")
+	}
+}
+
+func TestRunDNSTestsSampled(t *testing.T) {
+	servers := []DNSServer{{IP: "127.0.0.253"}, {IP: "127.0.0.254"}}
+	var domains []DomainCategory
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		domains = append(domains, DomainCategory{Domain: name + ".example"})
+	}
+
+	results := runDNSTests(servers, domains, TestOptions{Timeout: time.Second, Workers: 2, SamplePercent: 50, SampleSeed: 1})
+	summary := results.Summary
+	if len(results.Results) != 5 || !summary.Sampled || summary.MatrixSize != 10 || summary.SampleSeed != 1 {
+		t.Errorf("sampled run = %d results, sampled %v of %d pairs with seed %d, want 5 of 10 with seed 1",
+			len(results.Results), summary.Sampled, summary.MatrixSize, summary.SampleSeed)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// AdaptiveWarmupSamples is the number of samples taken before the per-pair
+// budget of --deadline is used to cut the sample count of slow servers
+const AdaptiveWarmupSamples = 2
+
+// sampleBudget spreads the --deadline time budget over the tested pairs
+type sampleBudget struct {
+	deadline time.Time
+	perPair  time.Duration
+}
+
+// newSampleBudget returns nil when no deadline is set. Pairs run in parallel
+// over the workers, so each pair may use workers/pairs of the total budget.
+func newSampleBudget(opts TestOptions, pairs int) *sampleBudget {
+	if opts.Deadline <= 0 || pairs == 0 {
+		return nil
+	}
+
+	return &sampleBudget{
+		deadline: time.Now().Add(opts.Deadline),
+		perPair:  opts.Deadline * time.Duration(opts.Workers) / time.Duration(pairs),
+	}
+}
+
+// testDNSSamples queries the server/domain pair opts.Samples times. The result
+// carries the answer of the first successful sample, with ResponseTime
+// averaged over all successful samples. It only fails when every sample did.
+// With a budget, the sample count of slow pairs is reduced after the warmup
+// samples and sampling stops once the deadline has passed. With --cold-warm,
+// the first sample is the cold query and the successful later ones are
+// averaged as the warm latency.
+func testDNSSamples(client *dns.Client, counter *queryCounter, server DNSServer, domain string, opts TestOptions, limiter *throttle, budget *sampleBudget) TestResult {
+	requested := opts.Samples
+	if requested < 1 {
+		requested = 1
+	}
+
+	var result TestResult
+	var latencies []time.Duration
+	var totalTime, cold, warmTime time.Duration
+	successes, warmSuccesses := 0, 0
+
+	count := requested
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		if budget != nil && i > 0 {
+			if time.Now().After(budget.deadline) {
+				break
+			}
+			if i == AdaptiveWarmupSamples {
+				perSample := time.Since(start) / time.Duration(i)
+				if perSample > 0 {
+					count = min(count, max(i, int(budget.perPair/perSample)))
+				}
+				if i >= count {
+					break
+				}
+			}
+		}
+
+		limiter.acquire(server)
+		sample := testDNS(client, counter, server, domain, opts)
+		limiter.release(server)
+		if sample.Skipped {
+			if successes == 0 {
+				result = sample
+			}
+			break
+		}
+
+		latencies = append(latencies, sample.ResponseTime)
+		if sample.Success {
+			if successes == 0 {
+				result = sample
+			}
+			successes++
+			totalTime += sample.ResponseTime
+			if i == 0 {
+				cold = sample.ResponseTime
+			} else {
+				warmSuccesses++
+				warmTime += sample.ResponseTime
+			}
+		} else if successes == 0 {
+			result = sample
+		}
+	}
+
+	if successes > 0 {
+		result.ResponseTime = totalTime / time.Duration(successes)
+	}
+	if opts.ColdWarm && cold > 0 && warmSuccesses > 0 {
+		result.ColdResponseTime = cold
+		result.WarmResponseTime = warmTime / time.Duration(warmSuccesses)
+		result.ColdWarmDelta = result.ColdResponseTime - result.WarmResponseTime
+	}
+
+	if requested > 1 {
+		result.SampleCount = len(latencies)
+		result.SampleSuccesses = successes
+		if len(latencies) < requested {
+			result.SamplesRequested = requested
+		}
+		if opts.EmitSamples {
+			result.Samples = latencies
+		}
+	}
+
+	return result
+}
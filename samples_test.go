@@ -0,0 +1,95 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestDNSSamples(t *testing.T) {
+	// Every other query gets no answer
+	var queries int64
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := answerA(r, "192.0.2.53")
+		if atomic.AddInt64(&queries, 1)%2 == 0 {
+			m.Answer = nil
+		}
+		w.WriteMsg(m)
+	}))
+	server := DNSServer{IP: ip}
+	limiter := newThrottle(nil, TestOptions{})
+	defer limiter.stop()
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Samples: 4, EmitSamples: true}
+	result := testDNSSamples(newDNSClient(opts), nil, server, "example.com", opts, limiter, nil)
+	if !result.Success || result.IP != "192.0.2.53" {
+		t.Errorf("result = success %v, ip %q, want the successful samples' answer", result.Success, result.IP)
+	}
+	if result.SampleCount != 4 || result.SampleSuccesses != 2 || len(result.Samples) != 4 {
+		t.Errorf("%d of %d samples succeeded with %d latencies, want 2 of 4 with 4",
+			result.SampleSuccesses, result.SampleCount, len(result.Samples))
+	}
+
+	opts.Samples, opts.EmitSamples = 1, false
+	result = testDNSSamples(newDNSClient(opts), nil, server, "example.com", opts, limiter, nil)
+	if result.SampleCount != 0 || result.Samples != nil {
+		t.Errorf("a single sample recorded count %d and %d latencies, want none", result.SampleCount, len(result.Samples))
+	}
+}
+
+func TestDNSSamplesAllFailing(t *testing.T) {
+	limiter := newThrottle(nil, TestOptions{})
+	defer limiter.stop()
+
+	// Nothing listens here, so every sample fails
+	opts := TestOptions{Timeout: time.Second, QueryType: dns.TypeA, Samples: 3}
+	result := testDNSSamples(newDNSClient(opts), nil, DNSServer{IP: "127.0.0.253"}, "example.com", opts, limiter, nil)
+	if result.Success || result.Error == "" || result.SampleCount != 3 || result.SampleSuccesses != 0 {
+		t.Errorf("result = %+v, want a failure over 3 samples", result)
+	}
+}
+
+func TestNewSampleBudget(t *testing.T) {
+	if newSampleBudget(TestOptions{Workers: 4}, 10) != nil {
+		t.Error("newSampleBudget without --deadline is not nil")
+	}
+	if newSampleBudget(TestOptions{Deadline: time.Minute, Workers: 4}, 0) != nil {
+		t.Error("newSampleBudget without pairs is not nil")
+	}
+	budget := newSampleBudget(TestOptions{Deadline: time.Minute, Workers: 4}, 12)
+	if budget == nil || budget.perPair != 20*time.Second {
+		t.Errorf("newSampleBudget = %+v, want 20s per pair", budget)
+	}
+}
+
+func TestDNSSamplesDeadline(t *testing.T) {
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+	server := DNSServer{IP: ip}
+	limiter := newThrottle(nil, TestOptions{})
+	defer limiter.stop()
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Samples: 10}
+
+	// A budget for about two samples stops right after the warmup
+	budget := &sampleBudget{deadline: time.Now().Add(time.Minute), perPair: 50 * time.Millisecond}
+	result := testDNSSamples(newDNSClient(opts), nil, server, "example.com", opts, limiter, budget)
+	if result.SampleCount != AdaptiveWarmupSamples || result.SamplesRequested != 10 {
+		t.Errorf("took %d of %d samples, want the count cut to the %d warmup samples", result.SampleCount, result.SamplesRequested, AdaptiveWarmupSamples)
+	}
+
+	// A passed deadline stops after the first sample
+	budget = &sampleBudget{deadline: time.Now().Add(-time.Second), perPair: time.Minute}
+	result = testDNSSamples(newDNSClient(opts), nil, server, "example.com", opts, limiter, budget)
+	if result.SampleCount != 1 || !result.Success {
+		t.Errorf("took %d samples past the deadline, want 1 successful", result.SampleCount)
+	}
+
+	summary := calculateSummary([]TestResult{result, {Success: true}})
+	if summary.ReducedSamples != 1 {
+		t.Errorf("ReducedSamples = %d, want 1", summary.ReducedSamples)
+	}
+}
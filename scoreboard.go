@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ScoreboardBarWidth is the number of cells of the success bar of --format scoreboard
+const ScoreboardBarWidth = 10
+
+// formatScoreboard renders one line per server with its success rate, average
+// and p95 latency and a bar of the success rate, best server first. The bar
+// is green from 95%, yellow from 80% and red below when color is enabled.
+func formatScoreboard(results TestResults, color bool) string {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results.Results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+	smoothed := results.Summary.smoothedRates()
+	sort.SliceStable(servers, func(i, j int) bool {
+		return serverBefore(byServer[servers[i]], byServer[servers[j]], SortBySuccess, smoothed)
+	})
+
+	method := PercentileLinear
+	if results.Summary.Percentiles != nil {
+		method = results.Summary.Percentiles.Method
+	}
+
+	width := len("SERVER")
+	for _, server := range servers {
+		width = max(width, len(server.label()))
+	}
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "%-*s  %7s  %9s  %9s  %s\n", width, "SERVER", "SUCCESS", "AVG MS", "P95 MS", "BAR")
+	for _, server := range servers {
+		serverResults := byServer[server]
+		rate := groupStats(serverResults).SuccessRate
+
+		fmt.Fprintf(&output, "%-*s  %6.1f%%  %9s  %9s  %s\n",
+			width, server.label(), rate,
+			formatMilliseconds(averageResponseTime(serverResults)),
+			formatMilliseconds(p95ResponseTime(serverResults, method)),
+			scoreboardBar(rate, color))
+	}
+
+	return output.String()
+}
+
+// scoreboardBar draws a success rate as a bar of ScoreboardBarWidth cells
+func scoreboardBar(rate float64, color bool) string {
+	filled := int(rate/100*ScoreboardBarWidth + 0.5)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", ScoreboardBarWidth-filled)
+
+	switch {
+	case rate >= 95:
+		return colorize(color, colorGreen, bar)
+	case rate >= 80:
+		return colorize(color, colorYellow, bar)
+	default:
+		return colorize(color, colorRed, bar)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoreboardBar(t *testing.T) {
+	tests := []struct {
+		rate  float64
+		color bool
+		want  string
+	}{
+		{100, false, "██████████"},
+		{0, false, "░░░░░░░░░░"},
+		{50, false, "█████░░░░░"},
+		{94, false, "█████████░"},
+		{96, false, "██████████"},
+		{100, true, colorGreen + "██████████" + colorReset},
+		{95, true, colorGreen + "██████████" + colorReset},
+		{80, true, colorYellow + "████████░░" + colorReset},
+		{79.9, true, colorRed + "████████░░" + colorReset},
+		{0, true, colorRed + "░░░░░░░░░░" + colorReset},
+	}
+	for _, tt := range tests {
+		if got := scoreboardBar(tt.rate, tt.color); got != tt.want {
+			t.Errorf("scoreboardBar(%v, %v) = %q, want %q", tt.rate, tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestFormatScoreboard(t *testing.T) {
+	ms := time.Millisecond
+	good := DNSServer{IP: "192.0.2.1", Description: "Good"}
+	bad := DNSServer{IP: "192.0.2.22"}
+	results := TestResults{Results: []TestResult{
+		{Server: bad, Success: true, ResponseTime: 30 * ms},
+		{Server: bad, Error: "timeout"},
+		{Server: good, Success: true, ResponseTime: 10 * ms},
+		{Server: good, Success: true, ResponseTime: 20 * ms},
+	}}
+
+	want := "SERVER      SUCCESS     AVG MS     P95 MS  BAR\n" +
+		"192.0.2.1    100.0%     15.000     19.500  ██████████\n" +
+		"192.0.2.22    50.0%     30.000     30.000  █████░░░░░\n"
+	if got := formatScoreboard(results, false); got != want {
+		t.Errorf("formatScoreboard =\n%s\nwant\n%s", got, want)
+	}
+}
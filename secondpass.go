@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// retryFailed re-tests the failed pairs once after the main run and replaces
+// the results that succeed on retry. It returns the number of pairs retried
+// and recovered. Uncached results are not failures and are left alone.
+func retryFailed(results []TestResult, opts TestOptions, client *dns.Client, counter *queryCounter, limiter *throttle) (int, int) {
+	var failed []int
+	for i, result := range results {
+		if !result.Success && !result.Uncached {
+			failed = append(failed, i)
+		}
+	}
+	if len(failed) == 0 {
+		return 0, 0
+	}
+
+	fmt.Fprintf(infoOutput, "Second pass: retrying %d failed pairs...\n", len(failed))
+
+	jobs := make(chan int, len(failed))
+	var mu sync.Mutex
+	recovered := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				original := results[idx]
+				target := original.Server
+				if original.Family != "" {
+					target = familyTarget(original.Server, original.Family)
+				}
+
+				retryOpts := opts
+				if qtype, ok := dns.StringToType[original.QueryType]; ok {
+					retryOpts.QueryType = qtype
+				}
+				retry := testDNSSamples(client, counter, target, original.Domain, retryOpts, limiter, nil)
+				if !retry.Success {
+					continue
+				}
+
+				retry.Server = original.Server
+				retry.Family = original.Family
+				retry.Category = original.Category
+				retry.Critical = original.Critical
+				retry.RecoveredOnRetry = true
+				retry.BogusAnswer = isBogusAnswer(retry)
+
+				mu.Lock()
+				results[idx] = retry
+				recovered++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, idx := range failed {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	return len(failed), recovered
+}
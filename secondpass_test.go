@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestRunDNSTestsSecondPass(t *testing.T) {
+	// The first query for each name gets no answer, later ones succeed;
+	// never.example never resolves
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		name := r.Question[0].Name
+		mu.Lock()
+		first := !seen[name]
+		seen[name] = true
+		mu.Unlock()
+
+		m := answerA(r, "192.0.2.53")
+		if first || name == "never.example." {
+			m.Answer = nil
+		}
+		w.WriteMsg(m)
+	}))
+	servers := []DNSServer{{IP: ip}}
+	domains := []DomainCategory{
+		{Domain: "a.example", Category: CategoryGeneral},
+		{Domain: "b.example", Category: CategoryGeneral},
+		{Domain: "never.example", Category: CategoryGeneral},
+	}
+
+	results := runDNSTests(servers, domains, TestOptions{Timeout: 2 * time.Second, Workers: 2, QueryType: dns.TypeA, SecondPass: true})
+	if results.Summary.SecondPassRetries != 3 || results.Summary.RecoveredFailures != 2 {
+		t.Errorf("second pass recovered %d of %d failures, want 2 of 3",
+			results.Summary.RecoveredFailures, results.Summary.SecondPassRetries)
+	}
+	if results.Summary.SuccessfulTests != 2 || results.Summary.TotalQueries != 6 {
+		t.Errorf("%d successes from %d queries, want 2 from 6", results.Summary.SuccessfulTests, results.Summary.TotalQueries)
+	}
+	for _, result := range results.Results {
+		recovered := result.Domain != "never.example"
+		if result.Success != recovered || result.RecoveredOnRetry != recovered || result.Category != CategoryGeneral {
+			t.Errorf("%s = success %v, recovered %v, category %q, want %v and the original category",
+				result.Domain, result.Success, result.RecoveredOnRetry, result.Category, recovered)
+		}
+	}
+}
+
+func TestRetryFailedSkipsUncached(t *testing.T) {
+	results := []TestResult{
+		{Server: DNSServer{IP: "127.0.0.253"}, Domain: "a.example", Uncached: true},
+		{Server: DNSServer{IP: "127.0.0.253"}, Domain: "b.example", Success: true},
+	}
+	opts := TestOptions{Timeout: time.Second, Workers: 1, QueryType: dns.TypeA}
+	limiter := newThrottle(nil, opts)
+	defer limiter.stop()
+
+	retried, recovered := retryFailed(results, opts, newDNSClient(opts), nil, limiter)
+	if retried != 0 || recovered != 0 {
+		t.Errorf("retryFailed = %d retried, %d recovered, want nothing retried", retried, recovered)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// ResponseSections summarizes the authority and additional sections of a
+// response, recorded with --include-sections
+type ResponseSections struct {
+	Authority  []string `json:"authority,omitempty"`
+	Additional []string `json:"additional,omitempty"`
+}
+
+// summarizeSections returns the authority and additional records of
+// response in a compact form, or nil when both are empty. The OPT
+// pseudo-record of EDNS is left out.
+func summarizeSections(response *dns.Msg) *ResponseSections {
+	sections := &ResponseSections{}
+	for _, rr := range response.Ns {
+		sections.Authority = append(sections.Authority, summarizeRecord(rr))
+	}
+	for _, rr := range response.Extra {
+		if rr.Header().Rrtype == dns.TypeOPT {
+			continue
+		}
+		sections.Additional = append(sections.Additional, summarizeRecord(rr))
+	}
+	if len(sections.Authority) == 0 && len(sections.Additional) == 0 {
+		return nil
+	}
+	return sections
+}
+
+// summarizeRecord renders rr as "NAME TYPE DATA", with SOA records reduced
+// to their primary server and serial
+func summarizeRecord(rr dns.RR) string {
+	header := rr.Header()
+	name := strings.TrimSuffix(header.Name, ".")
+	switch record := rr.(type) {
+	case *dns.NS:
+		return fmt.Sprintf("%s NS %s", name, strings.TrimSuffix(record.Ns, "."))
+	case *dns.SOA:
+		return fmt.Sprintf("%s SOA %s serial %d", name, strings.TrimSuffix(record.Ns, "."), record.Serial)
+	case *dns.A:
+		return fmt.Sprintf("%s A %s", name, record.A)
+	case *dns.AAAA:
+		return fmt.Sprintf("%s AAAA %s", name, record.AAAA)
+	}
+	// Anything else keeps its presentation format, minus the TTL and class
+	data := strings.TrimPrefix(rr.String(), header.String())
+	return fmt.Sprintf("%s %s %s", name, dns.TypeToString[header.Rrtype], data)
+}
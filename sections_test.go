@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestSummarizeSections(t *testing.T) {
+	// A referral: the delegation, its glue and the EDNS record
+	referral := new(dns.Msg)
+	referral.Ns = []dns.RR{
+		&dns.NS{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: 172800}, Ns: "ns1.example.com."},
+	}
+	referral.Extra = []dns.RR{
+		&dns.A{Hdr: dns.RR_Header{Name: "ns1.example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 172800}, A: net.ParseIP("192.0.2.1")},
+		&dns.AAAA{Hdr: dns.RR_Header{Name: "ns1.example.com.", Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: 172800}, AAAA: net.ParseIP("2001:db8::1")},
+		&dns.TXT{Hdr: dns.RR_Header{Name: "info.example.com.", Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60}, Txt: []string{"hello"}},
+	}
+	referral.SetEdns0(1232, false)
+
+	want := &ResponseSections{
+		Authority:  []string{"example.com NS ns1.example.com"},
+		Additional: []string{"ns1.example.com A 192.0.2.1", "ns1.example.com AAAA 2001:db8::1", `info.example.com TXT "hello"`},
+	}
+	if got := summarizeSections(referral); !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeSections(referral) = %+v, want %+v", got, want)
+	}
+
+	negative := new(dns.Msg)
+	negative.Ns = []dns.RR{&dns.SOA{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 300}, Ns: "ns1.example.com.", Serial: 2024010101}}
+	if got := summarizeSections(negative); got == nil || !reflect.DeepEqual(got.Authority, []string{"example.com SOA ns1.example.com serial 2024010101"}) {
+		t.Errorf("summarizeSections(negative) = %+v, want the SOA", got)
+	}
+
+	onlyEDNS := new(dns.Msg)
+	onlyEDNS.SetEdns0(1232, false)
+	if got := summarizeSections(onlyEDNS); got != nil {
+		t.Errorf("summarizeSections(EDNS only) = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// serverCSVHeader lists the columns of --format server-csv
+var serverCSVHeader = []string{
+	"ip", "ipv6", "description", "country", "total_tests",
+	"success_rate", "avg_latency_ms", "p95_latency_ms", "block_rate",
+}
+
+// formatServerCSV renders one row of aggregate stats per server, ordered as
+// the text output. The country comes from --geoip and the block rate is the
+// percentage of tests answered with NXDOMAIN or a sinkhole address.
+func formatServerCSV(results TestResults, sortBy string) ([]byte, error) {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results.Results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+	smoothed := results.Summary.smoothedRates()
+	sort.SliceStable(servers, func(i, j int) bool {
+		return serverBefore(byServer[servers[i]], byServer[servers[j]], sortBy, smoothed)
+	})
+
+	method := PercentileLinear
+	if results.Summary.Percentiles != nil {
+		method = results.Summary.Percentiles.Method
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(serverCSVHeader)
+
+	for _, server := range servers {
+		serverResults := byServer[server]
+		stats := groupStats(serverResults)
+
+		var country string
+		var blocked int
+		for _, result := range serverResults {
+			if result.ServerCountry != "" {
+				country = result.ServerCountry
+			}
+			if result.Blocked {
+				blocked++
+			}
+		}
+
+		writer.Write([]string{
+			server.IP,
+			server.IPv6,
+			server.Description,
+			country,
+			strconv.Itoa(stats.TotalTests),
+			strconv.FormatFloat(stats.SuccessRate, 'f', 2, 64),
+			formatMilliseconds(averageResponseTime(serverResults)),
+			formatMilliseconds(p95ResponseTime(serverResults, method)),
+			strconv.FormatFloat(float64(blocked)/float64(len(serverResults))*100, 'f', 2, 64),
+		})
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// p95ResponseTime returns the 95th percentile response time of the
+// successful results, or zero when none succeeded
+func p95ResponseTime(results []TestResult, method string) time.Duration {
+	var times []time.Duration
+	for _, result := range results {
+		if result.Success {
+			times = append(times, result.ResponseTime)
+		}
+	}
+	if len(times) == 0 {
+		return 0
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return percentile(times, 95, method)
+}
+
+// formatMilliseconds renders a duration as fractional milliseconds
+func formatMilliseconds(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatServerCSV(t *testing.T) {
+	a := DNSServer{IP: "8.8.8.8", Description: "Google, Inc."}
+	b := DNSServer{IP: "1.1.1.1", IPv6: "2606:4700:4700::1111"}
+	ms := time.Millisecond
+	results := TestResults{Results: []TestResult{
+		{Server: b, Success: true, ResponseTime: 5 * ms, Blocked: true},
+		{Server: b, Error: "timeout"},
+		{Server: a, Success: true, ResponseTime: 10 * ms, ServerCountry: "US"},
+		{Server: a, Success: true, ResponseTime: 30 * ms, ServerCountry: "US"},
+	}}
+
+	// Rows follow --sort-by, here the most reliable server first
+	data, err := formatServerCSV(results, SortBySuccess)
+	if err != nil {
+		t.Fatalf("formatServerCSV error = %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("server-csv output is not valid CSV: %v", err)
+	}
+
+	want := [][]string{
+		serverCSVHeader,
+		{"8.8.8.8", "", "Google, Inc.", "US", "2", "100.00", "20.000", "29.000", "0.00"},
+		{"1.1.1.1", "2606:4700:4700::1111", "", "", "2", "50.00", "5.000", "5.000", "50.00"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("server-csv has %d rows, want %d:\n%s", len(records), len(want), data)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadDNSServersStrict(t *testing.T) {
+	path := writeTestFile(t, "servers.txt", "# comment\n1.1.1.1 Cloudflare DNS\n\nnot_an_ip Broken\n2001:4860:4860::8888\n")
+
+	servers, err := loadDNSServersFromFile(path, false)
+	if err != nil {
+		t.Fatalf("loadDNSServersFromFile error = %v", err)
+	}
+	want := []DNSServer{{IP: "1.1.1.1", Description: "Cloudflare DNS"}, {IP: "2001:4860:4860::8888"}}
+	if len(servers) != len(want) || servers[0] != want[0] || servers[1] != want[1] {
+		t.Errorf("loadDNSServersFromFile = %+v, want %+v", servers, want)
+	}
+
+	_, err = loadDNSServersFromFile(path, true)
+	if err == nil || !strings.Contains(err.Error(), "servers.txt:4:") {
+		t.Errorf("strict loadDNSServersFromFile error = %v, want one on line 4", err)
+	}
+}
+
+func TestLoadExcludeList(t *testing.T) {
+	path := writeTestFile(t, "exclude.txt", "# denylist\n1.1.1.1 Cloudflare\n\n  8.8.8.8\n")
+	ips, err := loadExcludeList(path)
+	if err != nil || strings.Join(ips, ",") != "1.1.1.1,8.8.8.8" {
+		t.Errorf("loadExcludeList = %v, %v, want [1.1.1.1 8.8.8.8]", ips, err)
+	}
+}
+
+func TestExcludeServers(t *testing.T) {
+	servers := []DNSServer{
+		{IP: "1.1.1.1"},
+		{IP: "8.8.8.8", IPv6: "2001:4860:4860::8888"},
+		{IP: "127.0.0.1", Port: "5335"},
+		{IP: "127.0.0.1"},
+	}
+	tests := []struct {
+		excluded []string
+		want     []string // Labels of the kept servers
+	}{
+		{nil, []string{"1.1.1.1", "8.8.8.8", "127.0.0.1:5335", "127.0.0.1"}},
+		{[]string{"1.1.1.1"}, []string{"8.8.8.8", "127.0.0.1:5335", "127.0.0.1"}},
+		{[]string{"2001:4860:4860::8888"}, []string{"1.1.1.1", "127.0.0.1:5335", "127.0.0.1"}},
+		{[]string{"127.0.0.1:5335"}, []string{"1.1.1.1", "8.8.8.8", "127.0.0.1"}},
+		{[]string{"127.0.0.1"}, []string{"1.1.1.1", "8.8.8.8"}},
+	}
+	for _, tt := range tests {
+		excluded := make(map[string]bool)
+		for _, entry := range tt.excluded {
+			excluded[entry] = true
+		}
+		kept, removed := excludeServers(servers, excluded)
+		var got []string
+		for _, server := range kept {
+			got = append(got, server.label())
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") || removed != len(servers)-len(tt.want) {
+			t.Errorf("excludeServers(%v) = %v, %d removed, want %v", tt.excluded, got, removed, tt.want)
+		}
+	}
+}
+
+func TestMergeDuplicateServers(t *testing.T) {
+	servers := []DNSServer{
+		{IP: "1.1.1.1", Description: "Cloudflare"},
+		{IP: "8.8.8.8"},
+		{IP: "1.1.1.1", Description: "Cloudflare"},
+		{IP: "1.1.1.1", IPv6: "2606:4700:4700::1111", Description: "Cloudflare DNS"},
+		{IP: "8.8.8.8", Description: "Google"},
+		{IP: "1.1.1.1", Port: "5335", Description: "Local forwarder"}, // Another port is another server
+	}
+
+	merged, count := mergeDuplicateServers(servers)
+	want := []DNSServer{
+		{IP: "1.1.1.1", IPv6: "2606:4700:4700::1111", Description: "Cloudflare / Cloudflare DNS"},
+		{IP: "8.8.8.8", Description: "Google"},
+		{IP: "1.1.1.1", Port: "5335", Description: "Local forwarder"},
+	}
+	if count != 3 || len(merged) != len(want) {
+		t.Fatalf("mergeDuplicateServers = %+v, %d merged, want %+v and 3", merged, count, want)
+	}
+	for i := range want {
+		if merged[i] != want[i] {
+			t.Errorf("server %d = %+v, want %+v", i, merged[i], want[i])
+		}
+	}
+}
+
+func TestParseDNSServersPorts(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantIP   string
+		wantPort string
+		wantDesc string
+		wantErr  bool
+	}{
+		{"127.0.0.1 port=5335 Local", "127.0.0.1", "5335", "Local", false},
+		{"::1 port=5335", "::1", "5335", "", false},
+		{"127.0.0.1:5335 Local", "127.0.0.1", "5335", "Local", false},
+		{"[::1]:5335", "::1", "5335", "", false},
+		// A numeric first description word is not a port
+		{"1.1.1.1 1 Cloudflare", "1.1.1.1", "", "1 Cloudflare", false},
+		{"9.9.9.9 9 Quad9", "9.9.9.9", "", "9 Quad9", false},
+		{"127.0.0.1:5335 port=53", "", "", "", true},
+		{"127.0.0.1 port=5335 ports=53,5353", "", "", "", true},
+		{"127.0.0.1 port=0", "", "", "", true},
+		{"127.0.0.1 port=x", "", "", "", true},
+	}
+	for _, tt := range tests {
+		servers, err := parseDNSServers(strings.NewReader(tt.line), "test", true)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDNSServers(%q) error = %v, want error %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if len(servers) != 1 {
+			t.Errorf("parseDNSServers(%q) returned %d servers, want 1", tt.line, len(servers))
+			continue
+		}
+		got := servers[0]
+		if got.IP != tt.wantIP || got.Port != tt.wantPort || got.Description != tt.wantDesc {
+			t.Errorf("parseDNSServers(%q) = IP %q, port %q, description %q; want %q, %q, %q",
+				tt.line, got.IP, got.Port, got.Description, tt.wantIP, tt.wantPort, tt.wantDesc)
+		}
+	}
+}
+
+func TestParseDNSServersUnresolvableHostname(t *testing.T) {
+	list := "dns.nonexistent.invalid Broken\n"
+
+	if _, err := parseDNSServers(strings.NewReader(list), "servers.txt", true); err == nil ||
+		!strings.HasPrefix(err.Error(), "servers.txt:1:") {
+		t.Errorf("strict parseDNSServers = %v, want an error on servers.txt:1", err)
+	}
+
+	servers, err := parseDNSServers(strings.NewReader(list), "servers.txt", false)
+	if err != nil || len(servers) != 0 {
+		t.Errorf("parseDNSServers = %v, %v, want the line skipped without an error", servers, err)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Latency metrics selectable with --latency-sla-metric
+const (
+	SLAMetricP95     = "p95"
+	SLAMetricAverage = "avg"
+)
+
+// ServerSLA represents whether a server met the --latency-sla threshold
+type ServerSLA struct {
+	Server  DNSServer     `json:"server"`
+	Latency time.Duration `json:"latency_ms"` // p95 or average of the successful responses
+	Met     bool          `json:"met"`
+	Over    time.Duration `json:"over_ms,omitempty"` // How far the latency exceeds the threshold
+}
+
+// SLAReport represents the latency SLA outcome of every server
+type SLAReport struct {
+	Threshold time.Duration `json:"threshold_ms"`
+	Metric    string        `json:"metric"`
+	Met       int           `json:"met"`
+	Servers   []ServerSLA   `json:"servers"`
+}
+
+// evaluateSLA checks every server's p95 or average response time against the
+// threshold. Servers without a successful response miss the SLA.
+func evaluateSLA(results []TestResult, threshold time.Duration, metric, percentileMethod string) *SLAReport {
+	report := &SLAReport{
+		Threshold: threshold,
+		Metric:    metric,
+	}
+
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	for _, server := range servers {
+		var times []time.Duration
+		for _, result := range byServer[server] {
+			if result.Success {
+				times = append(times, result.ResponseTime)
+			}
+		}
+
+		sla := ServerSLA{Server: server}
+		if len(times) > 0 {
+			if metric == SLAMetricAverage {
+				sla.Latency = averageResponseTime(byServer[server])
+			} else {
+				sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+				sla.Latency = percentile(times, 95, percentileMethod)
+			}
+			sla.Met = sla.Latency <= threshold
+			if !sla.Met {
+				sla.Over = sla.Latency - threshold
+			}
+		}
+
+		if sla.Met {
+			report.Met++
+		}
+		report.Servers = append(report.Servers, sla)
+	}
+
+	return report
+}
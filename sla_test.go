@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateSLA(t *testing.T) {
+	ms := time.Millisecond
+	fast := DNSServer{IP: "192.0.2.1"}
+	spiky := DNSServer{IP: "192.0.2.2"}
+	down := DNSServer{IP: "192.0.2.3"}
+
+	var results []TestResult
+	for i := 0; i < 20; i++ {
+		results = append(results, TestResult{Server: fast, Success: true, ResponseTime: 10 * ms})
+		// One slow answer in ten keeps the average low but the p95 high
+		latency := 10 * ms
+		if i%10 == 0 {
+			latency = 200 * ms
+		}
+		results = append(results, TestResult{Server: spiky, Success: true, ResponseTime: latency})
+		results = append(results, TestResult{Server: down, Error: "timeout"})
+	}
+
+	tests := []struct {
+		metric string
+		met    []bool
+	}{
+		{SLAMetricP95, []bool{true, false, false}},
+		{SLAMetricAverage, []bool{true, true, false}},
+	}
+	for _, tt := range tests {
+		report := evaluateSLA(results, 50*ms, tt.metric, PercentileNearestRank)
+		if report.Metric != tt.metric || report.Threshold != 50*ms || len(report.Servers) != 3 {
+			t.Fatalf("%s report = %+v, want 3 servers against 50ms", tt.metric, report)
+		}
+		met := 0
+		for i, server := range report.Servers {
+			if server.Met != tt.met[i] {
+				t.Errorf("%s: %s met = %v, want %v", tt.metric, server.Server.IP, server.Met, tt.met[i])
+			}
+			if server.Met {
+				met++
+			} else if server.Latency > 0 && server.Over != server.Latency-50*ms {
+				t.Errorf("%s: %s over by %v, want %v", tt.metric, server.Server.IP, server.Over, server.Latency-50*ms)
+			}
+		}
+		if report.Met != met {
+			t.Errorf("%s: report.Met = %d, want %d", tt.metric, report.Met, met)
+		}
+	}
+
+	// The average of the spiky server is (18*10 + 2*200) / 20 = 29ms
+	report := evaluateSLA(results, 50*ms, SLAMetricAverage, PercentileLinear)
+	if report.Servers[1].Latency != 29*ms || report.Servers[2].Latency != 0 {
+		t.Errorf("average latencies = %v and %v, want 29ms and none", report.Servers[1].Latency, report.Servers[2].Latency)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/miekg/dns"
+)
+
+// SOAInfo holds the zone timers of an SOA record
+type SOAInfo struct {
+	Serial  uint32 `json:"serial"`
+	Refresh uint32 `json:"refresh"`
+	Expire  uint32 `json:"expire"`
+}
+
+// SerialMismatch represents a domain whose SOA serial differs across servers
+type SerialMismatch struct {
+	Domain  string              `json:"domain"`
+	Serials map[uint32][]string `json:"serials"` // Serial -> server IPs returning it
+}
+
+// extractSOA returns the first SOA record of the answer section, if any
+func extractSOA(answers []dns.RR) *SOAInfo {
+	for _, answer := range answers {
+		if soa, ok := answer.(*dns.SOA); ok {
+			return &SOAInfo{
+				Serial:  soa.Serial,
+				Refresh: soa.Refresh,
+				Expire:  soa.Expire,
+			}
+		}
+	}
+	return nil
+}
+
+// findSerialMismatches flags domains for which servers returned different
+// SOA serials, which usually means a secondary is lagging behind
+func findSerialMismatches(results []TestResult) []SerialMismatch {
+	serials := make(map[string]map[uint32][]string)
+	for _, result := range results {
+		if !result.Success || result.SOA == nil {
+			continue
+		}
+		if serials[result.Domain] == nil {
+			serials[result.Domain] = make(map[uint32][]string)
+		}
+		serials[result.Domain][result.SOA.Serial] = append(serials[result.Domain][result.SOA.Serial], result.Server.IP)
+	}
+
+	var mismatches []SerialMismatch
+	for domain, bySerial := range serials {
+		if len(bySerial) > 1 {
+			mismatches = append(mismatches, SerialMismatch{Domain: domain, Serials: bySerial})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Domain < mismatches[j].Domain
+	})
+
+	return mismatches
+}
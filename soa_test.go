@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestExtractSOA(t *testing.T) {
+	answers := []dns.RR{
+		&dns.A{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET}, A: net.ParseIP("192.0.2.1")},
+		&dns.SOA{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET}, Serial: 2024010101, Refresh: 7200, Expire: 1209600},
+	}
+	want := &SOAInfo{Serial: 2024010101, Refresh: 7200, Expire: 1209600}
+	if got := extractSOA(answers); got == nil || *got != *want {
+		t.Errorf("extractSOA = %+v, want %+v", got, want)
+	}
+	if got := extractSOA(answers[:1]); got != nil {
+		t.Errorf("extractSOA without an SOA = %+v, want nil", got)
+	}
+}
+
+func TestFindSerialMismatches(t *testing.T) {
+	soa := func(server string, domain string, serial uint32) TestResult {
+		return TestResult{Server: DNSServer{IP: server}, Domain: domain, Success: true, SOA: &SOAInfo{Serial: serial}}
+	}
+	results := []TestResult{
+		soa("1.1.1.1", "b.example", 7),
+		soa("8.8.8.8", "b.example", 6),
+		soa("9.9.9.9", "b.example", 7),
+		soa("1.1.1.1", "a.example", 3),
+		soa("8.8.8.8", "a.example", 4),
+		soa("1.1.1.1", "same.example", 1),
+		soa("8.8.8.8", "same.example", 1),
+		{Server: DNSServer{IP: "9.9.9.9"}, Domain: "same.example", Error: "timeout"},
+	}
+
+	want := []SerialMismatch{
+		{Domain: "a.example", Serials: map[uint32][]string{3: {"1.1.1.1"}, 4: {"8.8.8.8"}}},
+		{Domain: "b.example", Serials: map[uint32][]string{7: {"1.1.1.1", "9.9.9.9"}, 6: {"8.8.8.8"}}},
+	}
+	if got := findSerialMismatches(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("findSerialMismatches = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseQueryType(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    uint16
+		wantErr bool
+	}{
+		{"A", dns.TypeA, false},
+		{"soa", dns.TypeSOA, false},
+		{" SOA ", dns.TypeSOA, false},
+		{"txt", dns.TypeTXT, false},
+		{"any", dns.TypeANY, false},
+		{"MX", dns.TypeMX, false},
+		{"aaaa", dns.TypeAAAA, false},
+		{"PTR", dns.TypePTR, false},
+		{"SRV", 0, true},
+		{"bogus", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseQueryType(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseQueryType(%q) = %d, %v, want %d, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
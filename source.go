@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"net"
+)
+
+// sourceAddress returns the local IP queries are sent from, given either
+// --source-ip or --interface. An interface stands for its first IPv4 address,
+// or its first global IPv6 address when it has no IPv4 one. It returns nil
+// when neither is set, leaving the choice to the OS.
+func sourceAddress(sourceIP, iface string) (net.IP, error) {
+	switch {
+	case sourceIP != "" && iface != "":
+		return nil, fmt.Errorf("--source-ip and --interface cannot be combined")
+	case sourceIP != "":
+		ip := net.ParseIP(sourceIP)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid source IP address: %s", sourceIP)
+		}
+		return ip, nil
+	case iface != "":
+		return interfaceAddress(iface)
+	}
+	return nil, nil
+}
+
+func interfaceAddress(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var v6 net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if v6 == nil && !ipNet.IP.IsLinkLocalUnicast() {
+			v6 = ipNet.IP
+		}
+	}
+	if v6 == nil {
+		return nil, fmt.Errorf("interface %s has no usable IP address", name)
+	}
+	return v6, nil
+}
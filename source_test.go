@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestSourceAddress(t *testing.T) {
+	tests := []struct {
+		sourceIP, iface string
+		want            string
+		wantErr         bool
+	}{
+		{"", "", "<nil>", false},
+		{"192.0.2.1", "", "192.0.2.1", false},
+		{"2001:db8::1", "", "2001:db8::1", false},
+		{"not-an-ip", "", "", true},
+		{"192.0.2.1", "lo", "", true},
+		{"", "lo", "127.0.0.1", false},
+		{"", "no-such-interface0", "", true},
+	}
+	for _, tt := range tests {
+		ip, err := sourceAddress(tt.sourceIP, tt.iface)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("sourceAddress(%q, %q) error = %v, want error %v", tt.sourceIP, tt.iface, err, tt.wantErr)
+			continue
+		}
+		if err == nil && ip.String() != tt.want {
+			t.Errorf("sourceAddress(%q, %q) = %v, want %s", tt.sourceIP, tt.iface, ip, tt.want)
+		}
+	}
+}
+
+func TestSourceIPBindsQueries(t *testing.T) {
+	remote := make(chan string, 1)
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		host, _, _ := net.SplitHostPort(w.RemoteAddr().String())
+		select {
+		case remote <- host:
+		default:
+		}
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, SourceIP: net.ParseIP("127.0.0.5")}
+	result := testDNS(newDNSClient(opts), nil, DNSServer{IP: ip}, "example.com", opts)
+	if !result.Success || result.SourceIP != "127.0.0.5" {
+		t.Fatalf("result = success %v, source %q, want a success from 127.0.0.5 (error %q)", result.Success, result.SourceIP, result.Error)
+	}
+	if got := <-remote; got != "127.0.0.5" {
+		t.Errorf("server saw the query from %s, want 127.0.0.5", got)
+	}
+}
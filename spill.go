@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SpillRunSize is the number of results --spill-dir sorts in memory and
+// writes out as one run
+const SpillRunSize = 10000
+
+// resultSpill keeps the full results of a run on disk as sorted runs of
+// NDJSON, merged back in order when the ndjson output is written. The
+// summary still needs a compact copy of every result in memory, so this
+// reduces memory use without bounding it.
+type resultSpill struct {
+	dir     string
+	runs    []string
+	pending []TestResult
+}
+
+func newResultSpill(parent string) (*resultSpill, error) {
+	dir, err := os.MkdirTemp(parent, "dns-check-spill-*")
+	if err != nil {
+		return nil, err
+	}
+	return &resultSpill{dir: dir}, nil
+}
+
+// add buffers a result, writing the buffer out as a run once it is full
+func (s *resultSpill) add(result TestResult) error {
+	s.pending = append(s.pending, result)
+	if len(s.pending) >= SpillRunSize {
+		return s.flush()
+	}
+	return nil
+}
+
+// flush sorts the buffered results and writes them out as a run
+func (s *resultSpill) flush() error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	sort.Slice(s.pending, func(i, j int) bool { return resultBefore(s.pending[i], s.pending[j]) })
+
+	path := filepath.Join(s.dir, fmt.Sprintf("run-%d.ndjson", len(s.runs)))
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, result := range s.pending {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	s.runs = append(s.runs, path)
+	s.pending = nil
+	return file.Close()
+}
+
+// each calls fn for every spilled result in resultBefore order, merging the
+// runs so that only one result per run is in memory at a time
+func (s *resultSpill) each(fn func(TestResult) error) error {
+	if err := s.flush(); err != nil {
+		return err
+	}
+
+	var heads spillHeap
+	for _, path := range s.runs {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		head := &spillHead{decoder: json.NewDecoder(bufio.NewReader(file))}
+		if ok, err := head.next(); err != nil {
+			return err
+		} else if ok {
+			heads = append(heads, head)
+		}
+	}
+	heap.Init(&heads)
+
+	for heads.Len() > 0 {
+		head := heads[0]
+		if err := fn(head.result); err != nil {
+			return err
+		}
+
+		ok, err := head.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+	}
+	return nil
+}
+
+// remove deletes the spilled runs
+func (s *resultSpill) remove() {
+	os.RemoveAll(s.dir)
+}
+
+// spillHead is the next unmerged result of a run
+type spillHead struct {
+	decoder *json.Decoder
+	result  TestResult
+}
+
+func (h *spillHead) next() (bool, error) {
+	if !h.decoder.More() {
+		return false, nil
+	}
+	h.result = TestResult{}
+	return true, h.decoder.Decode(&h.result)
+}
+
+type spillHeap []*spillHead
+
+func (h spillHeap) Len() int           { return len(h) }
+func (h spillHeap) Less(i, j int) bool { return resultBefore(h[i].result, h[j].result) }
+func (h spillHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *spillHeap) Push(x any)        { *h = append(*h, x.(*spillHead)) }
+func (h *spillHeap) Pop() any {
+	old := *h
+	head := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return head
+}
+
+// compactResult drops the fields of a result that only the output needs, so
+// the copy kept in memory for the summary is small
+func compactResult(result TestResult) TestResult {
+	result.IP = ""
+	result.ResolvedIPs = nil
+	result.Country = ""
+	result.ASN = 0
+	result.ASOrg = ""
+	result.ServerCountry = ""
+	result.ResponseName = ""
+	result.Rcode = ""
+	result.SourceIP = ""
+	result.Samples = nil
+	result.Sections = nil
+	result.Error = ""
+	return result
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestResultSpillMergesRuns(t *testing.T) {
+	parent := t.TempDir()
+	spill, err := newResultSpill(parent)
+	if err != nil {
+		t.Fatalf("newResultSpill error = %v", err)
+	}
+
+	// Two runs, each sorted on its own, interleave once merged
+	runs := [][]string{{"8.8.8.8", "1.1.1.1", "9.9.9.9"}, {"2.2.2.2", "1.0.0.1"}}
+	for _, run := range runs {
+		for _, ip := range run {
+			if err := spill.add(TestResult{Server: DNSServer{IP: ip}, Domain: "example.com", IP: "192.0.2.1"}); err != nil {
+				t.Fatalf("add error = %v", err)
+			}
+		}
+		if err := spill.flush(); err != nil {
+			t.Fatalf("flush error = %v", err)
+		}
+	}
+	// A last, unflushed result is merged too
+	spill.add(TestResult{Server: DNSServer{IP: "1.1.1.2"}, Domain: "example.com"})
+
+	var order []string
+	err = spill.each(func(result TestResult) error {
+		order = append(order, result.Server.IP)
+		if result.Server.IP != "1.1.1.2" && result.IP != "192.0.2.1" {
+			t.Errorf("%s read back without its resolved IP", result.Server.IP)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("each error = %v", err)
+	}
+	if got := strings.Join(order, ","); got != "1.0.0.1,1.1.1.1,1.1.1.2,2.2.2.2,8.8.8.8,9.9.9.9" {
+		t.Errorf("merged order = %s, want every result sorted by server IP", got)
+	}
+
+	spill.remove()
+	if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+		t.Errorf("remove left %d entries in the spill directory", len(entries))
+	}
+}
+
+func TestRunDNSTestsSpill(t *testing.T) {
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		w.WriteMsg(answerA(r, "192.0.2.53"))
+	}))
+
+	opts := TestOptions{Timeout: 2 * time.Second, Workers: 2, QueryType: dns.TypeA, SpillDir: t.TempDir()}
+	domains := []DomainCategory{{Domain: "one.com"}, {Domain: "two.com"}}
+	results := runDNSTests([]DNSServer{{IP: ip}}, domains, opts)
+	defer results.spill.remove()
+
+	if results.Summary.SuccessfulTests != 2 {
+		t.Errorf("summary counts %d successful tests, want 2", results.Summary.SuccessfulTests)
+	}
+	for _, result := range results.Results {
+		if result.IP != "" {
+			t.Errorf("in-memory result of %s keeps its resolved IP, want it compacted", result.Domain)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeNDJSON(&buf, results); err != nil {
+		t.Fatalf("writeNDJSON error = %v", err)
+	}
+	if got := strings.Count(buf.String(), `"resolved_ip":"192.0.2.53"`); got != 2 {
+		t.Errorf("ndjson output holds %d full results, want 2:\n%s", got, buf.String())
+	}
+}
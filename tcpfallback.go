@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net"
+
+	"github.com/miekg/dns"
+)
+
+// tcpFallbackClient returns a client repeating over TCP the UDP queries whose
+// response came back truncated (TC bit), as stub resolvers do. It keeps the
+// timeout and source address of client.
+func tcpFallbackClient(client *dns.Client) *dns.Client {
+	tcp := &dns.Client{Net: "tcp", Timeout: client.Timeout}
+	if client.Dialer != nil {
+		if local, ok := client.Dialer.LocalAddr.(*net.UDPAddr); ok {
+			tcp.Dialer = &net.Dialer{LocalAddr: &net.TCPAddr{IP: local.IP}}
+		}
+	}
+	return tcp
+}
+
+// TruncatingServer represents a server whose UDP answers came back truncated
+// and were fetched again over TCP
+type TruncatingServer struct {
+	Server    DNSServer `json:"server"`
+	Fallbacks int       `json:"fallbacks"`
+}
+
+// truncatingServers lists the servers with TCP fallbacks, in result order
+func truncatingServers(results []TestResult) []TruncatingServer {
+	var servers []TruncatingServer
+	index := make(map[DNSServer]int)
+	for _, result := range results {
+		if !result.TCPFallback {
+			continue
+		}
+		i, seen := index[result.Server]
+		if !seen {
+			i = len(servers)
+			index[result.Server] = i
+			servers = append(servers, TruncatingServer{Server: result.Server})
+		}
+		servers[i].Fallbacks++
+	}
+	return servers
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestTestDNSTCPFallback(t *testing.T) {
+	// Over UDP the server only sets the TC bit; the answer comes over TCP
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		m.Truncated = true
+		w.WriteMsg(m)
+	}))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("cannot listen on TCP %s: %v", addr, err)
+	}
+	started := make(chan struct{})
+	tcpServer := &dns.Server{Listener: listener, NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })}
+	go tcpServer.ActivateAndServe()
+	t.Cleanup(func() { tcpServer.Shutdown() })
+	<-started
+
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP}
+	result := testDNS(newDNSClient(opts), nil, server, "example.com", opts)
+	if !result.Success || result.IP != "192.0.2.53" || !result.TCPFallback {
+		t.Fatalf("truncated query = success %v, %q, fallback %v, want the TCP answer", result.Success, result.IP, result.TCPFallback)
+	}
+
+	results := []TestResult{result, result, {Server: DNSServer{IP: "192.0.2.1"}, Success: true}}
+	if got := truncatingServers(results); len(got) != 1 || got[0].Server != server || got[0].Fallbacks != 2 {
+		t.Errorf("truncatingServers = %+v, want %s with 2 fallbacks", got, server.label())
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// loadTemplate parses the user-supplied --template file. Templates render the
+// TestResults struct and can use these helpers:
+//
+//	ms       formats a duration in milliseconds, e.g. {{ms .ResponseTime}}
+//	percent  formats a rate, e.g. {{percent .Summary.SuccessRate}}
+//	status   the OK/MISS/FAIL label of a result, colored like the text output
+//	color    wraps a string in red, green, yellow or bold, e.g. {{color "red" .Error}}
+func loadTemplate(path string, color bool) (*template.Template, error) {
+	colors := map[string]string{
+		"red":    colorRed,
+		"green":  colorGreen,
+		"yellow": colorYellow,
+		"bold":   colorBold,
+	}
+
+	funcs := template.FuncMap{
+		"ms": func(d time.Duration) string {
+			return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+		},
+		"percent": func(rate float64) string {
+			return fmt.Sprintf("%.2f%%", rate)
+		},
+		"status": func(result TestResult) string {
+			return strings.TrimSpace(resultStatus(result, color))
+		},
+		"color": func(name, s string) (string, error) {
+			code, ok := colors[name]
+			if !ok {
+				return "", fmt.Errorf("unknown color: %s", name)
+			}
+			return colorize(color, code, s), nil
+		},
+	}
+
+	return template.New(filepath.Base(path)).Funcs(funcs).ParseFiles(path)
+}
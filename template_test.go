@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadTemplate(t *testing.T) {
+	path := writeTestFile(t, "report.tmpl",
+		`{{range .Results}}{{.Domain}} {{status .}} {{ms .ResponseTime}}{{"\n"}}{{end}}`+
+			`{{percent .Summary.SuccessRate}} {{color "bold" "done"}}`)
+	results := TestResults{Results: []TestResult{
+		{Domain: "one.com", Success: true, ResponseTime: 1500 * time.Microsecond},
+		{Domain: "two.com", Error: "timeout"},
+	}}
+	results.Summary = calculateSummary(results.Results)
+
+	tmpl, err := loadTemplate(path, false)
+	if err != nil {
+		t.Fatalf("loadTemplate error = %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "report.txt")
+	if err := outputResults(results, OutputOptions{File: file, Format: "json", Template: tmpl}); err != nil {
+		t.Fatalf("outputResults error = %v", err)
+	}
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "one.com OK 1.50ms\ntwo.com FAIL 0.00ms\n50.00% done"
+	if string(got) != want {
+		t.Errorf("template rendered %q, want %q", got, want)
+	}
+
+	tmpl, err = loadTemplate(path, true)
+	if err != nil {
+		t.Fatalf("loadTemplate error = %v", err)
+	}
+	var colored strings.Builder
+	if err := tmpl.Execute(&colored, results); err != nil {
+		t.Fatalf("template error = %v", err)
+	}
+	if !strings.Contains(colored.String(), colorBold+"done"+colorReset) {
+		t.Errorf("colored template rendered %q, want the bold helper applied", colored.String())
+	}
+}
+
+func TestLoadTemplateErrors(t *testing.T) {
+	if _, err := loadTemplate(filepath.Join(t.TempDir(), "missing.tmpl"), false); err == nil {
+		t.Error("loadTemplate of a missing file succeeded, want an error")
+	}
+	if _, err := loadTemplate(writeTestFile(t, "bad.tmpl", "{{range}}"), false); err == nil {
+		t.Error("loadTemplate of a malformed template succeeded, want an error")
+	}
+
+	tmpl, err := loadTemplate(writeTestFile(t, "color.tmpl", `{{color "purple" "x"}}`), false)
+	if err != nil {
+		t.Fatalf("loadTemplate error = %v", err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, TestResults{}); err == nil {
+		t.Error("template with an unknown color rendered, want an error")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+// startTestServer runs handler as a UDP DNS server on addr and returns the
+// address it listens on. The test is skipped when addr can't be bound, e.g.
+// port 53 without the privilege.
+func startTestServer(t *testing.T, addr string, handler dns.Handler) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		t.Skipf("cannot listen on %s: %v", addr, err)
+	}
+
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: conn, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	return conn.LocalAddr().String()
+}
+
+// startPort53Server runs handler on port 53 of the first free loopback
+// address from 127.0.0.100 on, for code that always queries port 53, and
+// returns that address
+func startPort53Server(t *testing.T, handler dns.Handler) string {
+	t.Helper()
+
+	for i := 100; i < 200; i++ {
+		ip := fmt.Sprintf("127.0.0.%d", i)
+		conn, err := net.ListenPacket("udp", net.JoinHostPort(ip, "53"))
+		if err != nil {
+			continue
+		}
+		conn.Close()
+		host, _, _ := net.SplitHostPort(startTestServer(t, net.JoinHostPort(ip, "53"), handler))
+		return host
+	}
+	t.Skip("cannot listen on port 53 of any loopback address")
+	return ""
+}
+
+// answerA returns a reply to r holding an A record with ip for every A
+// question, and no answer for other types
+func answerA(r *dns.Msg, ip string) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.RecursionAvailable = true
+	if r.Question[0].Qtype == dns.TypeA {
+		m.Answer = append(m.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.ParseIP(ip),
+		})
+	}
+	return m
+}
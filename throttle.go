@@ -0,0 +1,69 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Polite mode presets, applied unless the corresponding flag is set explicitly
+const (
+	PoliteWorkers      = 10
+	PoliteQPS          = 20
+	PoliteMaxPerServer = 1
+	PoliteJitter       = 100 * time.Millisecond
+)
+
+// throttle enforces the global QPS limit, the per-server concurrency limit and
+// the random jitter added before each query
+type throttle struct {
+	ticker    *time.Ticker
+	perServer map[string]chan struct{}
+	jitter    time.Duration
+}
+
+func newThrottle(servers []DNSServer, opts TestOptions) *throttle {
+	t := &throttle{jitter: opts.Jitter}
+
+	if opts.QPS > 0 {
+		t.ticker = time.NewTicker(time.Second / time.Duration(opts.QPS))
+	}
+
+	if opts.MaxPerServer > 0 {
+		t.perServer = make(map[string]chan struct{})
+		for _, server := range servers {
+			if _, exists := t.perServer[server.IP]; !exists {
+				t.perServer[server.IP] = make(chan struct{}, opts.MaxPerServer)
+			}
+		}
+	}
+
+	return t
+}
+
+// acquire blocks until a query to server may be sent
+func (t *throttle) acquire(server DNSServer) {
+	if slots, ok := t.perServer[server.IP]; ok {
+		slots <- struct{}{}
+	}
+
+	if t.jitter > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(t.jitter))))
+	}
+
+	if t.ticker != nil {
+		<-t.ticker.C
+	}
+}
+
+// release frees the per-server slot taken by acquire
+func (t *throttle) release(server DNSServer) {
+	if slots, ok := t.perServer[server.IP]; ok {
+		<-slots
+	}
+}
+
+func (t *throttle) stop() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+	}
+}
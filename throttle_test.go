@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottlePerServer(t *testing.T) {
+	a, b := DNSServer{IP: "192.0.2.1"}, DNSServer{IP: "192.0.2.2"}
+	limiter := newThrottle([]DNSServer{a, b}, TestOptions{MaxPerServer: 1})
+	defer limiter.stop()
+
+	limiter.acquire(a)
+
+	// Another server isn't held up by the busy one
+	done := make(chan struct{})
+	go func() {
+		limiter.acquire(b)
+		limiter.release(b)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("acquire of an idle server blocked")
+	}
+
+	// The busy server gets its slot back only on release
+	acquired := make(chan struct{})
+	go func() {
+		limiter.acquire(a)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second acquire of a server with --max-per-server 1 didn't block")
+	case <-time.After(50 * time.Millisecond):
+	}
+	limiter.release(a)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("acquire didn't proceed after release")
+	}
+}
+
+func TestThrottleQPS(t *testing.T) {
+	limiter := newThrottle(nil, TestOptions{QPS: 100})
+	defer limiter.stop()
+
+	server := DNSServer{IP: "192.0.2.1"}
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		limiter.acquire(server)
+		limiter.release(server)
+	}
+	// 10 queries at 100 per second take at least 9 intervals of 10ms
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("10 queries at --qps 100 took %v, want at least 90ms", elapsed)
+	}
+}
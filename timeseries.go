@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeSeriesHeader lists the columns of the --timeseries-dir files
+var timeSeriesHeader = []string{"timestamp", "avg_ms", "success_rate"}
+
+// timeSeriesName maps a server label to a file name, replacing the
+// characters of IPv6 addresses, ports and hostnames that don't belong in one
+var timeSeriesName = strings.NewReplacer(":", "_", "[", "", "]", "", "/", "_")
+
+// appendTimeSeries appends one row per server to <dir>/<ip>.csv with the
+// average response time and success rate of this cycle. Each row is a single
+// append, and a new file is created together with its header, so several
+// runs can share the directory.
+func appendTimeSeries(dir string, timestamp time.Time, results []TestResult) error {
+	var servers []DNSServer
+	byServer := make(map[DNSServer][]TestResult)
+	for _, result := range results {
+		if _, seen := byServer[result.Server]; !seen {
+			servers = append(servers, result.Server)
+		}
+		byServer[result.Server] = append(byServer[result.Server], result)
+	}
+
+	for _, server := range servers {
+		serverResults := byServer[server]
+		stats := groupStats(serverResults)
+		row := []string{
+			timestamp.Format(time.RFC3339),
+			formatMilliseconds(averageResponseTime(serverResults)),
+			strconv.FormatFloat(stats.SuccessRate, 'f', 2, 64),
+		}
+		path := filepath.Join(dir, timeSeriesName.Replace(server.label())+".csv")
+		if err := appendTimeSeriesRow(path, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendTimeSeriesRow writes row to the end of path. Whoever creates the file
+// writes the header with its row, in one write.
+func appendTimeSeriesRow(path string, row []string) error {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err == nil {
+		writer.Write(timeSeriesHeader)
+	} else if errors.Is(err, os.ErrExist) {
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer.Write(row)
+	writer.Flush()
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return file.Close()
+}
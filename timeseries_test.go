@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendTimeSeries(t *testing.T) {
+	dir := t.TempDir()
+	v4 := DNSServer{IP: "192.0.2.1"}
+	v6 := DNSServer{IP: "2001:db8::1", Port: "5353"}
+
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cycles := [][]TestResult{
+		{
+			{Server: v4, Success: true, ResponseTime: 10 * time.Millisecond},
+			{Server: v4, Error: "timeout"},
+			{Server: v6, Success: true, ResponseTime: 1500 * time.Microsecond},
+		},
+		{
+			{Server: v4, Success: true, ResponseTime: 20 * time.Millisecond},
+		},
+	}
+	for i, results := range cycles {
+		if err := appendTimeSeries(dir, first.Add(time.Duration(i)*time.Minute), results); err != nil {
+			t.Fatalf("cycle %d: appendTimeSeries error = %v", i, err)
+		}
+	}
+
+	files := map[string]string{
+		"192.0.2.1.csv": "timestamp,avg_ms,success_rate\n" +
+			"2024-01-02T03:04:05Z,10.000,50.00\n" +
+			"2024-01-02T03:05:05Z,20.000,100.00\n",
+		"2001_db8__1_5353.csv": "timestamp,avg_ms,success_rate\n" +
+			"2024-01-02T03:04:05Z,1.500,100.00\n",
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s =\n%s\nwant\n%s", name, got, want)
+		}
+	}
+}
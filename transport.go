@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// TransportDelta represents the latency of a server over UDP and over TCP,
+// measured on the same domains
+type TransportDelta struct {
+	Server       DNSServer     `json:"server"`
+	UDPAverage   time.Duration `json:"udp_average_ms"`
+	TCPAverage   time.Duration `json:"tcp_average_ms,omitempty"`
+	Delta        time.Duration `json:"delta_ms,omitempty"` // TCP minus UDP average, the penalty of falling back to TCP
+	UDPSuccesses int           `json:"udp_successes"`
+	TCPSuccesses int           `json:"tcp_successes"`
+	Queries      int           `json:"queries"` // Queries per transport
+	TCPAvailable bool          `json:"tcp_available"`
+}
+
+// measureTransportDelta queries every domain on every server over UDP and
+// then over TCP. Each TCP query opens its own connection, so the TCP
+// latency includes the handshake a client falling back to TCP pays.
+func measureTransportDelta(servers []DNSServer, domains []DomainCategory, opts TestOptions) []TransportDelta {
+	fmt.Fprintf(infoOutput, "Comparing UDP and TCP latency of %d DNS servers...\n", len(servers))
+
+	udpClient := newDNSClient(TestOptions{Timeout: opts.Timeout, SourceIP: opts.SourceIP, Protocol: ProtocolUDP})
+	tcpClient := newDNSClient(TestOptions{Timeout: opts.Timeout, SourceIP: opts.SourceIP, Protocol: ProtocolTCP})
+
+	// query returns the response time, or false when the query failed
+	query := func(client *dns.Client, server DNSServer, domain string) (time.Duration, bool) {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+		msg.RecursionDesired = !opts.NoRecurse
+
+		ctx, cancel := context.WithTimeout(opts.context(), opts.queryTimeout(server))
+		defer cancel()
+		start := time.Now()
+		response, _, err := client.ExchangeContext(ctx, msg, server.address("53"))
+		elapsed := time.Since(start)
+		return elapsed, err == nil && response.Rcode == dns.RcodeSuccess
+	}
+
+	deltas := make([]TransportDelta, len(servers))
+	jobs := make(chan int, len(servers))
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range jobs {
+				server := servers[s]
+				delta := TransportDelta{Server: server, Queries: len(domains)}
+				var udpTotal, tcpTotal time.Duration
+				for _, domain := range domains {
+					if elapsed, ok := query(udpClient, server, domain.Domain); ok {
+						delta.UDPSuccesses++
+						udpTotal += elapsed
+					}
+					if elapsed, ok := query(tcpClient, server, domain.Domain); ok {
+						delta.TCPSuccesses++
+						tcpTotal += elapsed
+					}
+				}
+
+				if delta.UDPSuccesses > 0 {
+					delta.UDPAverage = udpTotal / time.Duration(delta.UDPSuccesses)
+				}
+				if delta.TCPSuccesses > 0 {
+					delta.TCPAvailable = true
+					delta.TCPAverage = tcpTotal / time.Duration(delta.TCPSuccesses)
+					if delta.UDPSuccesses > 0 {
+						delta.Delta = delta.TCPAverage - delta.UDPAverage
+					}
+				}
+				deltas[s] = delta
+			}
+		}()
+	}
+	for s := range servers {
+		jobs <- s
+	}
+	close(jobs)
+	wg.Wait()
+
+	return deltas
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestMeasureTransportDelta(t *testing.T) {
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) { w.WriteMsg(answerA(r, "192.0.2.53")) })
+
+	// One server answers over UDP only, the other over both transports
+	_, udpOnlyPort, _ := net.SplitHostPort(startTestServer(t, "127.0.0.1:0", handler))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on TCP: %v", err)
+	}
+	started := make(chan struct{})
+	tcpServer := &dns.Server{Listener: listener, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go tcpServer.ActivateAndServe()
+	t.Cleanup(func() { tcpServer.Shutdown() })
+	<-started
+	_, bothPort, _ := net.SplitHostPort(listener.Addr().String())
+	startTestServer(t, net.JoinHostPort("127.0.0.1", bothPort), handler)
+
+	servers := []DNSServer{{IP: "127.0.0.1", Port: udpOnlyPort}, {IP: "127.0.0.1", Port: bothPort}}
+	domains := []DomainCategory{{Domain: "a.example"}, {Domain: "b.example"}}
+	deltas := measureTransportDelta(servers, domains, TestOptions{Timeout: time.Second, Workers: 2})
+
+	if len(deltas) != 2 {
+		t.Fatalf("got %d deltas, want 2", len(deltas))
+	}
+	if d := deltas[0]; d.Server != servers[0] || d.TCPAvailable || d.UDPSuccesses != 2 || d.TCPSuccesses != 0 || d.Queries != 2 {
+		t.Errorf("UDP-only server = %+v, want 2 UDP answers and TCP unavailable", d)
+	}
+	if d := deltas[1]; d.Server != servers[1] || !d.TCPAvailable || d.UDPSuccesses != 2 || d.TCPSuccesses != 2 || d.Delta != d.TCPAverage-d.UDPAverage {
+		t.Errorf("dual-transport server = %+v, want both transports and their delta", d)
+	}
+}
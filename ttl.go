@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// answerTTL returns the TTL of the first record of the queried type
+func answerTTL(answers []dns.RR, qtype uint16) (uint32, bool) {
+	for _, answer := range answers {
+		if answer.Header().Rrtype == qtype {
+			return answer.Header().Ttl, true
+		}
+	}
+	return 0, false
+}
+
+// probeMinTTL compares the TTL a server returns for domain with the
+// authoritative TTL. A higher TTL means the server raises low TTLs to a
+// minimum of its own, which is then at least the returned value.
+func probeMinTTL(domain string, authTTL uint32) serverProbe {
+	return func(server DNSServer, opts TestOptions, profile *ServerProfile) {
+		client := newDNSClient(opts)
+
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+
+		response, _, err := exchangeServer(client, msg, server, opts)
+		if err != nil {
+			profile.addError("min ttl", err)
+			return
+		}
+
+		ttl, ok := answerTTL(response.Answer, dns.TypeA)
+		if !ok {
+			return
+		}
+
+		raised := ttl > authTTL
+		profile.ObservedTTL = ttl
+		profile.AuthoritativeTTL = authTTL
+		profile.TTLRaised = &raised
+	}
+}
+
+// authoritativeTTL looks up the TTL the zone's own nameserver gives for the A
+// record of domain. The nameserver is found through resolver; its answer is
+// queried directly with recursion off, so no cache can alter the TTL.
+func authoritativeTTL(domain string, resolver DNSServer, timeout time.Duration) (uint32, error) {
+	client := &dns.Client{
+		Timeout: timeout,
+	}
+	exchange := func(name string, qtype uint16, server string, recurse bool) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(name), qtype)
+		msg.RecursionDesired = recurse
+		response, _, err := client.Exchange(msg, net.JoinHostPort(server, "53"))
+		return response, err
+	}
+
+	// Walk up the labels until the zone apex with NS records is found
+	var nameserver string
+	labels := dns.SplitDomainName(domain)
+	for i := 0; i < len(labels) && nameserver == ""; i++ {
+		response, err := exchange(strings.Join(labels[i:], "."), dns.TypeNS, resolver.IP, true)
+		if err != nil {
+			return 0, err
+		}
+		for _, answer := range response.Answer {
+			if ns, ok := answer.(*dns.NS); ok {
+				nameserver = ns.Ns
+				break
+			}
+		}
+	}
+	if nameserver == "" {
+		return 0, fmt.Errorf("no nameserver found for %s", domain)
+	}
+
+	response, err := exchange(nameserver, dns.TypeA, resolver.IP, true)
+	if err != nil {
+		return 0, err
+	}
+	var nameserverIP string
+	for _, answer := range response.Answer {
+		if a, ok := answer.(*dns.A); ok {
+			nameserverIP = a.A.String()
+			break
+		}
+	}
+	if nameserverIP == "" {
+		return 0, fmt.Errorf("cannot resolve nameserver %s", nameserver)
+	}
+
+	response, err = exchange(domain, dns.TypeA, nameserverIP, false)
+	if err != nil {
+		return 0, err
+	}
+	ttl, ok := answerTTL(response.Answer, dns.TypeA)
+	if !ok {
+		return 0, fmt.Errorf("nameserver %s has no A record for %s", nameserver, domain)
+	}
+	return ttl, nil
+}
+
+// negativeTTL returns how long a negative answer may be cached (RFC 2308):
+// the lower of the TTL and the MINIMUM field of the SOA record in the
+// authority section. It reports false when there is no SOA record.
+func negativeTTL(response *dns.Msg) (uint32, bool) {
+	for _, ns := range response.Ns {
+		if soa, ok := ns.(*dns.SOA); ok {
+			if soa.Hdr.Ttl < soa.Minttl {
+				return soa.Hdr.Ttl, true
+			}
+			return soa.Minttl, true
+		}
+	}
+	return 0, false
+}
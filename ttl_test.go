@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestAnswerTTL(t *testing.T) {
+	answers := []dns.RR{
+		&dns.CNAME{Hdr: dns.RR_Header{Name: "www.example.", Rrtype: dns.TypeCNAME, Ttl: 3600}, Target: "example."},
+		&dns.A{Hdr: dns.RR_Header{Name: "example.", Rrtype: dns.TypeA, Ttl: 60}},
+	}
+	if ttl, ok := answerTTL(answers, dns.TypeA); ttl != 60 || !ok {
+		t.Errorf("answerTTL(A) = %d, %v, want the A record's 60", ttl, ok)
+	}
+	if ttl, ok := answerTTL(answers, dns.TypeTXT); ttl != 0 || ok {
+		t.Errorf("answerTTL(TXT) = %d, %v, want nothing", ttl, ok)
+	}
+}
+
+// ttlServer acts as both a resolver and the authoritative server of
+// low.example: recursive answers carry a raised TTL of 300, authoritative
+// ones the zone's TTL of 30
+func ttlServer(t *testing.T) string {
+	return startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		q := r.Question[0]
+		switch {
+		case q.Qtype == dns.TypeNS && q.Name == "low.example.":
+			m.Answer = append(m.Answer, &dns.NS{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: 3600}, Ns: "ns.low.example."})
+		case q.Qtype == dns.TypeA && q.Name == "ns.low.example.":
+			// The nameserver is this server itself
+			ip, _, _ := net.SplitHostPort(w.LocalAddr().String())
+			m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 3600}, A: net.ParseIP(ip)})
+		case q.Qtype == dns.TypeA && q.Name == "low.example.":
+			ttl := uint32(30)
+			if r.RecursionDesired {
+				ttl = 300
+			}
+			m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: ttl}, A: net.ParseIP("192.0.2.53")})
+		}
+		w.WriteMsg(m)
+	}))
+}
+
+func TestMinTTLProbe(t *testing.T) {
+	ip := ttlServer(t)
+	server := DNSServer{IP: ip}
+
+	authTTL, err := authoritativeTTL("low.example", server, 2*time.Second)
+	if err != nil || authTTL != 30 {
+		t.Fatalf("authoritativeTTL = %d, %v, want the zone's 30", authTTL, err)
+	}
+	if _, err := authoritativeTTL("missing.example", server, 2*time.Second); err == nil {
+		t.Error("authoritativeTTL of a name without nameservers succeeded, want an error")
+	}
+
+	var profile ServerProfile
+	probeMinTTL("low.example", authTTL)(server, TestOptions{Timeout: 2 * time.Second}, &profile)
+	if profile.TTLRaised == nil || !*profile.TTLRaised || profile.ObservedTTL != 300 || profile.AuthoritativeTTL != 30 {
+		t.Fatalf("profile = %+v, want TTL 30 raised to 300", profile)
+	}
+	var results TestResults
+	applyServerProfiles(&results, []ServerProfile{profile})
+	if results.Summary.TTLRaisingServers != 1 {
+		t.Errorf("TTLRaisingServers = %d, want 1", results.Summary.TTLRaisingServers)
+	}
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA}
+	if result := testDNS(newDNSClient(opts), nil, server, "low.example", opts); result.TTL == nil || *result.TTL != 300 {
+		t.Errorf("testDNS recorded TTL %v, want 300", result.TTL)
+	}
+}
+
+func TestNegativeTTL(t *testing.T) {
+	soa := func(ttl, minttl uint32) dns.RR {
+		return &dns.SOA{Hdr: dns.RR_Header{Name: "example.", Rrtype: dns.TypeSOA, Ttl: ttl}, Minttl: minttl}
+	}
+	tests := []struct {
+		name   string
+		ns     []dns.RR
+		want   uint32
+		wantOK bool
+	}{
+		{"minimum is lower", []dns.RR{soa(3600, 300)}, 300, true},
+		{"ttl is lower", []dns.RR{soa(60, 300)}, 60, true},
+		{"no SOA", []dns.RR{&dns.NS{Hdr: dns.RR_Header{Name: "example.", Rrtype: dns.TypeNS}, Ns: "ns.example."}}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := negativeTTL(&dns.Msg{Ns: tt.ns})
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: negativeTTL = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestTestDNSNegativeTTL(t *testing.T) {
+	// missing.example does not exist, empty.example has no A record and
+	// found.example resolves; every answer carries the zone's SOA
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		name := r.Question[0].Name
+		switch name {
+		case "missing.example.":
+			m.Rcode = dns.RcodeNameError
+		case "found.example.":
+			m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60}, A: net.ParseIP("192.0.2.53")})
+		}
+		m.Ns = append(m.Ns, &dns.SOA{
+			Hdr:    dns.RR_Header{Name: "example.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 3600},
+			Ns:     "ns.example.",
+			Mbox:   "hostmaster.example.",
+			Minttl: 300,
+		})
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP}
+	for domain, want := range map[string]uint32{"missing.example": 300, "empty.example": 300, "found.example": 0} {
+		if result := testDNS(newDNSClient(opts), nil, server, domain, opts); result.NegativeTTL != want {
+			t.Errorf("%s: NegativeTTL = %d, want %d", domain, result.NegativeTTL, want)
+		}
+	}
+}
+
+func TestZeroTTLKept(t *testing.T) {
+	// zero.example is answered with a TTL of 0, empty.example without records
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		if r.Question[0].Name == "zero.example." {
+			m.Answer = append(m.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 0},
+				A:   net.ParseIP("192.0.2.53"),
+			})
+		}
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA, Protocol: ProtocolUDP}
+
+	zero := testDNS(newDNSClient(opts), nil, server, "zero.example", opts)
+	if zero.TTL == nil || *zero.TTL != 0 {
+		t.Errorf("zero.example TTL = %v, want an explicit 0", zero.TTL)
+	}
+	data, err := json.Marshal(zero)
+	if err != nil || !strings.Contains(string(data), `"ttl":0`) {
+		t.Errorf("JSON of a zero TTL = %s, %v, want \"ttl\":0", data, err)
+	}
+	if empty := testDNS(newDNSClient(opts), nil, server, "empty.example", opts); empty.TTL != nil {
+		t.Errorf("empty.example TTL = %d, want nil", *empty.TTL)
+	}
+}
+
+func TestNegativeTTLSkipsANY(t *testing.T) {
+	// Every answer holds only an A record and the zone's SOA, so MX queries
+	// get NODATA while ANY queries get a non-empty answer
+	addr := startTestServer(t, "127.0.0.1:0", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		name := r.Question[0].Name
+		if qtype := r.Question[0].Qtype; qtype == dns.TypeA || qtype == dns.TypeANY {
+			m.Answer = append(m.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+				A:   net.ParseIP("192.0.2.53"),
+			})
+		}
+		m.Ns = append(m.Ns, &dns.SOA{
+			Hdr:    dns.RR_Header{Name: name, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 3600},
+			Ns:     "ns." + name,
+			Mbox:   "hostmaster." + name,
+			Minttl: 300,
+		})
+		w.WriteMsg(m)
+	}))
+	_, port, _ := net.SplitHostPort(addr)
+	server := DNSServer{IP: "127.0.0.1", Port: port}
+
+	tests := []struct {
+		qtype uint16
+		want  uint32
+	}{
+		{dns.TypeMX, 300},
+		{dns.TypeANY, 0},
+		{dns.TypeA, 0},
+	}
+	for _, tt := range tests {
+		opts := TestOptions{Timeout: 2 * time.Second, QueryType: tt.qtype, Protocol: ProtocolUDP}
+		result := testDNS(newDNSClient(opts), nil, server, "example.com", opts)
+		if result.NegativeTTL != tt.want {
+			t.Errorf("%s query: NegativeTTL = %d, want %d", dns.TypeToString[tt.qtype], result.NegativeTTL, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// TXTMismatch represents a domain whose TXT content differs across servers
+type TXTMismatch struct {
+	Domain string              `json:"domain"`
+	Values map[string][]string `json:"values"` // TXT content -> server IPs returning it
+}
+
+// extractTXT returns the TXT records of the answer section as one string. The
+// strings of a record are concatenated as RFC 7208 does for SPF, and records
+// are sorted so the result doesn't depend on the answer order.
+func extractTXT(answers []dns.RR) (string, bool) {
+	var records []string
+	for _, answer := range answers {
+		if txt, ok := answer.(*dns.TXT); ok {
+			records = append(records, strings.Join(txt.Txt, ""))
+		}
+	}
+	if len(records) == 0 {
+		return "", false
+	}
+
+	sort.Strings(records)
+	return strings.Join(records, " | "), true
+}
+
+// findTXTMismatches flags domains for which servers returned different TXT
+// content, which points at tampering or stale caches
+func findTXTMismatches(results []TestResult) []TXTMismatch {
+	values := make(map[string]map[string][]string)
+	for _, result := range results {
+		if !result.Success || result.TXT == "" {
+			continue
+		}
+		if values[result.Domain] == nil {
+			values[result.Domain] = make(map[string][]string)
+		}
+		values[result.Domain][result.TXT] = append(values[result.Domain][result.TXT], result.Server.IP)
+	}
+
+	var mismatches []TXTMismatch
+	for domain, byValue := range values {
+		if len(byValue) > 1 {
+			mismatches = append(mismatches, TXTMismatch{Domain: domain, Values: byValue})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Domain < mismatches[j].Domain
+	})
+
+	return mismatches
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestExtractTXT(t *testing.T) {
+	txt := func(parts ...string) dns.RR {
+		return &dns.TXT{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeTXT}, Txt: parts}
+	}
+	tests := []struct {
+		name    string
+		answers []dns.RR
+		want    string
+		wantOK  bool
+	}{
+		{"none", nil, "", false},
+		{"other types only", []dns.RR{&dns.A{Hdr: dns.RR_Header{Rrtype: dns.TypeA}}}, "", false},
+		{"split strings", []dns.RR{txt("v=spf1 ", "-all")}, "v=spf1 -all", true},
+		{"sorted records", []dns.RR{txt("b"), txt("a")}, "a | b", true},
+	}
+	for _, tt := range tests {
+		got, ok := extractTXT(tt.answers)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: extractTXT = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFindTXTMismatches(t *testing.T) {
+	results := []TestResult{
+		{Server: DNSServer{IP: "192.0.2.1"}, Domain: "b.com", Success: true, TXT: "one"},
+		{Server: DNSServer{IP: "192.0.2.2"}, Domain: "b.com", Success: true, TXT: "two"},
+		{Server: DNSServer{IP: "192.0.2.3"}, Domain: "b.com", Success: true, TXT: "one"},
+		{Server: DNSServer{IP: "192.0.2.1"}, Domain: "a.com", Success: true, TXT: "x"},
+		{Server: DNSServer{IP: "192.0.2.2"}, Domain: "a.com", Success: true, TXT: "y"},
+		{Server: DNSServer{IP: "192.0.2.1"}, Domain: "same.com", Success: true, TXT: "z"},
+		{Server: DNSServer{IP: "192.0.2.2"}, Domain: "same.com", Success: true, TXT: "z"},
+		{Server: DNSServer{IP: "192.0.2.3"}, Domain: "same.com", Error: "timeout"},
+	}
+
+	mismatches := findTXTMismatches(results)
+	if len(mismatches) != 2 || mismatches[0].Domain != "a.com" || mismatches[1].Domain != "b.com" {
+		t.Fatalf("mismatches = %+v, want a.com and b.com in order", mismatches)
+	}
+	if servers := mismatches[1].Values["one"]; len(servers) != 2 || servers[0] != "192.0.2.1" || servers[1] != "192.0.2.3" {
+		t.Errorf("b.com servers returning \"one\" = %v, want 192.0.2.1 and 192.0.2.3", servers)
+	}
+}
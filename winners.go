@@ -0,0 +1,109 @@
+package main
+
+import "time"
+
+// sinkholeAddresses are the answers filtering resolvers commonly give for
+// blocked names
+var sinkholeAddresses = map[string]bool{
+	"0.0.0.0":   true,
+	"127.0.0.1": true,
+	"::":        true,
+}
+
+// blockingCategories are the categories where a blocked answer, rather than
+// a resolved one, is the outcome a split-DNS setup wants
+var blockingCategories = map[string]bool{
+	CategoryAdServer: true,
+	CategoryAdult:    true,
+}
+
+// LatencyPenaltyPerPoint is the average latency that costs one point of the
+// category score, capped at 100 points
+const LatencyPenaltyPerPoint = 10 * time.Millisecond
+
+// CategoryWinner represents the best scoring server of a category
+type CategoryWinner struct {
+	Category            string        `json:"category"`
+	Server              DNSServer     `json:"server"`
+	Score               float64       `json:"score"`
+	Rate                float64       `json:"rate"` // Percentage of resolved answers, or of blocked ones in blocking categories
+	AverageResponseTime time.Duration `json:"average_response_time_ms"`
+	Blocking            bool          `json:"blocking,omitempty"`
+}
+
+// categoryWinners ranks the servers within every category and returns the top
+// one per category. The score is the percentage of good outcomes minus one
+// point per LatencyPenaltyPerPoint of their average latency. A good outcome
+// is a blocked answer in the blocking categories, and a resolved, unblocked
+// one elsewhere. Ties go to the server tested first. It returns nil when
+// fewer than two servers were tested.
+func categoryWinners(results []TestResult) []CategoryWinner {
+	var servers []DNSServer
+	var categories []string
+	byPair := make(map[string]map[DNSServer][]TestResult)
+	for _, result := range results {
+		if _, seen := byPair[result.Category]; !seen {
+			categories = append(categories, result.Category)
+			byPair[result.Category] = make(map[DNSServer][]TestResult)
+		}
+		if !containsServer(servers, result.Server) {
+			servers = append(servers, result.Server)
+		}
+		byPair[result.Category][result.Server] = append(byPair[result.Category][result.Server], result)
+	}
+	if len(servers) < 2 {
+		return nil
+	}
+	sortCategories(categories)
+
+	var winners []CategoryWinner
+	for _, category := range categories {
+		blocking := blockingCategories[category]
+
+		var best *CategoryWinner
+		for _, server := range servers {
+			tests := byPair[category][server]
+			if len(tests) == 0 {
+				continue
+			}
+
+			good := 0
+			var totalTime time.Duration
+			for _, result := range tests {
+				if (blocking && result.Blocked) || (!blocking && result.Success && !result.Blocked) {
+					good++
+					totalTime += result.ResponseTime
+				}
+			}
+
+			candidate := CategoryWinner{
+				Category: category,
+				Server:   server,
+				Rate:     float64(good) / float64(len(tests)) * 100,
+				Blocking: blocking,
+			}
+			penalty := 100.0
+			if good > 0 {
+				candidate.AverageResponseTime = totalTime / time.Duration(good)
+				penalty = min(float64(candidate.AverageResponseTime)/float64(LatencyPenaltyPerPoint), 100)
+			}
+			candidate.Score = candidate.Rate - penalty
+
+			if best == nil || candidate.Score > best.Score {
+				best = &candidate
+			}
+		}
+		winners = append(winners, *best)
+	}
+
+	return winners
+}
+
+func containsServer(servers []DNSServer, server DNSServer) bool {
+	for _, s := range servers {
+		if s == server {
+			return true
+		}
+	}
+	return false
+}
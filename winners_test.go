@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestCategoryWinners(t *testing.T) {
+	open := DNSServer{IP: "1.1.1.1"}
+	filtering := DNSServer{IP: "94.140.14.14"}
+	ms := time.Millisecond
+	results := []TestResult{
+		// Both resolve, the open server faster
+		{Server: open, Category: CategoryGeneral, Success: true, ResponseTime: 10 * ms},
+		{Server: filtering, Category: CategoryGeneral, Success: true, ResponseTime: 30 * ms},
+		// Only the filtering server blocks
+		{Server: open, Category: CategoryAdServer, Success: true, ResponseTime: 5 * ms},
+		{Server: filtering, Category: CategoryAdServer, Success: true, Blocked: true, IP: "0.0.0.0", ResponseTime: 20 * ms},
+		// A sinkholed answer is not a resolved one
+		{Server: open, Category: "Custom", Success: true, Blocked: true, ResponseTime: ms},
+		{Server: filtering, Category: "Custom", Success: true, ResponseTime: 50 * ms},
+	}
+
+	winners := categoryWinners(results)
+	want := []struct {
+		category string
+		server   DNSServer
+		score    float64
+		blocking bool
+	}{
+		{CategoryGeneral, open, 99, false},
+		{CategoryAdServer, filtering, 98, true},
+		{"Custom", filtering, 95, false},
+	}
+	if len(winners) != len(want) {
+		t.Fatalf("categoryWinners = %+v, want %d winners", winners, len(want))
+	}
+	for i, w := range want {
+		got := winners[i]
+		if got.Category != w.category || got.Server != w.server || got.Score != w.score || got.Blocking != w.blocking {
+			t.Errorf("winner %d = %+v, want %s won by %s with score %v", i, got, w.category, w.server.IP, w.score)
+		}
+	}
+
+	if winners := categoryWinners(results[:1]); winners != nil {
+		t.Errorf("categoryWinners of a single server = %+v, want nil", winners)
+	}
+}
+
+func TestBlockedAnswers(t *testing.T) {
+	ip := startPort53Server(t, dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		switch r.Question[0].Name {
+		case "nx.example.":
+			m := new(dns.Msg)
+			m.SetRcode(r, dns.RcodeNameError)
+			w.WriteMsg(m)
+		case "sinkhole.example.":
+			w.WriteMsg(answerA(r, "0.0.0.0"))
+		default:
+			w.WriteMsg(answerA(r, "192.0.2.53"))
+		}
+	}))
+
+	opts := TestOptions{Timeout: 2 * time.Second, QueryType: dns.TypeA}
+	for _, tt := range []struct {
+		domain  string
+		blocked bool
+	}{
+		{"nx.example", true},
+		{"sinkhole.example", true},
+		{"open.example", false},
+	} {
+		result := testDNS(newDNSClient(opts), nil, DNSServer{IP: ip}, tt.domain, opts)
+		if result.Blocked != tt.blocked {
+			t.Errorf("%s: blocked = %v, want %v", tt.domain, result.Blocked, tt.blocked)
+		}
+	}
+}
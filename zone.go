@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// zoneMaxCNAMEHops bounds how far in-zone CNAME chains are followed
+const zoneMaxCNAMEHops = 8
+
+// ExpectedZoneReport represents the resolved results checked against the
+// records of --expected-zone and the answers outside them
+type ExpectedZoneReport struct {
+	File       string             `json:"file"`
+	Domains    int                `json:"domains"` // Tested domains with A/AAAA records in the zone
+	Mismatches []ExpectedMismatch `json:"mismatches,omitempty"`
+}
+
+// ExpectedMismatch represents a tested server answering a domain with an
+// address that isn't in the zone
+type ExpectedMismatch struct {
+	Server   DNSServer `json:"server"`
+	Domain   string    `json:"domain"`
+	Answer   string    `json:"answer"`
+	Expected []string  `json:"expected"`
+}
+
+// expectedZone holds the authoritative records of a zone file: the A and
+// AAAA RRsets by owner name, and the CNAMEs to follow to them
+type expectedZone struct {
+	file      string
+	addresses map[string]map[string]bool
+	cnames    map[string]string
+}
+
+// loadExpectedZone parses a zone file in master file format. $ORIGIN and
+// $TTL are honored; $INCLUDE is not.
+func loadExpectedZone(path string) (*expectedZone, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	zone := &expectedZone{
+		file:      path,
+		addresses: make(map[string]map[string]bool),
+		cnames:    make(map[string]string),
+	}
+
+	parser := dns.NewZoneParser(file, "", path)
+	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
+		name := strings.ToLower(rr.Header().Name)
+		switch record := rr.(type) {
+		case *dns.A:
+			zone.add(name, record.A.String())
+		case *dns.AAAA:
+			zone.add(name, record.AAAA.String())
+		case *dns.CNAME:
+			zone.cnames[name] = strings.ToLower(record.Target)
+		}
+	}
+	if err := parser.Err(); err != nil {
+		return nil, err
+	}
+	if len(zone.addresses) == 0 {
+		return nil, fmt.Errorf("%s: no A or AAAA records", path)
+	}
+	return zone, nil
+}
+
+func (z *expectedZone) add(name, address string) {
+	if z.addresses[name] == nil {
+		z.addresses[name] = make(map[string]bool)
+	}
+	z.addresses[name][address] = true
+}
+
+// expected returns the addresses the zone holds for domain, following CNAMEs
+// within the zone, or nil when the zone has none
+func (z *expectedZone) expected(domain string) map[string]bool {
+	name := strings.ToLower(dns.Fqdn(domain))
+	for hop := 0; hop <= zoneMaxCNAMEHops; hop++ {
+		if addresses, ok := z.addresses[name]; ok {
+			return addresses
+		}
+		target, ok := z.cnames[name]
+		if !ok {
+			return nil
+		}
+		name = target
+	}
+	return nil
+}
+
+// applyExpectedZone flags the resolved results answering an address outside
+// the zone's RRset for the domain. Failed results and domains missing from
+// the zone aren't judged.
+func applyExpectedZone(results *TestResults, zone *expectedZone, domains []DomainCategory) {
+	report := &ExpectedZoneReport{File: zone.file}
+	for _, domain := range domains {
+		if zone.expected(domain.Domain) != nil {
+			report.Domains++
+		}
+	}
+
+	for i := range results.Results {
+		result := &results.Results[i]
+		expected := zone.expected(result.Domain)
+		if expected == nil || !result.Success || result.IP == "" {
+			continue
+		}
+		if !expected[result.IP] {
+			result.ExpectedMismatch = true
+
+			var addresses []string
+			for address := range expected {
+				addresses = append(addresses, address)
+			}
+			sort.Strings(addresses)
+			report.Mismatches = append(report.Mismatches, ExpectedMismatch{
+				Server:   result.Server,
+				Domain:   result.Domain,
+				Answer:   result.IP,
+				Expected: addresses,
+			})
+		}
+	}
+	results.Summary.ExpectedZone = report
+}
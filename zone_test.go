@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testZone = `$ORIGIN example.com.
+$TTL 300
+@        IN SOA ns hostmaster 1 3600 600 86400 300
+@        IN A     192.0.2.1
+@        IN A     192.0.2.2
+www      IN CNAME @
+WWW2     IN CNAME www
+v6       IN AAAA  2001:db8::1
+mail     IN MX    10 mx
+`
+
+func TestLoadExpectedZone(t *testing.T) {
+	zone, err := loadExpectedZone(writeTestFile(t, "example.zone", testZone))
+	if err != nil {
+		t.Fatalf("loadExpectedZone error = %v", err)
+	}
+
+	tests := []struct {
+		domain string
+		want   map[string]bool
+	}{
+		{"example.com", map[string]bool{"192.0.2.1": true, "192.0.2.2": true}},
+		{"www2.example.com", map[string]bool{"192.0.2.1": true, "192.0.2.2": true}},
+		{"v6.example.com.", map[string]bool{"2001:db8::1": true}},
+		{"mail.example.com", nil},
+		{"example.org", nil},
+	}
+	for _, tt := range tests {
+		if got := zone.expected(tt.domain); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expected(%s) = %v, want %v", tt.domain, got, tt.want)
+		}
+	}
+
+	if _, err := loadExpectedZone(writeTestFile(t, "empty.zone", "$ORIGIN example.com.\nmail 300 IN MX 10 mx\n")); err == nil {
+		t.Error("loadExpectedZone of a zone without addresses succeeded, want an error")
+	}
+	if _, err := loadExpectedZone(writeTestFile(t, "broken.zone", "example.com. IN A not-an-address\n")); err == nil {
+		t.Error("loadExpectedZone of a malformed zone succeeded, want an error")
+	}
+}
+
+func TestApplyExpectedZone(t *testing.T) {
+	zone, err := loadExpectedZone(writeTestFile(t, "example.zone", testZone))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := DNSServer{IP: "192.0.2.53"}
+	results := TestResults{Results: []TestResult{
+		{Server: server, Domain: "example.com", Success: true, IP: "192.0.2.2"},
+		{Server: server, Domain: "www.example.com", Success: true, IP: "10.0.0.1"},
+		{Server: server, Domain: "www.example.com", Error: "timeout"},
+		{Server: server, Domain: "example.org", Success: true, IP: "10.0.0.1"},
+	}}
+	domains := []DomainCategory{{Domain: "example.com"}, {Domain: "www.example.com"}, {Domain: "example.org"}}
+
+	applyExpectedZone(&results, zone, domains)
+
+	for i, want := range []bool{false, true, false, false} {
+		if got := results.Results[i].ExpectedMismatch; got != want {
+			t.Errorf("result %d: ExpectedMismatch = %v, want %v", i, got, want)
+		}
+	}
+	report := results.Summary.ExpectedZone
+	if report == nil || report.Domains != 2 || len(report.Mismatches) != 1 {
+		t.Fatalf("report = %+v, want 2 domains and 1 mismatch", report)
+	}
+	if want := []string{"192.0.2.1", "192.0.2.2"}; !reflect.DeepEqual(report.Mismatches[0].Expected, want) {
+		t.Errorf("mismatch expected = %v, want %v", report.Mismatches[0].Expected, want)
+	}
+}